@@ -0,0 +1,104 @@
+package formulas
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/chadeldridge/rpgtools/incrementers"
+)
+
+// Derived is a value computed from a Formula. It tracks the values of the Formula's variables and only
+// recomputes when one of them changes, so dice terms are rolled once per change.
+type Derived struct {
+	formula Formula
+	vars    Vars
+	roll    Roller
+	names   []string
+	inputs  []int
+	value   int
+	err     error
+	valid   bool
+}
+
+// NewDerived creates a new Derived value from f. Every variable used by f must be in vars. The variables
+// are copied, so later changes to vars do not affect the Derived.
+func NewDerived(f Formula, vars Vars) (*Derived, error) {
+	names := f.Variables()
+	own := make(Vars, len(names))
+	for _, name := range names {
+		v, ok := vars[name]
+		if !ok || v == nil {
+			return nil, fmt.Errorf("unknown variable %s", name)
+		}
+
+		own[name] = v
+	}
+
+	return &Derived{formula: f, vars: own, roll: DefaultRoller, names: names}, nil
+}
+
+// Formula returns the Formula of the Derived value.
+func (d *Derived) Formula() Formula { return d.formula }
+
+// Sources returns the variables of the Formula in the order of Formula.Variables. A
+// DynamicIncrementer follows them to find binding cycles through the Derived value.
+func (d *Derived) Sources() []incrementers.Valuer {
+	sources := make([]incrementers.Valuer, len(d.names))
+	for i, name := range d.names {
+		sources[i] = d.vars[name]
+	}
+
+	return sources
+}
+
+// SetRoller sets the Roller used for dice terms and forces a recompute.
+func (d *Derived) SetRoller(roll Roller) { d.roll = roll; d.valid = false }
+
+// Changed returns true if any input has changed since the value was last computed.
+func (d *Derived) Changed() bool {
+	if !d.valid {
+		return true
+	}
+
+	return !slices.Equal(d.inputs, d.current())
+}
+
+// Eval returns the value of the Derived, recomputing it if any input has changed.
+func (d *Derived) Eval() (int, error) {
+	inputs := d.current()
+	if d.valid && slices.Equal(d.inputs, inputs) {
+		return d.value, d.err
+	}
+
+	d.inputs = inputs
+	d.value, d.err = d.formula.EvalWith(d.vars, d.roll)
+	d.valid = true
+
+	return d.value, d.err
+}
+
+// Value returns the value of the Derived, recomputing it if any input has changed. Returns 0 if the
+// Formula could not be evaluated; use Eval to get the error.
+func (d *Derived) Value() int {
+	v, err := d.Eval()
+	if err != nil {
+		return 0
+	}
+
+	return v
+}
+
+// Invalidate forces the value to be recomputed on next use, rerolling any dice.
+func (d *Derived) Invalidate() { d.valid = false }
+
+// String returns a string representation of the Derived value.
+func (d *Derived) String() string { return fmt.Sprintf("%d", d.Value()) }
+
+func (d *Derived) current() []int {
+	inputs := make([]int, len(d.names))
+	for i, name := range d.names {
+		inputs[i] = d.vars[name].Value()
+	}
+
+	return inputs
+}
@@ -0,0 +1,122 @@
+package formulas
+
+import (
+	"testing"
+
+	"github.com/chadeldridge/rpgtools"
+	"github.com/chadeldridge/rpgtools/incrementers"
+	"github.com/stretchr/testify/require"
+)
+
+var _ incrementers.Dependent = &Derived{}
+
+func TestDerivedNew(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		dex := incrementers.NewIncrementerWithValue(14)
+		d, err := NewDerived(MustParse("10 + (dex - 10) / 2"), Vars{"dex": &dex})
+		require.NoError(err, "NewDerived() returned an error: %s", err)
+		require.Equal("10 + (dex - 10) / 2", d.Formula().String())
+		require.Equal(12, d.Value())
+		require.Equal("12", d.String())
+	})
+
+	t.Run("unknown variable", func(t *testing.T) {
+		_, err := NewDerived(MustParse("dex + str"), Vars{"dex": incrementers.NewIncrementer()})
+		require.Error(err, "NewDerived() did not return an error")
+		require.Equal("unknown variable str", err.Error())
+	})
+
+	t.Run("copies vars", func(t *testing.T) {
+		dex := incrementers.NewIncrementerWithValue(14)
+		vars := Vars{"dex": &dex}
+		d, err := NewDerived(MustParse("dex + 1"), vars)
+		require.NoError(err, "NewDerived() returned an error: %s", err)
+
+		delete(vars, "dex")
+		require.Equal(15, d.Value())
+	})
+}
+
+func TestDerivedRecompute(t *testing.T) {
+	require := require.New(t)
+	dex := incrementers.NewIncrementerWithValue(14)
+	shield := incrementers.NewCounterWithValue(2)
+
+	rolls := 0
+	roller := func(pool int, die rpgtools.Die) int { rolls++; return rolls }
+
+	d, err := NewDerived(MustParse("10 + (dex - 10) / 2 + shield + d4"), Vars{"dex": &dex, "shield": shield})
+	require.NoError(err, "NewDerived() returned an error: %s", err)
+	d.SetRoller(roller)
+	require.True(d.Changed())
+
+	t.Run("computed once", func(t *testing.T) {
+		require.Equal(15, d.Value())
+		require.Equal(15, d.Value())
+		require.False(d.Changed())
+		require.Equal(1, rolls)
+	})
+
+	t.Run("input changed", func(t *testing.T) {
+		dex.Add(2)
+		require.True(d.Changed())
+		require.Equal(17, d.Value())
+		require.Equal(2, rolls)
+
+		shield.Empty()
+		require.Equal(16, d.Value())
+		require.Equal(3, rolls)
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		d.Invalidate()
+		require.Equal(17, d.Value())
+		require.Equal(4, rolls)
+	})
+}
+
+func TestDerivedChained(t *testing.T) {
+	require := require.New(t)
+	con := incrementers.NewIncrementerWithValue(14)
+
+	mod, err := NewDerived(MustParse("(con - 10) / 2"), Vars{"con": &con})
+	require.NoError(err, "NewDerived() returned an error: %s", err)
+
+	hp, err := NewDerived(MustParse("10 + mod * level"), Vars{"mod": mod, "level": incrementers.NewCounterWithValue(3)})
+	require.NoError(err, "NewDerived() returned an error: %s", err)
+	require.Equal(16, hp.Value())
+
+	con.SetValue(8)
+	require.Equal(7, hp.Value())
+}
+
+func TestDerivedEvalError(t *testing.T) {
+	require := require.New(t)
+	zero := incrementers.NewIncrementer()
+
+	d, err := NewDerived(MustParse("10 / zero"), Vars{"zero": &zero})
+	require.NoError(err, "NewDerived() returned an error: %s", err)
+
+	_, err = d.Eval()
+	require.Error(err, "Derived.Eval() did not return an error")
+	require.Equal(0, d.Value())
+
+	zero.SetValue(5)
+	v, err := d.Eval()
+	require.NoError(err, "Derived.Eval() returned an error: %s", err)
+	require.Equal(2, v)
+}
+
+func TestDerivedSources(t *testing.T) {
+	require := require.New(t)
+	con := incrementers.NewIncrementerWithValue(14)
+	hp := incrementers.NewDynamicIncrementer(incrementers.NewClampedIncrementerWithValue(0, 100, 50))
+	d, err := NewDerived(MustParse("hp + con"), Vars{"hp": hp, "con": &con})
+	require.NoError(err, "NewDerived() returned an error: %s", err)
+	require.Equal([]incrementers.Valuer{&con, hp}, d.Sources())
+
+	require.ErrorIs(hp.BindMax(incrementers.Sum, d), incrementers.ErrBindingCycle)
+	require.False(hp.IsMaxBound())
+}
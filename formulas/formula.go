@@ -0,0 +1,294 @@
+// Package formulas evaluates small arithmetic formulas such as "10 + dex_mod + shield" or "1d8 + str",
+// whose variables are incrementers and whose dice terms are rolled with rpgtools.
+//
+// Formulas support integer numbers, variables, dice terms (2d6, d20), the operators + - * / with the
+// usual precedence, parentheses, and the functions min, max and abs. Division rounds toward negative
+// infinity so (dex - 10) / 2 gives the usual ability modifier. Variable names start with a letter or
+// underscore and may contain letters, digits, underscores and dots. A name of d followed only by
+// digits is always a dice term. Dice pools are limited to MaxDicePool dice, and arithmetic or dice
+// whose result may not fit in an int return an error wrapping incrementers.ErrOverflow.
+package formulas
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/chadeldridge/rpgtools"
+	"github.com/chadeldridge/rpgtools/incrementers"
+)
+
+// MaxDicePool is the largest pool of dice a formula can roll.
+const MaxDicePool = 1000
+
+// Vars maps variable names to the incrementers that provide their values.
+type Vars map[string]incrementers.Valuer
+
+// Roller rolls a pool of dice and returns the sum.
+type Roller func(pool int, die rpgtools.Die) int
+
+// DefaultRoller rolls dice with rpgtools.DicePool.
+func DefaultRoller(pool int, die rpgtools.Die) int { return rpgtools.NewDicePool(pool).Roll(die).Sum }
+
+type env struct {
+	vars Vars
+	roll Roller
+}
+
+type node interface {
+	eval(e env) (int, error)
+	walk(fn func(node))
+}
+
+type numberNode int
+
+func (n numberNode) eval(env) (int, error) { return int(n), nil }
+func (n numberNode) walk(fn func(node))    { fn(n) }
+
+type varNode string
+
+func (n varNode) eval(e env) (int, error) {
+	v, ok := e.vars[string(n)]
+	if !ok || v == nil {
+		return 0, fmt.Errorf("unknown variable %s", string(n))
+	}
+
+	return v.Value(), nil
+}
+
+func (n varNode) walk(fn func(node)) { fn(n) }
+
+type diceNode struct {
+	pool  int
+	sides int
+}
+
+// eval rolls the dice, returning an error if their largest sum does not fit in an int.
+func (n diceNode) eval(e env) (int, error) {
+	if _, err := incrementers.MulChecked(n.pool, n.sides); err != nil {
+		return 0, err
+	}
+
+	return e.roll(n.pool, rpgtools.Die(n.sides)), nil
+}
+
+func (n diceNode) walk(fn func(node)) { fn(n) }
+
+type negNode struct {
+	n node
+}
+
+func (n negNode) eval(e env) (int, error) {
+	v, err := n.n.eval(e)
+	if err != nil {
+		return 0, err
+	}
+
+	return incrementers.SubChecked(0, v)
+}
+
+func (n negNode) walk(fn func(node)) { fn(n); n.n.walk(fn) }
+
+type binaryNode struct {
+	op    byte
+	left  node
+	right node
+}
+
+func (n binaryNode) eval(e env) (int, error) {
+	l, err := n.left.eval(e)
+	if err != nil {
+		return 0, err
+	}
+
+	r, err := n.right.eval(e)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.op {
+	case '+':
+		return incrementers.AddChecked(l, r)
+	case '-':
+		return incrementers.SubChecked(l, r)
+	case '*':
+		return incrementers.MulChecked(l, r)
+	}
+
+	if r == 0 {
+		return 0, fmt.Errorf("division by zero")
+	}
+
+	if r == -1 {
+		return incrementers.SubChecked(0, l)
+	}
+
+	q := l / r
+	if l%r != 0 && (l < 0) != (r < 0) {
+		q--
+	}
+
+	return q, nil
+}
+
+func (n binaryNode) walk(fn func(node)) { fn(n); n.left.walk(fn); n.right.walk(fn) }
+
+type function struct {
+	minArgs int
+	maxArgs int // 0 means no limit.
+	fn      func(args []int) (int, error)
+}
+
+var functions = map[string]function{
+	"min": {minArgs: 1, fn: func(args []int) (int, error) {
+		m := args[0]
+		for _, a := range args[1:] {
+			m = min(m, a)
+		}
+
+		return m, nil
+	}},
+	"max": {minArgs: 1, fn: func(args []int) (int, error) {
+		m := args[0]
+		for _, a := range args[1:] {
+			m = max(m, a)
+		}
+
+		return m, nil
+	}},
+	"abs": {minArgs: 1, maxArgs: 1, fn: func(args []int) (int, error) {
+		if args[0] < 0 {
+			return incrementers.SubChecked(0, args[0])
+		}
+
+		return args[0], nil
+	}},
+}
+
+type callNode struct {
+	name string
+	args []node
+}
+
+func (n callNode) eval(e env) (int, error) {
+	args := make([]int, len(n.args))
+	for i, a := range n.args {
+		v, err := a.eval(e)
+		if err != nil {
+			return 0, err
+		}
+
+		args[i] = v
+	}
+
+	return functions[n.name].fn(args)
+}
+
+func (n callNode) walk(fn func(node)) {
+	fn(n)
+	for _, a := range n.args {
+		a.walk(fn)
+	}
+}
+
+// Formula is a parsed formula.
+type Formula struct {
+	src  string
+	root node
+}
+
+// Parse parses src into a Formula. Returns a *ParseError if src is not a valid formula.
+func Parse(src string) (Formula, error) {
+	root, err := parse(src)
+	if err != nil {
+		return Formula{}, err
+	}
+
+	return Formula{src: src, root: root}, nil
+}
+
+// MustParse is like Parse but panics if src is not a valid formula.
+func MustParse(src string) Formula {
+	f, err := Parse(src)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+// String returns the source of the Formula.
+func (f Formula) String() string { return f.src }
+
+// Variables returns the sorted names of every variable used by the Formula.
+func (f Formula) Variables() []string {
+	seen := make(map[string]bool)
+	var names []string
+
+	if f.root == nil {
+		return names
+	}
+
+	f.root.walk(func(n node) {
+		if v, ok := n.(varNode); ok && !seen[string(v)] {
+			seen[string(v)] = true
+			names = append(names, string(v))
+		}
+	})
+
+	sort.Strings(names)
+	return names
+}
+
+// HasDice returns true if the Formula contains any dice terms.
+func (f Formula) HasDice() bool {
+	var dice bool
+	if f.root != nil {
+		f.root.walk(func(n node) {
+			if _, ok := n.(diceNode); ok {
+				dice = true
+			}
+		})
+	}
+
+	return dice
+}
+
+// Eval evaluates the Formula using vars for variables and DefaultRoller for dice.
+func (f Formula) Eval(vars Vars) (int, error) { return f.EvalWith(vars, DefaultRoller) }
+
+// EvalWith evaluates the Formula using vars for variables and roll for dice.
+func (f Formula) EvalWith(vars Vars, roll Roller) (int, error) {
+	if f.root == nil {
+		return 0, fmt.Errorf("empty formula")
+	}
+
+	return f.root.eval(env{vars: vars, roll: roll})
+}
+
+// MarshalJSON returns the source of the Formula as a JSON string.
+func (f Formula) MarshalJSON() ([]byte, error) { return json.Marshal(f.src) }
+
+// UnmarshalJSON parses a Formula from a JSON string.
+func (f *Formula) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("Formula.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" {
+		return nil
+	}
+
+	var src string
+	if err := json.Unmarshal(data, &src); err != nil {
+		return err
+	}
+
+	n, err := Parse(src)
+	if err != nil {
+		return err
+	}
+
+	*f = n
+	return nil
+}
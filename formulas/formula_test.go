@@ -0,0 +1,165 @@
+package formulas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/chadeldridge/rpgtools"
+	"github.com/chadeldridge/rpgtools/incrementers"
+	"github.com/stretchr/testify/require"
+)
+
+// maxRoller rolls the highest possible result so tests are deterministic.
+func maxRoller(pool int, die rpgtools.Die) int { return pool * int(die) }
+
+func TestFormulaEval(t *testing.T) {
+	require := require.New(t)
+	dex := incrementers.NewIncrementerWithValue(14)
+	shield := incrementers.NewCounterWithValue(2)
+	clock := incrementers.NewClockWithTicks(6, 4)
+	vars := Vars{"dex": dex, "shield": shield, "clock": clock}
+
+	for _, tc := range []struct {
+		src  string
+		want int
+	}{
+		{"42", 42},
+		{"10 + (dex - 10) / 2 + shield", 14},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"-dex + 20", 6},
+		{"--3", 3},
+		{"(9 - 10) / 2", -1},
+		{"-7 / 2", -4},
+		{"7 / -2", -4},
+		{"6 / 3", 2},
+		{"2d6 + d20", 32},
+		{"clock * 10", 40},
+		{"min(dex, 10, shield)", 2},
+		{"max(dex, 10)", 14},
+		{"abs(shield - dex)", 12},
+	} {
+		t.Run(tc.src, func(t *testing.T) {
+			f, err := Parse(tc.src)
+			require.NoError(err, "Parse() returned an error: %s", err)
+
+			got, err := f.EvalWith(vars, maxRoller)
+			require.NoError(err, "Formula.EvalWith() returned an error: %s", err)
+			require.Equal(tc.want, got)
+		})
+	}
+}
+
+func TestFormulaEvalErrors(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("unknown variable", func(t *testing.T) {
+		_, err := MustParse("str + 1").Eval(Vars{})
+		require.Error(err, "Formula.Eval() did not return an error")
+		require.Equal("unknown variable str", err.Error())
+	})
+
+	t.Run("division by zero", func(t *testing.T) {
+		_, err := MustParse("1 / (2 - 2)").Eval(nil)
+		require.Error(err, "Formula.Eval() did not return an error")
+		require.Equal("division by zero", err.Error())
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		big := incrementers.NewIncrementerWithValue(math.MaxInt)
+		small := incrementers.NewIncrementerWithValue(math.MinInt)
+		vars := Vars{"big": &big, "small": &small}
+		for _, src := range []string{
+			"big + 1", "small - 1", "big * 2", "-small", "small / -1", "abs(small)",
+			"abs(-9223372036854775807 - 1)", "1000d9223372036854775807",
+		} {
+			_, err := MustParse(src).Eval(vars)
+			require.ErrorIs(err, incrementers.ErrOverflow, "Formula.Eval(%s) did not return ErrOverflow", src)
+		}
+
+		v, err := MustParse("big - 1 + 1").Eval(vars)
+		require.NoError(err, "Formula.Eval() returned an error: %s", err)
+		require.Equal(math.MaxInt, v)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := Formula{}.Eval(nil)
+		require.Error(err, "Formula.Eval() did not return an error")
+		require.Equal("empty formula", err.Error())
+	})
+}
+
+func TestFormulaEvalDice(t *testing.T) {
+	require := require.New(t)
+	f := MustParse("3d6")
+	for range 50 {
+		v, err := f.Eval(nil)
+		require.NoError(err, "Formula.Eval() returned an error: %s", err)
+		require.GreaterOrEqual(v, 3)
+		require.LessOrEqual(v, 18)
+	}
+}
+
+func TestFormulaMustParse(t *testing.T) {
+	require := require.New(t)
+	require.Equal("1 + 2", MustParse("1 + 2").String())
+	require.Panics(func() { MustParse("1 +") })
+}
+
+func TestFormulaVariables(t *testing.T) {
+	require := require.New(t)
+	require.Equal([]string{"dex", "shield", "str"}, MustParse("str + max(dex, shield) - dex").Variables())
+	require.Empty(MustParse("1d6").Variables())
+	require.Empty(Formula{}.Variables())
+}
+
+func TestFormulaHasDice(t *testing.T) {
+	require := require.New(t)
+	require.True(MustParse("str + max(1, 1d4)").HasDice())
+	require.False(MustParse("str + 1").HasDice())
+	require.False(Formula{}.HasDice())
+}
+
+func TestFormulaMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	data, err := MustParse(`10 + dex`).MarshalJSON()
+	require.NoError(err, "Formula.MarshalJSON() returned an error: %s", err)
+	require.Equal(`"10 + dex"`, string(data))
+}
+
+func TestFormulaUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		var f Formula
+		require.NoError(f.UnmarshalJSON([]byte(`"10 + dex"`)))
+		require.Equal("10 + dex", f.String())
+		require.Equal([]string{"dex"}, f.Variables())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var f Formula
+		err := f.UnmarshalJSON(nil)
+		require.Error(err, "Formula.UnmarshalJSON() did not return an error")
+		require.Equal("Formula.UnmarshalJSON(): data was nil", err.Error())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var f Formula
+		require.NoError(f.UnmarshalJSON([]byte(`null`)))
+		require.Equal(Formula{}, f)
+	})
+
+	t.Run("not a string", func(t *testing.T) {
+		var f Formula
+		require.Error(f.UnmarshalJSON([]byte(`12`)))
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		var f Formula
+		err := f.UnmarshalJSON([]byte(`"10 +"`))
+		require.Error(err, "Formula.UnmarshalJSON() did not return an error")
+		require.Equal("column 5: unexpected end of formula", err.Error())
+	})
+}
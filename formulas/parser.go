@@ -0,0 +1,345 @@
+package formulas
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ParseError is returned when a formula can not be parsed.
+type ParseError struct {
+	Column int // 1 based position of the error in the formula.
+	Msg    string
+}
+
+// Error returns the error message with the position of the error.
+func (e *ParseError) Error() string { return fmt.Sprintf("column %d: %s", e.Column, e.Msg) }
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenDice
+	tokenIdent
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // 0 based offset of the token in the formula.
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isIdentStart(c byte) bool { return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+
+func isIdent(c byte) bool { return isIdentStart(c) || isDigit(c) || c == '.' }
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) && (l.src[l.pos] == ' ' || l.src[l.pos] == '\t') {
+		l.pos++
+	}
+
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case isDigit(c):
+		l.digits()
+		// A number followed by d and more digits is a dice term such as 2d6.
+		if l.pos+1 < len(l.src) && l.src[l.pos] == 'd' && isDigit(l.src[l.pos+1]) {
+			l.pos++
+			l.digits()
+			return l.dice(start)
+		}
+
+		return token{kind: tokenNumber, text: l.src[start:l.pos], pos: start}, nil
+	case isIdentStart(c):
+		for l.pos < len(l.src) && isIdent(l.src[l.pos]) {
+			l.pos++
+		}
+
+		text := l.src[start:l.pos]
+		// A lone d followed only by digits is a single die such as d20.
+		if len(text) > 1 && text[0] == 'd' && allDigits(text[1:]) {
+			return token{kind: tokenDice, text: text, pos: start}, nil
+		}
+
+		return token{kind: tokenIdent, text: text, pos: start}, nil
+	case c == '+' || c == '-' || c == '*' || c == '/':
+		l.pos++
+		return token{kind: tokenOp, text: string(c), pos: start}, nil
+	case c == '(':
+		l.pos++
+		return token{kind: tokenLParen, text: "(", pos: start}, nil
+	case c == ')':
+		l.pos++
+		return token{kind: tokenRParen, text: ")", pos: start}, nil
+	case c == ',':
+		l.pos++
+		return token{kind: tokenComma, text: ",", pos: start}, nil
+	}
+
+	return token{}, &ParseError{Column: start + 1, Msg: fmt.Sprintf("unexpected character %q", c)}
+}
+
+func (l *lexer) digits() {
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+}
+
+func (l *lexer) dice(start int) (token, error) {
+	if l.pos < len(l.src) && isIdent(l.src[l.pos]) {
+		return token{}, &ParseError{Column: l.pos + 1, Msg: fmt.Sprintf("unexpected character %q", l.src[l.pos])}
+	}
+
+	return token{kind: tokenDice, text: l.src[start:l.pos], pos: start}, nil
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type parser struct {
+	lex lexer
+	tok token
+}
+
+// parse parses src into a tree of nodes.
+func parse(src string) (node, error) {
+	p := &parser{lex: lexer{src: src}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokenEOF {
+		return nil, &ParseError{Column: 1, Msg: "empty formula"}
+	}
+
+	n, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind != tokenEOF {
+		return nil, p.unexpected()
+	}
+
+	return n, nil
+}
+
+func (p *parser) advance() error {
+	t, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+
+	p.tok = t
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return &ParseError{Column: p.tok.pos + 1, Msg: "unexpected end of formula"}
+	}
+
+	return &ParseError{Column: p.tok.pos + 1, Msg: fmt.Sprintf("unexpected %q", p.tok.text)}
+}
+
+// expr parses addition and subtraction.
+func (p *parser) expr() (node, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok.kind == tokenOp && (p.tok.text == "+" || p.tok.text == "-") {
+		op := p.tok.text[0]
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binaryNode{op: op, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// term parses multiplication and division.
+func (p *parser) term() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok.kind == tokenOp && (p.tok.text == "*" || p.tok.text == "/") {
+		op := p.tok.text[0]
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binaryNode{op: op, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// unary parses negation.
+func (p *parser) unary() (node, error) {
+	if p.tok.kind == tokenOp && p.tok.text == "-" {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return negNode{n}, nil
+	}
+
+	return p.primary()
+}
+
+// primary parses numbers, dice, variables, function calls and parentheses.
+func (p *parser) primary() (node, error) {
+	t := p.tok
+	switch t.kind {
+	case tokenNumber:
+		v, err := strconv.Atoi(t.text)
+		if err != nil {
+			return nil, &ParseError{Column: t.pos + 1, Msg: fmt.Sprintf("invalid number %s", t.text)}
+		}
+
+		return numberNode(v), p.advance()
+	case tokenDice:
+		n, err := parseDice(t)
+		if err != nil {
+			return nil, err
+		}
+
+		return n, p.advance()
+	case tokenIdent:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind != tokenLParen {
+			return varNode(t.text), nil
+		}
+
+		return p.call(t)
+	case tokenLParen:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		n, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind != tokenRParen {
+			return nil, p.unexpected()
+		}
+
+		return n, p.advance()
+	}
+
+	return nil, p.unexpected()
+}
+
+// call parses the arguments of a function call. The current token is the opening parenthesis.
+func (p *parser) call(name token) (node, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return nil, &ParseError{Column: name.pos + 1, Msg: fmt.Sprintf("unknown function %s", name.text)}
+	}
+
+	var args []node
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	for p.tok.kind != tokenRParen {
+		if len(args) > 0 {
+			if p.tok.kind != tokenComma {
+				return nil, p.unexpected()
+			}
+
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+
+		arg, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+	}
+
+	if len(args) < fn.minArgs || (fn.maxArgs > 0 && len(args) > fn.maxArgs) {
+		return nil, &ParseError{Column: name.pos + 1, Msg: fmt.Sprintf("wrong number of arguments to %s", name.text)}
+	}
+
+	return callNode{name: name.text, args: args}, p.advance()
+}
+
+func parseDice(t token) (node, error) {
+	var pool, sides int
+	var err error
+
+	i := 0
+	for t.text[i] != 'd' {
+		i++
+	}
+
+	pool = 1
+	if i > 0 {
+		if pool, err = strconv.Atoi(t.text[:i]); err != nil {
+			return nil, &ParseError{Column: t.pos + 1, Msg: fmt.Sprintf("invalid dice %s", t.text)}
+		}
+	}
+
+	if pool > MaxDicePool {
+		return nil, &ParseError{Column: t.pos + 1, Msg: fmt.Sprintf("dice %s rolls more than %d dice", t.text, MaxDicePool)}
+	}
+
+	if sides, err = strconv.Atoi(t.text[i+1:]); err != nil || sides < 1 {
+		return nil, &ParseError{Column: t.pos + 1, Msg: fmt.Sprintf("invalid dice %s", t.text)}
+	}
+
+	return diceNode{pool: pool, sides: sides}, nil
+}
@@ -0,0 +1,81 @@
+package formulas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParserLexer(t *testing.T) {
+	require := require.New(t)
+	l := lexer{src: " 2d6+d20 * (str.mod, _x1) - 3"}
+
+	var got []token
+	for {
+		tok, err := l.next()
+		require.NoError(err, "lexer.next() returned an error: %s", err)
+		got = append(got, tok)
+		if tok.kind == tokenEOF {
+			break
+		}
+	}
+
+	require.Equal([]token{
+		{kind: tokenDice, text: "2d6", pos: 1},
+		{kind: tokenOp, text: "+", pos: 4},
+		{kind: tokenDice, text: "d20", pos: 5},
+		{kind: tokenOp, text: "*", pos: 9},
+		{kind: tokenLParen, text: "(", pos: 11},
+		{kind: tokenIdent, text: "str.mod", pos: 12},
+		{kind: tokenComma, text: ",", pos: 19},
+		{kind: tokenIdent, text: "_x1", pos: 21},
+		{kind: tokenRParen, text: ")", pos: 24},
+		{kind: tokenOp, text: "-", pos: 26},
+		{kind: tokenNumber, text: "3", pos: 28},
+		{kind: tokenEOF, pos: 29},
+	}, got)
+}
+
+func TestParserErrors(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		src    string
+		column int
+		msg    string
+	}{
+		{"", 1, "empty formula"},
+		{"   ", 1, "empty formula"},
+		{"1 +", 4, "unexpected end of formula"},
+		{"1 + * 2", 5, `unexpected "*"`},
+		{"(1 + 2", 7, "unexpected end of formula"},
+		{"1 + 2)", 6, `unexpected ")"`},
+		{"str $ 2", 5, `unexpected character '$'`},
+		{"2d6x", 4, `unexpected character 'x'`},
+		{"2d0", 1, "invalid dice 2d0"},
+		{"1 + 9999999999d6", 5, "dice 9999999999d6 rolls more than 1000 dice"},
+		{"99999999999999999999d6", 1, "invalid dice 99999999999999999999d6"},
+		{"99999999999999999999", 1, "invalid number 99999999999999999999"},
+		{"floor(str)", 1, "unknown function floor"},
+		{"abs(1, 2)", 1, "wrong number of arguments to abs"},
+		{"min()", 1, "wrong number of arguments to min"},
+		{"max(1 2)", 7, `unexpected "2"`},
+		{"str dex", 5, `unexpected "dex"`},
+	} {
+		t.Run(tc.src, func(t *testing.T) {
+			_, err := Parse(tc.src)
+			require.Error(err, "Parse() did not return an error")
+
+			var pe *ParseError
+			require.ErrorAs(err, &pe)
+			require.Equal(tc.column, pe.Column)
+			require.Equal(tc.msg, pe.Msg)
+		})
+	}
+}
+
+func TestParserParseErrorError(t *testing.T) {
+	require := require.New(t)
+	err := &ParseError{Column: 3, Msg: "unexpected end of formula"}
+	require.Equal("column 3: unexpected end of formula", err.Error())
+}
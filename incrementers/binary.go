@@ -0,0 +1,220 @@
+package incrementers
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Binary representations start with a format version byte followed by the fields of the type as
+// varints. They are much smaller and faster than JSON and are used by encoding/gob. Text
+// representations are the binary representation encoded as unpadded URL-safe base64. A min or max
+// is a bool followed by the bound value if it is set. Version 1 wrote min and max as plain varints
+// with a max of 0 meaning no maximum, and can still be read.
+//
+//	Incrementer:         version inc val orig
+//	ClampedIncrementer:  version min max inc val orig
+//	WrappingIncrementer: version min max wraps inc val orig
+//	TickingIncrementer:  version rate delay duration ticks stopped min max inc val orig
+//	ModifierStack:       version min max inc val orig count modifiers count rules
+//	SnappedIncrementer:  version origin mode min max inc val orig
+//	SequenceIncrementer: version kind start ratio count steps start pos orig
+const binaryVersion = 2
+
+type binaryWriter struct {
+	buf []byte
+}
+
+func newBinaryWriter() *binaryWriter {
+	return &binaryWriter{buf: append(make([]byte, 0, 32), binaryVersion)}
+}
+
+func (w *binaryWriter) int(vals ...int) {
+	for _, v := range vals {
+		w.buf = binary.AppendVarint(w.buf, int64(v))
+	}
+}
+
+func (w *binaryWriter) uint(v uint64) { w.buf = binary.AppendUvarint(w.buf, v) }
+
+func (w *binaryWriter) bool(b bool) {
+	if b {
+		w.buf = append(w.buf, 1)
+		return
+	}
+
+	w.buf = append(w.buf, 0)
+}
+
+func (w *binaryWriter) float(f float64) {
+	w.buf = binary.LittleEndian.AppendUint64(w.buf, math.Float64bits(f))
+}
+
+func (w *binaryWriter) string(s string) {
+	w.uint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *binaryWriter) bound(b Bound) {
+	w.bool(b.ok)
+	if b.ok {
+		w.int(b.val)
+	}
+}
+
+func (w *binaryWriter) clamped(c ClampedIncrementer) {
+	w.bound(c.min)
+	w.bound(c.max)
+	w.int(c.inc, c.val, c.orig)
+}
+
+// binaryReader reads the fields written by binaryWriter. The first error is kept and every read after
+// it returns a zero value, so callers only need to check the error once with close.
+type binaryReader struct {
+	typ     string
+	version byte
+	data    []byte
+	err     error
+}
+
+func newBinaryReader(typ string, data []byte) (*binaryReader, error) {
+	if data == nil {
+		return nil, fmt.Errorf("%s.UnmarshalBinary(): data was nil", typ)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("invalid %s: binary data was empty", typ)
+	}
+
+	if data[0] < 1 || data[0] > binaryVersion {
+		return nil, fmt.Errorf("invalid %s: binary version %d is not supported", typ, data[0])
+	}
+
+	return &binaryReader{typ: typ, version: data[0], data: data[1:]}, nil
+}
+
+func (r *binaryReader) short() {
+	if r.err == nil {
+		r.err = fmt.Errorf("invalid %s: binary data was too short", r.typ)
+	}
+}
+
+func (r *binaryReader) int() int {
+	if r.err != nil {
+		return 0
+	}
+
+	v, n := binary.Varint(r.data)
+	if n <= 0 || v < math.MinInt || v > math.MaxInt {
+		r.short()
+		return 0
+	}
+
+	r.data = r.data[n:]
+	return int(v)
+}
+
+func (r *binaryReader) uint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.short()
+		return 0
+	}
+
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *binaryReader) bool() bool {
+	if r.err != nil || len(r.data) == 0 {
+		r.short()
+		return false
+	}
+
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b != 0
+}
+
+func (r *binaryReader) float() float64 {
+	if r.err != nil || len(r.data) < 8 {
+		r.short()
+		return 0
+	}
+
+	f := math.Float64frombits(binary.LittleEndian.Uint64(r.data))
+	r.data = r.data[8:]
+	return f
+}
+
+func (r *binaryReader) string() string {
+	n := r.uint()
+	if r.err != nil || n > uint64(len(r.data)) {
+		r.short()
+		return ""
+	}
+
+	s := string(r.data[:n])
+	r.data = r.data[n:]
+	return s
+}
+
+func (r *binaryReader) bound() Bound {
+	if !r.bool() {
+		return Unbounded()
+	}
+
+	return BoundAt(r.int())
+}
+
+func (r *binaryReader) clamped() ClampedIncrementer {
+	var c ClampedIncrementer
+	if r.version == 1 {
+		c.min = BoundAt(r.int())
+		if max := r.int(); max != 0 {
+			c.max = BoundAt(max)
+		}
+	} else {
+		c.min = r.bound()
+		c.max = r.bound()
+	}
+
+	c.Incrementer = Incrementer{inc: r.int(), val: r.int(), orig: r.int()}
+	return c
+}
+
+// close returns the first read error, or an error if there is data left over.
+func (r *binaryReader) close() error {
+	if r.err == nil && len(r.data) > 0 {
+		return fmt.Errorf("invalid %s: binary data had %d extra bytes", r.typ, len(r.data))
+	}
+
+	return r.err
+}
+
+func marshalText(m encoding.BinaryMarshaler) ([]byte, error) {
+	data, err := m.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	text := make([]byte, base64.RawURLEncoding.EncodedLen(len(data)))
+	base64.RawURLEncoding.Encode(text, data)
+	return text, nil
+}
+
+func unmarshalText(u encoding.BinaryUnmarshaler, text []byte) error {
+	data := make([]byte, base64.RawURLEncoding.DecodedLen(len(text)))
+	n, err := base64.RawURLEncoding.Decode(data, text)
+	if err != nil {
+		return err
+	}
+
+	return u.UnmarshalBinary(data[:n])
+}
@@ -0,0 +1,236 @@
+package incrementers
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("incrementer", func(t *testing.T) {
+		i := Incrementer{inc: 1, val: -2, orig: 3}
+		data, err := i.MarshalBinary()
+		require.NoError(err, "Incrementer.MarshalBinary() returned an error: %s", err)
+		require.Equal([]byte{binaryVersion, 2, 3, 6}, data)
+	})
+
+	t.Run("clamped", func(t *testing.T) {
+		c := NewClampedIncrementerWithValue(0, 4, 2)
+		data, err := c.MarshalBinary()
+		require.NoError(err, "ClampedIncrementer.MarshalBinary() returned an error: %s", err)
+		require.Equal([]byte{binaryVersion, 1, 0, 1, 8, 2, 4, 4}, data)
+	})
+
+	t.Run("smaller than json", func(t *testing.T) {
+		c := NewClampedIncrementerWithValue(-100, 1000, 500)
+		data, _ := c.MarshalBinary()
+		j, _ := c.MarshalJSON()
+		require.Less(len(data)*5, len(j))
+	})
+}
+
+func TestUnmarshalBinary(t *testing.T) {
+	require := require.New(t)
+	stack := NewModifierStack(NewIncrementerWithValue(10))
+	stack.AddModifier(NewAdditiveModifier("ring", "deflection", 2, 0))
+	stack.AddModifier(NewMultiplicativeModifier("rage", "", 1.5, 3))
+	stack.SetRule("deflection", StackHighest)
+
+	tests := []struct {
+		name string
+		in   encoding.BinaryMarshaler
+		out  interface {
+			encoding.BinaryUnmarshaler
+			encoding.TextUnmarshaler
+		}
+	}{
+		{"incrementer", Incrementer{inc: 2, val: math.MinInt, orig: math.MaxInt}, &Incrementer{}},
+		{"uincrementer", NewUIncrementerWithValue(7), &UIncrementer{}},
+		{"clamped", NewClampedIncrementerWithValue(-5, 5, 1), &ClampedIncrementer{}},
+		{"unbounded", NewClampedIncrementerWithBounds(BoundAt(3), Unbounded(), 100), &ClampedIncrementer{}},
+		{"no min", NewClampedIncrementerWithBounds(Unbounded(), BoundAt(0), -100), &ClampedIncrementer{}},
+		{"wrapping", WrappingIncrementer{min: 0, max: 23, wraps: -2, Incrementer: Incrementer{inc: 1, val: 22, orig: 6}}, &WrappingIncrementer{}},
+		{"ticking", TickingIncrementer{
+			ClampedIncrementer: NewClampedIncrementerWithValue(0, 10, 4), rate: -1, delay: 2, duration: 4, ticks: 1, stopped: true,
+		}, &TickingIncrementer{}},
+		{"modifiers", stack, &ModifierStack{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.in.MarshalBinary()
+			require.NoError(err, "MarshalBinary() returned an error: %s", err)
+			require.NoError(tt.out.UnmarshalBinary(data))
+			got, _ := tt.out.(encoding.BinaryMarshaler).MarshalBinary()
+			require.Equal(data, got)
+
+			text, err := tt.in.(encoding.TextMarshaler).MarshalText()
+			require.NoError(err, "MarshalText() returned an error: %s", err)
+			require.NoError(tt.out.UnmarshalText(text))
+			got, _ = tt.out.(encoding.TextMarshaler).MarshalText()
+			require.Equal(string(text), string(got))
+		})
+	}
+
+	t.Run("modifier stack effective", func(t *testing.T) {
+		var s ModifierStack
+		data, _ := stack.MarshalBinary()
+		require.NoError(s.UnmarshalBinary(data))
+		require.Equal(stack.Effective(), s.Effective())
+		require.Equal(StackHighest, s.Rule("deflection"))
+	})
+
+	t.Run("ticking keeps stop condition", func(t *testing.T) {
+		ti := NewTickingIncrementer(NewClampedIncrementerWithValue(0, 10, 4), 1)
+		ti.SetStopCondition(StopAtMax)
+		data, _ := ti.MarshalBinary()
+		require.NoError(ti.UnmarshalBinary(data))
+		require.NotNil(ti.stop)
+	})
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	require := require.New(t)
+
+	tests := []struct {
+		name string
+		data []byte
+		out  encoding.BinaryUnmarshaler
+		err  string
+	}{
+		{"nil", nil, &Incrementer{}, "Incrementer.UnmarshalBinary(): data was nil"},
+		{"empty", []byte{}, &Incrementer{}, "invalid Incrementer: binary data was empty"},
+		{"version", []byte{9, 2, 4, 6}, &Incrementer{}, "invalid Incrementer: binary version 9 is not supported"},
+		{"short", []byte{binaryVersion, 2, 4}, &Incrementer{}, "invalid Incrementer: binary data was too short"},
+		{"extra", []byte{binaryVersion, 2, 4, 6, 8}, &Incrementer{}, "invalid Incrementer: binary data had 1 extra bytes"},
+		{"negative", []byte{binaryVersion, 2, 3, 6}, &UIncrementer{}, "invalid UIncrementer: Incrementer.val must be 0 or greater"},
+		{"clamped bounds", []byte{binaryVersion, 1, 8, 1, 2, 2, 4, 4}, &ClampedIncrementer{}, "invalid ClampedIncrementer: min must be less than max"},
+		{"clamped val", []byte{binaryVersion, 1, 0, 1, 8, 2, 10, 4}, &ClampedIncrementer{}, "invalid ClampedIncrementer: Incrementer.val must min <= val <= max"},
+		{"wrapping", []byte{binaryVersion, 0, 8, 0, 2, 10, 4}, &WrappingIncrementer{}, "invalid WrappingIncrementer: Incrementer.val must min <= val <= max"},
+		{"ticking", []byte{binaryVersion, 2, 1, 0, 0, 0, 1, 0, 1, 8, 2, 4, 4}, &TickingIncrementer{}, "invalid TickingIncrementer: delay, duration and ticks must be 0 or greater"},
+		{"modifier kind", []byte{binaryVersion, 1, 0, 0, 2, 4, 4, 1, 0, 0, 10, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, &ModifierStack{}, "invalid ModifierStack: unknown modifier kind 5"},
+		{"stacking rule", []byte{binaryVersion, 1, 0, 0, 2, 4, 4, 0, 1, 0, 14}, &ModifierStack{}, "invalid ModifierStack: unknown stacking rule 7"},
+		{"modifier string", []byte{binaryVersion, 1, 0, 0, 2, 4, 4, 1, 40}, &ModifierStack{}, "invalid ModifierStack: binary data was too short"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.out.UnmarshalBinary(tt.data)
+			require.Error(err, "UnmarshalBinary() did not return an error")
+			require.Equal(tt.err, err.Error())
+		})
+	}
+
+	t.Run("unchanged on error", func(t *testing.T) {
+		c := NewClampedIncrementerWithValue(0, 4, 2)
+		require.Error(c.UnmarshalBinary([]byte{binaryVersion, 1, 8, 1, 2, 2, 4, 4}))
+		require.Equal(NewClampedIncrementerWithValue(0, 4, 2), c)
+	})
+
+	t.Run("version 1", func(t *testing.T) {
+		var c ClampedIncrementer
+		require.NoError(c.UnmarshalBinary([]byte{1, 0, 8, 2, 4, 4}))
+		require.Equal(NewClampedIncrementerWithValue(0, 4, 2), c)
+
+		require.NoError(c.UnmarshalBinary([]byte{1, 6, 0, 2, 200, 1, 200, 1}))
+		require.Equal(NewClampedIncrementerWithBounds(BoundAt(3), Unbounded(), 100), c)
+	})
+
+	t.Run("invalid text", func(t *testing.T) {
+		var i Incrementer
+		require.Error(i.UnmarshalText([]byte("not base64!")))
+	})
+}
+
+func TestGob(t *testing.T) {
+	require := require.New(t)
+
+	type save struct {
+		Weather Incrementer
+		Gold    UIncrementer
+		Doom    ClampedIncrementer
+		Day     WrappingIncrementer
+	}
+
+	in := save{
+		Weather: NewIncrementerWithValue(-3),
+		Gold:    NewUIncrementerWithValue(40),
+		Doom:    NewClampedIncrementerWithValue(0, 6, 2),
+		Day:     NewWrappingIncrementerWithValue(1, 7, 3),
+	}
+	in.Day.Add(12)
+
+	var buf bytes.Buffer
+	require.NoError(gob.NewEncoder(&buf).Encode(in))
+
+	var out save
+	require.NoError(gob.NewDecoder(&buf).Decode(&out))
+	require.Equal(in, out)
+	require.Equal(2, out.Day.Wraps())
+}
+
+func BenchmarkIncrementerMarshalJSON(b *testing.B) {
+	i := Incrementer{inc: 1, val: 1234, orig: 1000}
+	for n := 0; n < b.N; n++ {
+		_, _ = i.MarshalJSON()
+	}
+}
+
+func BenchmarkIncrementerMarshalBinary(b *testing.B) {
+	i := Incrementer{inc: 1, val: 1234, orig: 1000}
+	for n := 0; n < b.N; n++ {
+		_, _ = i.MarshalBinary()
+	}
+}
+
+func BenchmarkIncrementerUnmarshalJSON(b *testing.B) {
+	i := Incrementer{inc: 1, val: 1234, orig: 1000}
+	data, _ := i.MarshalJSON()
+	for n := 0; n < b.N; n++ {
+		_ = i.UnmarshalJSON(data)
+	}
+}
+
+func BenchmarkIncrementerUnmarshalBinary(b *testing.B) {
+	i := Incrementer{inc: 1, val: 1234, orig: 1000}
+	data, _ := i.MarshalBinary()
+	for n := 0; n < b.N; n++ {
+		_ = i.UnmarshalBinary(data)
+	}
+}
+
+func BenchmarkClampedIncrementerMarshalJSON(b *testing.B) {
+	c := NewClampedIncrementerWithValue(0, 100, 42)
+	for n := 0; n < b.N; n++ {
+		_, _ = c.MarshalJSON()
+	}
+}
+
+func BenchmarkClampedIncrementerMarshalBinary(b *testing.B) {
+	c := NewClampedIncrementerWithValue(0, 100, 42)
+	for n := 0; n < b.N; n++ {
+		_, _ = c.MarshalBinary()
+	}
+}
+
+func BenchmarkClampedIncrementerUnmarshalJSON(b *testing.B) {
+	c := NewClampedIncrementerWithValue(0, 100, 42)
+	data, _ := c.MarshalJSON()
+	for n := 0; n < b.N; n++ {
+		_ = c.UnmarshalJSON(data)
+	}
+}
+
+func BenchmarkClampedIncrementerUnmarshalBinary(b *testing.B) {
+	c := NewClampedIncrementerWithValue(0, 100, 42)
+	data, _ := c.MarshalBinary()
+	for n := 0; n < b.N; n++ {
+		_ = c.UnmarshalBinary(data)
+	}
+}
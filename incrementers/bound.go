@@ -0,0 +1,79 @@
+package incrementers
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// Bound is an optional minimum or maximum of a ClampedIncrementer. The zero value is unbounded. Its
+// JSON representation is the bound value, or null if it is unbounded.
+type Bound struct {
+	val int
+	ok  bool
+}
+
+// BoundAt returns a Bound at val.
+func BoundAt(val int) Bound { return Bound{val: val, ok: true} }
+
+// Unbounded returns a Bound with no limit.
+func Unbounded() Bound { return Bound{} }
+
+// Value returns the bound value and true, or 0 and false if the Bound is unbounded.
+func (b Bound) Value() (int, bool) { return b.val, b.ok }
+
+// IsUnbounded returns true if the Bound has no limit.
+func (b Bound) IsUnbounded() bool { return !b.ok }
+
+// String returns the bound value, or "none" if the Bound is unbounded.
+func (b Bound) String() string {
+	if !b.ok {
+		return "none"
+	}
+
+	return strconv.Itoa(b.val)
+}
+
+// MarshalJSON returns the bound value, or null if the Bound is unbounded.
+func (b Bound) MarshalJSON() ([]byte, error) {
+	if !b.ok {
+		return []byte("null"), nil
+	}
+
+	return []byte(strconv.Itoa(b.val)), nil
+}
+
+// UnmarshalJSON parses a bound value, or null for an unbounded Bound.
+func (b *Bound) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*b = Bound{}
+		return nil
+	}
+
+	var val int
+	if err := json.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	*b = BoundAt(val)
+	return nil
+}
+
+// or returns the bound value, or def if the Bound is unbounded.
+func (b Bound) or(def int) int {
+	if !b.ok {
+		return def
+	}
+
+	return b.val
+}
+
+// maxBound returns a Bound at max, or an unbounded Bound if max is 0, the way the int max of the
+// original ClampedIncrementer API was read.
+func maxBound(max int) Bound {
+	if max == 0 {
+		return Unbounded()
+	}
+
+	return BoundAt(max)
+}
@@ -0,0 +1,45 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBound(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("bounded", func(t *testing.T) {
+		b := BoundAt(0)
+		val, ok := b.Value()
+		require.Equal(0, val)
+		require.True(ok)
+		require.False(b.IsUnbounded())
+		require.Equal("0", b.String())
+	})
+
+	t.Run("unbounded", func(t *testing.T) {
+		b := Unbounded()
+		_, ok := b.Value()
+		require.False(ok)
+		require.True(b.IsUnbounded())
+		require.Equal(Bound{}, b, "the zero value is not unbounded")
+		require.Equal("none", b.String())
+	})
+}
+
+func TestBoundJSON(t *testing.T) {
+	require := require.New(t)
+
+	data, err := json.Marshal([]Bound{BoundAt(-10), Unbounded()})
+	require.NoError(err)
+	require.Equal(`[-10,null]`, string(data))
+
+	var got []Bound
+	require.NoError(json.Unmarshal([]byte(`[-10, null, 0]`), &got))
+	require.Equal([]Bound{BoundAt(-10), Unbounded(), BoundAt(0)}, got)
+
+	var b Bound
+	require.Error(json.Unmarshal([]byte(`"max"`), &b))
+}
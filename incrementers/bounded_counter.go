@@ -0,0 +1,359 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BoundedCounter is a replicated counter which stays between a minimum and maximum value even when
+// replicas change it while offline. Each replica holds rights to part of the room below and above the
+// value and can only spend its own rights, so merged changes can never cross a bound. The replica which
+// creates the counter starts with every right and can give rights to other replicas with GiveRights.
+//
+// Add, Remove and SetValue stop at the local replica's rights the same way a ClampedIncrementer stops
+// at its bounds. SetMax can always raise the maximum but can only lower it by the local replica's
+// rights above the value.
+type BoundedCounter struct {
+	replica string
+	inc     int
+	orig    int
+	base    int
+	min     int
+	max     int // Starting maximum. See Max for the current maximum.
+	owner   string
+	p       gcounter            // Amount added by each replica.
+	n       gcounter            // Amount removed by each replica.
+	down    map[string]gcounter // Rights to remove given from one replica to others.
+	up      map[string]gcounter // Rights to add given from one replica to others.
+	raised  gcounter            // Amount each replica raised the maximum by.
+	lowered gcounter            // Amount each replica lowered the maximum by.
+}
+
+// NewBoundedCounter creates a new BoundedCounter with a starting value clamped to min and max. The
+// given replica holds every right.
+func NewBoundedCounter(replica string, min, max, val int) (*BoundedCounter, error) {
+	if min >= max {
+		return nil, fmt.Errorf("invalid BoundedCounter: min must be less than max")
+	}
+
+	val = Clamp(val, min, max)
+	return &BoundedCounter{replica: replica, inc: 1, orig: val, base: val, min: min, max: max, owner: replica}, nil
+}
+
+// NewBoundedCounterFromJSON creates a new BoundedCounter from a JSON representation.
+func NewBoundedCounterFromJSON(data []byte) (*BoundedCounter, error) {
+	c := &BoundedCounter{}
+	err := c.UnmarshalJSON(data)
+	return c, err
+}
+
+// Replica returns the name of the replica which makes this copy's changes.
+func (c *BoundedCounter) Replica() string { return c.replica }
+
+// Fork returns a copy of the BoundedCounter for another replica. The new replica has no rights until
+// they are given to it.
+func (c *BoundedCounter) Fork(replica string) *BoundedCounter {
+	f := *c
+	f.replica = replica
+	f.p = c.p.clone()
+	f.n = c.n.clone()
+	f.down = cloneTransfers(c.down)
+	f.up = cloneTransfers(c.up)
+	f.raised = c.raised.clone()
+	f.lowered = c.lowered.clone()
+	return &f
+}
+
+// Merge adds the changes from another copy of the BoundedCounter.
+func (c *BoundedCounter) Merge(o *BoundedCounter) error {
+	if c.base != o.base || c.min != o.min || c.max != o.max || c.owner != o.owner {
+		return fmt.Errorf("invalid BoundedCounter: cannot merge counters with different starting values")
+	}
+
+	c.p.merge(o.p)
+	c.n.merge(o.n)
+	c.down = mergeTransfers(c.down, o.down)
+	c.up = mergeTransfers(c.up, o.up)
+	c.raised.merge(o.raised)
+	c.lowered.merge(o.lowered)
+	return nil
+}
+
+// Rights returns how much the local replica can remove and add.
+func (c *BoundedCounter) Rights() (down, up int) { return c.RightsOf(c.replica) }
+
+// RightsOf returns how much the given replica can remove and add, as far as this copy knows.
+func (c *BoundedCounter) RightsOf(replica string) (down, up int) {
+	if replica == c.owner {
+		down = SubSaturating(c.base, c.min)
+		up = SubSaturating(c.max, c.base)
+	}
+
+	// Every count is 0 or greater, so each difference fits in an int.
+	down = AddSaturating(down, c.p[replica]-c.n[replica])
+	down = AddSaturating(down, received(c.down, replica)-given(c.down, replica))
+	up = AddSaturating(up, c.n[replica]-c.p[replica])
+	up = AddSaturating(up, received(c.up, replica)-given(c.up, replica))
+	up = AddSaturating(up, c.raised[replica]-c.lowered[replica])
+	return down, up
+}
+
+// GiveRights gives some of the local replica's rights to remove and add to another replica. Each
+// amount is limited to the rights the local replica holds.
+func (c *BoundedCounter) GiveRights(to string, down, up int) {
+	if to == c.replica {
+		return
+	}
+
+	haveDown, haveUp := c.Rights()
+	c.down = addTransfer(c.down, c.replica, to, min(down, haveDown))
+	c.up = addTransfer(c.up, c.replica, to, min(up, haveUp))
+}
+
+// Inc returns the amount Increment and Decrement change the counter by.
+func (c *BoundedCounter) Inc() int { return c.inc }
+
+// Value returns the merged value of the BoundedCounter.
+func (c *BoundedCounter) Value() int { return net(c.base, c.p, c.n) }
+
+// Original returns the value Reset sets the BoundedCounter to.
+func (c *BoundedCounter) Original() int { return c.orig }
+
+// Min returns the minimum value.
+func (c *BoundedCounter) Min() int { return c.min }
+
+// Max returns the merged maximum value.
+func (c *BoundedCounter) Max() int { return net(c.max, c.raised, c.lowered) }
+
+// IsFull returns true if the value is at the maximum.
+func (c *BoundedCounter) IsFull() bool { return c.Value() == c.Max() }
+
+// IsEmpty returns true if the value is 0.
+func (c *BoundedCounter) IsEmpty() bool { return c.Value() == 0 }
+
+// Increment increases the counter by the incrementer value.
+func (c *BoundedCounter) Increment() { c.Add(c.inc) }
+
+// Decrement decreases the counter by the incrementer value.
+func (c *BoundedCounter) Decrement() { c.Remove(c.inc) }
+
+// Add increases the counter by val, up to the local replica's rights.
+func (c *BoundedCounter) Add(val int) {
+	if val < 0 {
+		c.Remove(SubSaturating(0, val))
+		return
+	}
+
+	_, up := c.Rights()
+	c.p.add(c.replica, min(val, up))
+}
+
+// Remove decreases the counter by val, up to the local replica's rights.
+func (c *BoundedCounter) Remove(val int) {
+	if val < 0 {
+		c.Add(SubSaturating(0, val))
+		return
+	}
+
+	down, _ := c.Rights()
+	c.n.add(c.replica, min(val, down))
+}
+
+// SetMax sets the maximum value. Raising the maximum gives the local replica the new rights. Lowering
+// it is limited by the local replica's rights to add and never reaches the minimum.
+func (c *BoundedCounter) SetMax(max int) {
+	d := SubSaturating(max, c.Max())
+	if d > 0 {
+		c.raised.add(c.replica, d)
+		return
+	}
+
+	_, up := c.Rights()
+	c.lowered.add(c.replica, min(SubSaturating(0, d), up, SubSaturating(c.Max(), c.min)-1))
+}
+
+// SetIncrementer sets the amount Increment and Decrement change the counter by.
+func (c *BoundedCounter) SetIncrementer(inc int) { c.inc = inc }
+
+// SetValue changes the counter toward val, up to the local replica's rights.
+func (c *BoundedCounter) SetValue(val int) {
+	c.Add(SubSaturating(Clamp(val, c.min, c.Max()), c.Value()))
+}
+
+// SetOriginalValue sets the value Reset sets the BoundedCounter to.
+func (c *BoundedCounter) SetOriginalValue(val int) { c.orig = val }
+
+// Fill sets the value toward the maximum.
+func (c *BoundedCounter) Fill() { c.SetValue(c.Max()) }
+
+// Empty sets the value toward 0.
+func (c *BoundedCounter) Empty() { c.SetValue(0) }
+
+// Reset sets the value toward the original value.
+func (c *BoundedCounter) Reset() { c.SetValue(c.orig) }
+
+// String returns a string representation of the BoundedCounter.
+func (c *BoundedCounter) String() string { return fmt.Sprintf("%d/%d", c.Value(), c.Max()) }
+
+func (c *BoundedCounter) view() formatView {
+	val, max := c.Value(), c.Max()
+	down, up := c.Rights()
+	return formatView{name: "BoundedCounter", val: val, min: BoundAt(c.min), max: BoundAt(max), str: c.String(),
+		fields: []formatField{
+			{"replica", c.replica}, {"val", val}, {"min", c.min}, {"max", max}, {"inc", c.inc}, {"orig", c.orig},
+			{"down", down}, {"up", up},
+		}}
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (c *BoundedCounter) Format(f fmt.State, verb rune) { c.view().format(f, verb) }
+
+type boundedCounterJSON struct {
+	Version int                 `json:"version"`
+	Replica string              `json:"replica"`
+	Inc     int                 `json:"inc"`
+	Orig    int                 `json:"orig"`
+	Base    int                 `json:"base"`
+	Min     int                 `json:"min"`
+	Max     int                 `json:"max"`
+	Owner   string              `json:"owner"`
+	P       gcounter            `json:"p,omitempty"`
+	N       gcounter            `json:"n,omitempty"`
+	Down    map[string]gcounter `json:"down,omitempty"`
+	Up      map[string]gcounter `json:"up,omitempty"`
+	Raised  gcounter            `json:"raised,omitempty"`
+	Lowered gcounter            `json:"lowered,omitempty"`
+}
+
+// MarshalJSON returns a JSON representation of the BoundedCounter including every replica's changes.
+func (c *BoundedCounter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(boundedCounterJSON{
+		Version: boundedCounterVersion,
+		Replica: c.replica,
+		Inc:     c.inc,
+		Orig:    c.orig,
+		Base:    c.base,
+		Min:     c.min,
+		Max:     c.max,
+		Owner:   c.owner,
+		P:       c.p,
+		N:       c.n,
+		Down:    c.down,
+		Up:      c.up,
+		Raised:  c.raised,
+		Lowered: c.lowered,
+	})
+}
+
+// UnmarshalJSON parses a JSON representation of the BoundedCounter.
+func (c *BoundedCounter) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("BoundedCounter.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" || string(data) == `""` {
+		return nil
+	}
+
+	var j boundedCounterJSON
+	err := unmarshalVersioned(
+		TypeBoundedCounter, "BoundedCounter", data, &j, "replica", "base", "min", "max", "owner",
+	)
+	if err != nil {
+		return err
+	}
+
+	if j.Min >= j.Max {
+		return fmt.Errorf("invalid BoundedCounter: min must be less than max")
+	}
+
+	if !IsClamped(j.Base, j.Min, j.Max) {
+		return fmt.Errorf("invalid BoundedCounter: base must min <= base <= max")
+	}
+
+	valid := j.P.valid() && j.N.valid() && j.Raised.valid() && j.Lowered.valid()
+	for _, g := range j.Down {
+		valid = valid && g.valid()
+	}
+
+	for _, g := range j.Up {
+		valid = valid && g.valid()
+	}
+
+	if !valid {
+		return fmt.Errorf("invalid BoundedCounter: counts must be 0 or greater")
+	}
+
+	*c = BoundedCounter{
+		replica: j.Replica,
+		inc:     j.Inc,
+		orig:    j.Orig,
+		base:    j.Base,
+		min:     j.Min,
+		max:     j.Max,
+		owner:   j.Owner,
+		p:       j.P,
+		n:       j.N,
+		down:    j.Down,
+		up:      j.Up,
+		raised:  j.Raised,
+		lowered: j.Lowered,
+	}
+
+	return nil
+}
+
+func addTransfer(t map[string]gcounter, from, to string, n int) map[string]gcounter {
+	if n <= 0 {
+		return t
+	}
+
+	if t == nil {
+		t = make(map[string]gcounter)
+	}
+
+	g := t[from]
+	g.add(to, n)
+	t[from] = g
+	return t
+}
+
+// received returns the rights given to replica.
+func received(t map[string]gcounter, replica string) int {
+	var total int
+	for _, g := range t {
+		total = AddSaturating(total, g[replica])
+	}
+
+	return total
+}
+
+// given returns the rights replica gave away.
+func given(t map[string]gcounter, replica string) int { return t[replica].sum() }
+
+func mergeTransfers(t, o map[string]gcounter) map[string]gcounter {
+	for from, g := range o {
+		if t == nil {
+			t = make(map[string]gcounter)
+		}
+
+		m := t[from]
+		m.merge(g)
+		t[from] = m
+	}
+
+	return t
+}
+
+func cloneTransfers(t map[string]gcounter) map[string]gcounter {
+	if t == nil {
+		return nil
+	}
+
+	c := make(map[string]gcounter, len(t))
+	for from, g := range t {
+		c[from] = g.clone()
+	}
+
+	return c
+}
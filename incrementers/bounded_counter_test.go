@@ -0,0 +1,264 @@
+package incrementers
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBoundedCounter(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		c, err := NewBoundedCounter("a", 0, 10, 12)
+		require.NoError(err)
+		require.Equal(10, c.Value())
+		require.Equal(0, c.Min())
+		require.Equal(10, c.Max())
+		require.Equal(10, c.Original())
+		require.True(c.IsFull())
+	})
+
+	t.Run("invalid bounds", func(t *testing.T) {
+		_, err := NewBoundedCounter("a", 5, 5, 5)
+		require.Error(err, "NewBoundedCounter() did not return an error")
+		require.Equal("invalid BoundedCounter: min must be less than max", err.Error())
+	})
+}
+
+func TestBoundedCounterRights(t *testing.T) {
+	require := require.New(t)
+	a, err := NewBoundedCounter("a", 0, 10, 4)
+	require.NoError(err)
+	b := a.Fork("b")
+
+	t.Run("owner", func(t *testing.T) {
+		down, up := a.Rights()
+		require.Equal(4, down)
+		require.Equal(6, up)
+
+		down, up = b.Rights()
+		require.Equal(0, down)
+		require.Equal(0, up)
+
+		b.Add(3)
+		require.Equal(4, b.Value())
+	})
+
+	t.Run("give", func(t *testing.T) {
+		a.GiveRights("b", 1, 9)
+		down, up := a.Rights()
+		require.Equal(3, down)
+		require.Equal(0, up)
+
+		require.NoError(b.Merge(a))
+		down, up = b.RightsOf("b")
+		require.Equal(1, down)
+		require.Equal(6, up)
+	})
+
+	t.Run("spend", func(t *testing.T) {
+		b.Add(8)
+		require.Equal(10, b.Value())
+		down, up := b.Rights()
+		require.Equal(7, down)
+		require.Equal(0, up)
+
+		a.Remove(5)
+		require.Equal(1, a.Value())
+		require.NoError(a.Merge(b))
+		require.NoError(b.Merge(a))
+		require.Equal(7, a.Value())
+		require.Equal(7, b.Value())
+	})
+
+	t.Run("self", func(t *testing.T) {
+		down, up := a.Rights()
+		a.GiveRights("a", 1, 1)
+		gotDown, gotUp := a.Rights()
+		require.Equal(down, gotDown)
+		require.Equal(up, gotUp)
+		require.NotContains(a.down["a"], "a")
+	})
+}
+
+func TestBoundedCounterMethods(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("add and remove", func(t *testing.T) {
+		c, err := NewBoundedCounter("a", -5, 5, 0)
+		require.NoError(err)
+		c.Increment()
+		c.Add(10)
+		require.Equal(5, c.Value())
+
+		c.Decrement()
+		c.Add(-2)
+		c.Remove(20)
+		require.Equal(-5, c.Value())
+
+		c.Remove(-3)
+		require.Equal(-2, c.Value())
+		require.Equal("-2/5", c.String())
+	})
+
+	t.Run("set value", func(t *testing.T) {
+		c, err := NewBoundedCounter("a", 0, 10, 4)
+		require.NoError(err)
+		c.SetValue(20)
+		require.True(c.IsFull())
+
+		c.Empty()
+		require.True(c.IsEmpty())
+
+		c.Reset()
+		require.Equal(4, c.Value())
+
+		c.SetOriginalValue(7)
+		c.Reset()
+		require.Equal(7, c.Value())
+
+		c.Fill()
+		require.Equal(10, c.Value())
+	})
+
+	t.Run("set max", func(t *testing.T) {
+		c, err := NewBoundedCounter("a", 0, 10, 4)
+		require.NoError(err)
+		c.SetMax(12)
+		require.Equal(12, c.Max())
+		c.Fill()
+		require.Equal(12, c.Value())
+
+		c.SetValue(4)
+		c.SetMax(2)
+		require.Equal(4, c.Max(), "SetMax() lowered the maximum below the value")
+
+		c.SetValue(0)
+		c.SetMax(-5)
+		require.Equal(1, c.Max(), "SetMax() lowered the maximum to the minimum")
+	})
+
+	t.Run("set max rights", func(t *testing.T) {
+		a, err := NewBoundedCounter("a", 0, 10, 4)
+		require.NoError(err)
+		b := a.Fork("b")
+		b.SetMax(8)
+		require.Equal(10, b.Max(), "SetMax() lowered the maximum without rights")
+
+		b.SetMax(15)
+		_, up := b.Rights()
+		require.Equal(5, up)
+
+		require.NoError(a.Merge(b))
+		require.Equal(15, a.Max())
+	})
+
+	t.Run("inc", func(t *testing.T) {
+		c, err := NewBoundedCounter("a", 0, 10, 0)
+		require.NoError(err)
+		c.SetIncrementer(3)
+		require.Equal(3, c.Inc())
+		c.Increment()
+		require.Equal(3, c.Value())
+		require.Equal("a", c.Replica())
+	})
+
+	t.Run("merge error", func(t *testing.T) {
+		a, err := NewBoundedCounter("a", 0, 10, 4)
+		require.NoError(err)
+		b, err := NewBoundedCounter("b", 0, 10, 4)
+		require.NoError(err)
+
+		err = a.Merge(b)
+		require.Error(err, "BoundedCounter.Merge() did not return an error")
+		require.Equal("invalid BoundedCounter: cannot merge counters with different starting values", err.Error())
+	})
+}
+
+func TestBoundedCounterRandomMerges(t *testing.T) {
+	require := require.New(t)
+	for seed := int64(1); seed <= 5; seed++ {
+		origin, err := NewBoundedCounter("r0", 0, 20, 10)
+		require.NoError(err)
+
+		names := []string{"r0", "r1", "r2", "r3"}
+		op := func(rng *rand.Rand, c *BoundedCounter) {
+			switch rng.Intn(6) {
+			case 0:
+				c.GiveRights(names[rng.Intn(len(names))], rng.Intn(6), rng.Intn(6))
+			case 1:
+				c.SetMax(c.Max() + rng.Intn(7) - 3)
+			case 2:
+				c.SetValue(rng.Intn(30) - 5)
+			default:
+				c.Add(rng.Intn(13) - 6)
+			}
+		}
+
+		check := func(c *BoundedCounter) {
+			require.True(IsClamped(c.Value(), c.Min(), c.Max()), "value %s left the bounds", c)
+			for _, name := range names {
+				down, up := c.RightsOf(name)
+				require.GreaterOrEqual(down, 0, "%s had negative rights", name)
+				require.GreaterOrEqual(up, 0, "%s had negative rights", name)
+			}
+		}
+
+		replicas := randomMerges(t, seed, origin, op, check)
+		for _, r := range replicas {
+			require.Equal(replicas[0].Max(), r.Max())
+		}
+	}
+}
+
+func TestBoundedCounterJSON(t *testing.T) {
+	require := require.New(t)
+	c, err := NewBoundedCounter("a", 0, 10, 4)
+	require.NoError(err)
+	c.Add(2)
+	c.GiveRights("b", 1, 2)
+
+	data, err := c.MarshalJSON()
+	require.NoError(err)
+	require.Equal(
+		`{"version":1,"replica":"a","inc":1,"orig":4,"base":4,"min":0,"max":10,"owner":"a",`+
+			`"p":{"a":2},"down":{"a":{"b":1}},"up":{"a":{"b":2}}}`,
+		string(data),
+	)
+
+	t.Run("round trip", func(t *testing.T) {
+		n, err := NewBoundedCounterFromJSON(data)
+		require.NoError(err)
+		require.Equal(c, n)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name string
+			data string
+			err  string
+		}{
+			{"missing", `{"replica":"a","base":0,"min":0,"max":10}`, "invalid BoundedCounter: owner is required"},
+			{
+				"bounds", `{"replica":"a","owner":"a","base":0,"min":5,"max":5}`,
+				"invalid BoundedCounter: min must be less than max",
+			},
+			{
+				"base", `{"replica":"a","owner":"a","base":11,"min":0,"max":10}`,
+				"invalid BoundedCounter: base must min <= base <= max",
+			},
+			{
+				"count", `{"replica":"a","owner":"a","base":0,"min":0,"max":10,"up":{"a":{"b":-1}}}`,
+				"invalid BoundedCounter: counts must be 0 or greater",
+			},
+		}
+
+		for _, tc := range tests {
+			_, err := NewBoundedCounterFromJSON([]byte(tc.data))
+			require.Error(err, "%s: BoundedCounter.UnmarshalJSON() did not return an error", tc.name)
+			require.Equal(tc.err, err.Error(), tc.name)
+		}
+	})
+}
@@ -0,0 +1,307 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Stage describes a single digit of a CascadingCounter.
+type Stage struct {
+	Name string `json:"name"`
+	Base int    `json:"base"` // Units of this stage that carry into 1 unit of the next stage.
+}
+
+type cascadeStage struct {
+	Stage
+	counter ClampedIncrementer
+	weight  int // Value of 1 unit of this stage in units of the lowest stage.
+}
+
+// CascadingCounter is an odometer style counter made of stages, lowest first. When a stage goes past
+// its base it carries into the next stage and when it goes below 0 it borrows from the next stage.
+// The last stage has no maximum and its base is ignored. The total value can not go below 0.
+type CascadingCounter struct {
+	stages []cascadeStage
+	orig   int
+}
+
+// NewCascadingCounter creates a new CascadingCounter from the given stages, lowest first. Every
+// stage except the last must have a base of 2 or greater.
+//
+//	coins, err := NewCascadingCounter(Stage{"cp", 10}, Stage{"sp", 10}, Stage{"gp", 0})
+func NewCascadingCounter(stages ...Stage) (CascadingCounter, error) {
+	var c CascadingCounter
+	if len(stages) == 0 {
+		return c, fmt.Errorf("invalid CascadingCounter: at least 1 stage is required")
+	}
+
+	weight := 1
+	for i, s := range stages {
+		if c.find(s.Name) != -1 {
+			return c, fmt.Errorf("invalid CascadingCounter: duplicate stage %s", s.Name)
+		}
+
+		last := i == len(stages)-1
+		if !last && s.Base < 2 {
+			return c, fmt.Errorf("invalid CascadingCounter: stage %s base must be 2 or greater", s.Name)
+		}
+
+		st := cascadeStage{Stage: s, weight: weight}
+		if last {
+			st.Base = 0
+			st.counter = NewClampedIncrementerWithBounds(BoundAt(0), Unbounded(), 0)
+		} else {
+			st.counter = NewClampedIncrementer(0, s.Base-1)
+		}
+
+		c.stages = append(c.stages, st)
+		if last {
+			break
+		}
+
+		var err error
+		if weight, err = MulChecked(weight, s.Base); err != nil {
+			return c, fmt.Errorf("invalid CascadingCounter: stage %s: %w", s.Name, err)
+		}
+	}
+
+	return c, nil
+}
+
+// NewCascadingCounterFromJSON creates a new CascadingCounter from a JSON representation.
+func NewCascadingCounterFromJSON(data []byte) (CascadingCounter, error) {
+	var c CascadingCounter
+	err := c.UnmarshalJSON(data)
+	return c, err
+}
+
+// Stages returns the stage definitions of the CascadingCounter, lowest first.
+func (c CascadingCounter) Stages() []Stage {
+	stages := make([]Stage, len(c.stages))
+	for i, s := range c.stages {
+		stages[i] = s.Stage
+	}
+
+	return stages
+}
+
+// Values returns the value of each stage, lowest first.
+func (c CascadingCounter) Values() []int {
+	values := make([]int, len(c.stages))
+	for i, s := range c.stages {
+		values[i] = s.counter.Value()
+	}
+
+	return values
+}
+
+// Stage returns the value of the named stage.
+func (c CascadingCounter) Stage(name string) (int, bool) {
+	i := c.find(name)
+	if i == -1 {
+		return 0, false
+	}
+
+	return c.stages[i].counter.Value(), true
+}
+
+// Value returns the total value of all stages in units of the lowest stage.
+func (c CascadingCounter) Value() int {
+	var total int
+	for _, s := range c.stages {
+		total = AddSaturating(total, MulSaturating(s.counter.Value(), s.weight))
+	}
+
+	return total
+}
+
+// Original returns the original total value of the CascadingCounter.
+func (c CascadingCounter) Original() int { return c.orig }
+
+// IsEmpty returns true if every stage is 0.
+func (c CascadingCounter) IsEmpty() bool { return c.Value() == 0 }
+
+// IsUnchanged returns true if the total value is the same as the original value.
+func (c CascadingCounter) IsUnchanged() bool { return c.Value() == c.orig }
+
+// Increment adds 1 unit to the lowest stage, carrying as needed.
+func (c *CascadingCounter) Increment() { c.Add(1) }
+
+// Decrement removes 1 unit from the lowest stage, borrowing as needed.
+func (c *CascadingCounter) Decrement() { c.Remove(1) }
+
+// Add adds val units of the lowest stage, carrying as needed. The total stops at math.MaxInt instead
+// of overflowing.
+func (c *CascadingCounter) Add(val int) { c.SetValue(AddSaturating(c.Value(), val)) }
+
+// Remove removes val units of the lowest stage, borrowing as needed.
+func (c *CascadingCounter) Remove(val int) { c.SetValue(SubSaturating(c.Value(), val)) }
+
+// AddChecked adds val units of the lowest stage, or returns ErrOverflow and leaves the counter
+// unchanged if the total does not fit in an int.
+func (c *CascadingCounter) AddChecked(val int) error {
+	total, err := AddChecked(c.Value(), val)
+	if err != nil {
+		return err
+	}
+
+	c.SetValue(total)
+	return nil
+}
+
+// RemoveChecked removes val units of the lowest stage, or returns ErrOverflow and leaves the counter
+// unchanged if the total does not fit in an int.
+func (c *CascadingCounter) RemoveChecked(val int) error {
+	total, err := SubChecked(c.Value(), val)
+	if err != nil {
+		return err
+	}
+
+	c.SetValue(total)
+	return nil
+}
+
+// AddStage adds val units to the named stage, carrying or borrowing as needed.
+func (c *CascadingCounter) AddStage(name string, val int) error {
+	i := c.find(name)
+	if i == -1 {
+		return fmt.Errorf("invalid CascadingCounter: unknown stage %s", name)
+	}
+
+	c.Add(MulSaturating(val, c.stages[i].weight))
+	return nil
+}
+
+// RemoveStage removes val units from the named stage, borrowing from higher stages as needed.
+func (c *CascadingCounter) RemoveStage(name string, val int) error { return c.AddStage(name, -val) }
+
+// SetStage sets the named stage to val and normalizes the counter so every stage is within its base.
+func (c *CascadingCounter) SetStage(name string, val int) error {
+	i := c.find(name)
+	if i == -1 {
+		return fmt.Errorf("invalid CascadingCounter: unknown stage %s", name)
+	}
+
+	values := c.Values()
+	values[i] = val
+	c.SetValues(values...)
+	return nil
+}
+
+// SetValues sets the stages to the given values, lowest first, and normalizes the counter so every
+// stage is within its base. Missing values are treated as 0 and extra values are ignored.
+func (c *CascadingCounter) SetValues(values ...int) {
+	var total int
+	for i, s := range c.stages {
+		if i < len(values) {
+			total = AddSaturating(total, MulSaturating(values[i], s.weight))
+		}
+	}
+
+	c.SetValue(total)
+}
+
+// SetValue sets the total value in units of the lowest stage with a minimum of 0.
+func (c *CascadingCounter) SetValue(val int) {
+	val = ClampMin(val, 0)
+	for i := len(c.stages) - 1; i >= 0; i-- {
+		s := &c.stages[i]
+		s.counter.SetValue(val / s.weight)
+		val %= s.weight
+	}
+}
+
+// SetOriginalValue sets the original total value with a minimum of 0.
+func (c *CascadingCounter) SetOriginalValue(val int) { c.orig = ClampMin(val, 0) }
+
+// Empty sets every stage to 0.
+func (c *CascadingCounter) Empty() { c.SetValue(0) }
+
+// Reset sets the CascadingCounter to the original total value.
+func (c *CascadingCounter) Reset() { c.SetValue(c.orig) }
+
+// String returns a string representation of the CascadingCounter, highest stage first.
+func (c CascadingCounter) String() string {
+	parts := make([]string, len(c.stages))
+	for i, s := range c.stages {
+		parts[len(c.stages)-1-i] = fmt.Sprintf("%d%s", s.counter.Value(), s.Name)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (c CascadingCounter) view() formatView {
+	val := c.Value()
+	return formatView{name: "CascadingCounter", val: val, min: BoundAt(0), str: c.String(),
+		fields: []formatField{{"val", val}, {"stages", c.Values()}, {"orig", c.orig}}}
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (c CascadingCounter) Format(f fmt.State, verb rune) { c.view().format(f, verb) }
+
+type cascadeStageJSON struct {
+	Stage
+	Val int `json:"val"`
+}
+
+type cascadingCounterJSON struct {
+	Version int                `json:"version"`
+	Stages  []cascadeStageJSON `json:"stages"`
+	Orig    int                `json:"orig"`
+}
+
+// MarshalJSON returns a JSON representation of the CascadingCounter.
+func (c CascadingCounter) MarshalJSON() ([]byte, error) {
+	j := cascadingCounterJSON{Version: cascadingVersion, Orig: c.orig}
+	for _, s := range c.stages {
+		j.Stages = append(j.Stages, cascadeStageJSON{Stage: s.Stage, Val: s.counter.Value()})
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON parses a JSON representation of the CascadingCounter. Older versions are migrated to
+// the latest version first.
+func (c *CascadingCounter) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("CascadingCounter.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" || string(data) == `""` {
+		return nil
+	}
+
+	var j cascadingCounterJSON
+	if err := unmarshalVersioned(TypeCascadingCounter, "CascadingCounter", data, &j, "stages"); err != nil {
+		return err
+	}
+
+	stages := make([]Stage, len(j.Stages))
+	values := make([]int, len(j.Stages))
+	for i, s := range j.Stages {
+		stages[i] = s.Stage
+		values[i] = s.Val
+	}
+
+	n, err := NewCascadingCounter(stages...)
+	if err != nil {
+		return err
+	}
+
+	n.SetValues(values...)
+	n.SetOriginalValue(j.Orig)
+	*c = n
+
+	return nil
+}
+
+func (c CascadingCounter) find(name string) int {
+	for i, s := range c.stages {
+		if s.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,271 @@
+package incrementers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newCoins(t *testing.T) CascadingCounter {
+	c, err := NewCascadingCounter(Stage{"cp", 10}, Stage{"sp", 10}, Stage{"gp", 0})
+	require.NoError(t, err, "NewCascadingCounter() returned an error: %s", err)
+	return c
+}
+
+func TestCascadingCounterNew(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		c := newCoins(t)
+		require.Equal([]Stage{{"cp", 10}, {"sp", 10}, {"gp", 0}}, c.Stages())
+		require.Equal([]int{0, 0, 0}, c.Values())
+		require.Equal(0, c.Value())
+	})
+
+	t.Run("last base ignored", func(t *testing.T) {
+		c, err := NewCascadingCounter(Stage{"rounds", 10}, Stage{"minutes", 60})
+		require.NoError(err, "NewCascadingCounter() returned an error: %s", err)
+		require.Equal([]Stage{{"rounds", 10}, {"minutes", 0}}, c.Stages())
+	})
+
+	t.Run("no stages", func(t *testing.T) {
+		_, err := NewCascadingCounter()
+		require.Error(err, "NewCascadingCounter() did not return an error")
+		require.Equal("invalid CascadingCounter: at least 1 stage is required", err.Error())
+	})
+
+	t.Run("invalid base", func(t *testing.T) {
+		_, err := NewCascadingCounter(Stage{"cp", 1}, Stage{"sp", 0})
+		require.Error(err, "NewCascadingCounter() did not return an error")
+		require.Equal("invalid CascadingCounter: stage cp base must be 2 or greater", err.Error())
+	})
+
+	t.Run("duplicate stage", func(t *testing.T) {
+		_, err := NewCascadingCounter(Stage{"cp", 10}, Stage{"cp", 0})
+		require.Error(err, "NewCascadingCounter() did not return an error")
+		require.Equal("invalid CascadingCounter: duplicate stage cp", err.Error())
+	})
+}
+
+func TestCascadingCounterStage(t *testing.T) {
+	require := require.New(t)
+	c := newCoins(t)
+	c.SetValue(123)
+
+	v, ok := c.Stage("sp")
+	require.True(ok)
+	require.Equal(2, v)
+
+	_, ok = c.Stage("pp")
+	require.False(ok)
+}
+
+func TestCascadingCounterIncrement(t *testing.T) {
+	require := require.New(t)
+	c := newCoins(t)
+	c.SetValue(99)
+
+	c.Increment()
+	require.Equal([]int{0, 0, 1}, c.Values())
+	require.Equal(100, c.Value())
+}
+
+func TestCascadingCounterDecrement(t *testing.T) {
+	require := require.New(t)
+	c := newCoins(t)
+
+	t.Run("borrow", func(t *testing.T) {
+		c.SetValue(100)
+		c.Decrement()
+		require.Equal([]int{9, 9, 0}, c.Values())
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		c.SetValue(0)
+		c.Decrement()
+		require.Equal([]int{0, 0, 0}, c.Values())
+	})
+}
+
+func TestCascadingCounterAdd(t *testing.T) {
+	require := require.New(t)
+	c := newCoins(t)
+
+	c.Add(1234)
+	require.Equal([]int{4, 3, 12}, c.Values())
+
+	c.Add(-34)
+	require.Equal([]int{0, 0, 12}, c.Values())
+}
+
+func TestCascadingCounterRemove(t *testing.T) {
+	require := require.New(t)
+	c := newCoins(t)
+	c.SetValue(105)
+
+	c.Remove(6)
+	require.Equal([]int{9, 9, 0}, c.Values())
+
+	c.Remove(1000)
+	require.Equal(0, c.Value())
+}
+
+func TestCascadingCounterAddStage(t *testing.T) {
+	require := require.New(t)
+	c := newCoins(t)
+
+	t.Run("carry", func(t *testing.T) {
+		require.NoError(c.AddStage("sp", 12))
+		require.Equal([]int{0, 2, 1}, c.Values())
+	})
+
+	t.Run("borrow", func(t *testing.T) {
+		require.NoError(c.RemoveStage("cp", 1))
+		require.Equal([]int{9, 1, 1}, c.Values())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		err := c.AddStage("pp", 1)
+		require.Error(err, "CascadingCounter.AddStage() did not return an error")
+		require.Equal("invalid CascadingCounter: unknown stage pp", err.Error())
+	})
+}
+
+func TestCascadingCounterSetStage(t *testing.T) {
+	require := require.New(t)
+	c := newCoins(t)
+
+	require.NoError(c.SetStage("cp", 37))
+	require.Equal([]int{7, 3, 0}, c.Values())
+
+	c.SetValue(137)
+	require.NoError(c.SetStage("sp", -1))
+	require.Equal([]int{7, 9, 0}, c.Values())
+
+	err := c.SetStage("pp", 1)
+	require.Error(err, "CascadingCounter.SetStage() did not return an error")
+}
+
+func TestCascadingCounterSetValues(t *testing.T) {
+	require := require.New(t)
+	c := newCoins(t)
+
+	c.SetValues(25, 14, 1)
+	require.Equal([]int{5, 6, 2}, c.Values())
+
+	c.SetValues(5)
+	require.Equal([]int{5, 0, 0}, c.Values())
+}
+
+func TestCascadingCounterReset(t *testing.T) {
+	require := require.New(t)
+	c := newCoins(t)
+	c.SetOriginalValue(150)
+	require.Equal(150, c.Original())
+	require.False(c.IsUnchanged())
+
+	c.Reset()
+	require.Equal([]int{0, 5, 1}, c.Values())
+	require.True(c.IsUnchanged())
+
+	c.Empty()
+	require.True(c.IsEmpty())
+}
+
+func TestCascadingCounterString(t *testing.T) {
+	require := require.New(t)
+	c := newCoins(t)
+	c.SetValue(1234)
+	require.Equal("12gp 3sp 4cp", c.String())
+}
+
+func TestCascadingCounterMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	c := newCoins(t)
+	c.SetValue(123)
+	c.SetOriginalValue(5)
+
+	data, err := c.MarshalJSON()
+	require.NoError(err, "CascadingCounter.MarshalJSON() returned an error: %s", err)
+	require.Equal(
+		`{"version":2,"stages":[{"name":"cp","base":10,"val":3},{"name":"sp","base":10,"val":2},{"name":"gp","base":0,"val":1}],"orig":5}`,
+		string(data),
+	)
+}
+
+func TestCascadingCounterUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		c, err := NewCascadingCounterFromJSON([]byte(
+			`{"stages":[{"name":"cp","base":10,"val":3},{"name":"sp","base":10,"val":2},{"name":"gp","base":0,"val":1}],"orig":5}`,
+		))
+		require.NoError(err, "CascadingCounter.UnmarshalJSON() returned an error: %s", err)
+		require.Equal(123, c.Value())
+		require.Equal(5, c.Original())
+	})
+
+	t.Run("normalizes", func(t *testing.T) {
+		c, err := NewCascadingCounterFromJSON([]byte(
+			`{"stages":[{"name":"cp","base":10,"val":13},{"name":"gp","base":0,"val":1}],"orig":0}`,
+		))
+		require.NoError(err, "CascadingCounter.UnmarshalJSON() returned an error: %s", err)
+		require.Equal([]int{3, 2}, c.Values())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var c CascadingCounter
+		err := c.UnmarshalJSON(nil)
+		require.Error(err, "CascadingCounter.UnmarshalJSON() did not return an error")
+		require.Equal("CascadingCounter.UnmarshalJSON(): data was nil", err.Error())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var c CascadingCounter
+		require.NoError(c.UnmarshalJSON([]byte(`null`)))
+		require.Equal(CascadingCounter{}, c)
+	})
+
+	t.Run("invalid stages", func(t *testing.T) {
+		_, err := NewCascadingCounterFromJSON([]byte(`{"stages":[],"orig":0}`))
+		require.Error(err, "CascadingCounter.UnmarshalJSON() did not return an error")
+		require.Equal("invalid CascadingCounter: at least 1 stage is required", err.Error())
+	})
+}
+
+func TestCascadingCounterOverflow(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("weights", func(t *testing.T) {
+		_, err := NewCascadingCounter(Stage{"a", math.MaxInt / 2}, Stage{"b", 3}, Stage{"c", 0})
+		require.ErrorIs(err, ErrOverflow)
+		require.Equal(
+			"invalid CascadingCounter: stage b: integer overflow: 4611686018427387903 * 3",
+			err.Error(),
+		)
+	})
+
+	t.Run("saturate", func(t *testing.T) {
+		c := newCoins(t)
+		c.Add(5)
+		c.Add(math.MaxInt)
+		require.Equal(math.MaxInt, c.Value())
+
+		require.NoError(c.AddStage("gp", math.MaxInt))
+		require.Equal(math.MaxInt, c.Value())
+
+		c.SetValues(1, 1, math.MaxInt)
+		require.Equal(math.MaxInt, c.Value())
+	})
+
+	t.Run("checked", func(t *testing.T) {
+		c := newCoins(t)
+		c.SetValue(math.MaxInt - 1)
+		require.ErrorIs(c.AddChecked(2), ErrOverflow)
+		require.Equal(math.MaxInt-1, c.Value())
+
+		require.NoError(c.RemoveChecked(math.MaxInt - 11))
+		require.Equal([]int{0, 1, 0}, c.Values())
+	})
+}
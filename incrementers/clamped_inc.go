@@ -2,28 +2,35 @@ package incrementers
 
 import (
 	"fmt"
+	"math"
 )
 
+// ClampedIncrementer is an Incrementer kept between an optional minimum and maximum value.
 type ClampedIncrementer struct {
-	min int
-	max int
+	min Bound
+	max Bound
 	Incrementer
 }
 
-// NewClampedIncrementer creates a new counter with a minimum value of 0 and no maximum value.
+// NewClampedIncrementer creates a new counter from min to max. A max of 0 means no maximum; use
+// NewClampedIncrementerWithBounds for a maximum of 0.
 func NewClampedIncrementer(min, max int) ClampedIncrementer {
-	return ClampedIncrementer{min: min, max: max, Incrementer: Incrementer{inc: 1}}
+	return ClampedIncrementer{min: BoundAt(min), max: maxBound(max), Incrementer: Incrementer{inc: 1}}
 }
 
-// NewClampedIncrementerWithValue creates a new counter with a starting value of 0 or greater.
+// NewClampedIncrementerWithValue creates a new counter from min to max with a starting value of val
+// clamped. A max of 0 means no maximum; use NewClampedIncrementerWithBounds for a maximum of 0.
 func NewClampedIncrementerWithValue(min, max, val int) ClampedIncrementer {
-	if max == 0 {
-		val = ClampMin(val, min)
-	} else {
-		val = Clamp(val, min, max)
-	}
+	return NewClampedIncrementerWithBounds(BoundAt(min), maxBound(max), val)
+}
 
-	return ClampedIncrementer{min: min, max: max, Incrementer: Incrementer{val: val, inc: 1, orig: val}}
+// NewClampedIncrementerWithBounds creates a new counter from min to max, either of which may be
+// Unbounded, with a starting value of val clamped.
+func NewClampedIncrementerWithBounds(min, max Bound, val int) ClampedIncrementer {
+	c := ClampedIncrementer{min: min, max: max, Incrementer: Incrementer{val: val, inc: 1}}
+	c.Clamp()
+	c.orig = c.val
+	return c
 }
 
 // NewClampedIncrementerFromJSON creates a new counter from a JSON representation.
@@ -33,32 +40,71 @@ func NewClampedIncrementerFromJSON(data []byte) (ClampedIncrementer, error) {
 	return c, err
 }
 
-// IsFull returns true if the counter is at the maximum value.
-func (c ClampedIncrementer) IsFull() bool { return c.val == c.max }
+// IsFull returns true if the counter has a maximum value and is at it.
+func (c ClampedIncrementer) IsFull() bool { return !c.max.IsUnbounded() && c.val == c.max.val }
+
+// Min returns the minimum value of the incrementer, or math.MinInt if it has no minimum.
+func (c ClampedIncrementer) Min() int { return c.min.or(math.MinInt) }
+
+// Max returns the maximum value of the incrementer, or math.MaxInt if it has no maximum.
+func (c ClampedIncrementer) Max() int { return c.max.or(math.MaxInt) }
 
-// Min returns the minimum value of the incrementer.
-func (c ClampedIncrementer) Min() int { return c.min }
+// MinBound returns the minimum of the incrementer.
+func (c ClampedIncrementer) MinBound() Bound { return c.min }
 
-// Max returns the maximum value of the incrementer.
-func (c ClampedIncrementer) Max() int { return c.max }
+// MaxBound returns the maximum of the incrementer.
+func (c ClampedIncrementer) MaxBound() Bound { return c.max }
 
 // Increment increases the counter by the incrementer value clamped.
-func (c *ClampedIncrementer) Increment() { c.val = c.val + c.inc; c.Clamp() }
+func (c *ClampedIncrementer) Increment() { c.val = AddSaturating(c.val, c.inc); c.Clamp() }
 
 // Decrement decreases the counter by the incrementer value clamped.
-func (c *ClampedIncrementer) Decrement() { c.val = c.val - c.inc; c.Clamp() }
+func (c *ClampedIncrementer) Decrement() { c.val = SubSaturating(c.val, c.inc); c.Clamp() }
 
 // Add increases the counter by the given number of val clamped.
-func (c *ClampedIncrementer) Add(val int) { c.val = c.val + val; c.Clamp() }
+func (c *ClampedIncrementer) Add(val int) { c.val = AddSaturating(c.val, val); c.Clamp() }
 
 // Remove decreases the counter by the given number of val clamped.
-func (c *ClampedIncrementer) Remove(val int) { c.val = c.val - val; c.Clamp() }
+func (c *ClampedIncrementer) Remove(val int) { c.val = SubSaturating(c.val, val); c.Clamp() }
+
+// AddChecked increases the counter by val clamped, or returns ErrOverflow and leaves the counter
+// unchanged if the unclamped result does not fit in an int.
+func (c *ClampedIncrementer) AddChecked(val int) error {
+	v, err := AddChecked(c.val, val)
+	if err != nil {
+		return err
+	}
+
+	c.val = v
+	c.Clamp()
+	return nil
+}
+
+// RemoveChecked decreases the counter by val clamped, or returns ErrOverflow and leaves the counter
+// unchanged if the unclamped result does not fit in an int.
+func (c *ClampedIncrementer) RemoveChecked(val int) error {
+	v, err := SubChecked(c.val, val)
+	if err != nil {
+		return err
+	}
+
+	c.val = v
+	c.Clamp()
+	return nil
+}
 
 // SetMin sets the minimum value of the incrementer.
-func (c *ClampedIncrementer) SetMin(min int) { c.min = min; c.Clamp() }
+func (c *ClampedIncrementer) SetMin(min int) { c.SetMinBound(BoundAt(min)) }
+
+// SetMax sets the maximmum value of the incrementer. A max of 0 removes the maximum; use SetMaxBound
+// for a maximum of 0.
+func (c *ClampedIncrementer) SetMax(max int) { c.SetMaxBound(maxBound(max)) }
 
-// SetMax sets the maximmum value of the incrementer.
-func (c *ClampedIncrementer) SetMax(max int) { c.max = max; c.Clamp() }
+// SetMinBound sets the minimum of the incrementer. Use Unbounded to remove the minimum.
+func (c *ClampedIncrementer) SetMinBound(min Bound) { c.min = min; c.Clamp() }
+
+// SetMaxBound sets the maximum of the incrementer. Use Unbounded to remove the maximum.
+func (c *ClampedIncrementer) SetMaxBound(max Bound) { c.max = max; c.Clamp() }
 
 // SetValue sets the counter value to the given number of val clamped.
 func (c *ClampedIncrementer) SetValue(val int) { c.val = val; c.Clamp() }
@@ -66,45 +112,103 @@ func (c *ClampedIncrementer) SetValue(val int) { c.val = val; c.Clamp() }
 // SetOrginalValue sets the counter's original value to the given number of val clamped.
 func (c *ClampedIncrementer) SetOriginalValue(val int) { c.orig = val; c.ClampOriginalValue() }
 
-// Clamp sets the value to the min max range. If max is 0 then the value will be clamped to the minimum.
-func (c *ClampedIncrementer) Clamp() {
-	if c.max == 0 {
-		c.val = ClampMin(c.val, c.min)
-		return
+// Clamp sets the value to the min max range. A missing min or max does not limit the value.
+func (c *ClampedIncrementer) Clamp() { c.val = Clamp(c.val, c.Min(), c.Max()) }
+
+// ClampOriginalValue sets the original value to the min max range. A missing min or max does not
+// limit the value.
+func (c *ClampedIncrementer) ClampOriginalValue() { c.orig = Clamp(c.orig, c.Min(), c.Max()) }
+
+// Fill sets the counter to the maximum value. Does nothing if there is no maximum.
+func (c *ClampedIncrementer) Fill() { c.val = c.max.or(c.val) }
+
+// Floor sets the counter to the minimum value. Does nothing if there is no minimum.
+func (c *ClampedIncrementer) Floor() { c.val = c.min.or(c.val) }
+
+// Ratio returns how far the value is from min to max, from 0 to 1. Returns 0 if there is no min or
+// max.
+func (c ClampedIncrementer) Ratio() float64 {
+	min, max, ok := c.bounds()
+	if !ok {
+		return 0
 	}
 
-	c.val = Clamp(c.val, c.min, c.max)
+	return ratio(c.val, min, max)
 }
 
-// ClampOriginalValue sets the original value to the min max range. If max is 0 then the value will
-// be clamped to the minimum.
-func (c *ClampedIncrementer) ClampOriginalValue() {
-	if c.max == 0 {
-		c.orig = ClampMin(c.orig, c.min)
+// Percent returns how far the value is from min to max, from 0 to 100, rounded down so only a full
+// incrementer is 100. Returns 0 if there is no min or max.
+func (c ClampedIncrementer) Percent() int { return c.Segments(100) }
+
+// Segments returns how many of n equal segments from min to max the value has completely filled, such
+// as the filled wedges of a clock drawn with n wedges. Returns 0 if there is no min or max or n is less
+// than 1.
+func (c ClampedIncrementer) Segments(n int) int {
+	min, max, ok := c.bounds()
+	if !ok {
+		return 0
+	}
+
+	return segments(c.val, min, max, n)
+}
+
+// SetFraction sets the value to the given fraction of the way from min to max, rounded with mode. f is
+// clamped to 0 to 1 and read as its shortest decimal representation, so 0.29 of 100 is exactly 29.
+// Does nothing if there is no min or max or f is NaN.
+func (c *ClampedIncrementer) SetFraction(f float64, mode RoundingMode) {
+	min, max, ok := c.bounds()
+	if !ok || math.IsNaN(f) {
 		return
 	}
 
-	c.orig = Clamp(c.orig, c.min, c.max)
+	c.val = atFraction(f, min, max, mode)
 }
 
-// Fill sets the counter to the maximum value.
-func (c *ClampedIncrementer) Fill() { c.val = c.max }
+// bounds returns the min and max, and false if either is unbounded.
+func (c ClampedIncrementer) bounds() (int, int, bool) {
+	min, hasMin := c.min.Value()
+	max, hasMax := c.max.Value()
+	return min, max, hasMin && hasMax
+}
 
-// Floor sets the counter to the minimum value.
-func (c *ClampedIncrementer) Floor() { c.val = c.min }
+// String returns a string representation of the Incrementer such as "3/10", or "3" if there is no
+// maximum.
+func (c ClampedIncrementer) String() string {
+	if c.max.IsUnbounded() {
+		return fmt.Sprintf("%d", c.val)
+	}
+
+	return fmt.Sprintf("%d/%d", c.val, c.max.val)
+}
+
+func (c ClampedIncrementer) view() formatView {
+	return formatView{name: "ClampedIncrementer", val: c.val, min: c.min, max: c.max, str: c.String(),
+		fields: []formatField{{"val", c.val}, {"min", c.min}, {"max", c.max}, {"inc", c.inc}, {"orig", c.orig}}}
+}
 
-// String returns a string representation of the Incrementer.
-func (c ClampedIncrementer) String() string { return fmt.Sprintf("%d/%d", c.val, c.max) }
+// Format implements fmt.Formatter. See the package's format verbs.
+func (c ClampedIncrementer) Format(f fmt.State, verb rune) { c.view().format(f, verb) }
 
-// MarshalJSON returns a JSON representation of the counter.
+// MarshalJSON returns a JSON representation of the counter. A missing min or max is null.
 func (c ClampedIncrementer) MarshalJSON() ([]byte, error) {
 	j, _ := c.Incrementer.MarshalJSON()
-	return []byte(fmt.Sprintf(`{"min":%d,"max":%d,"incrementer":%s}`, c.min, c.max, j)), nil
+	min, _ := c.min.MarshalJSON()
+	max, _ := c.max.MarshalJSON()
+	return []byte(fmt.Sprintf(
+		`{"version":%d,"min":%s,"max":%s,"incrementer":%s}`,
+		clampedIncrementerVersion, min, max, j,
+	)), nil
 }
 
-func (c *ClampedIncrementer) UnmarshalJSON(data []byte) error {
-	var iData []byte
+type clampedIncrementerJSON struct {
+	Min         Bound       `json:"min"`
+	Max         Bound       `json:"max"`
+	Incrementer Incrementer `json:"incrementer"`
+}
 
+// UnmarshalJSON parses a JSON representation of the counter. Older versions are migrated to the latest
+// version first.
+func (c *ClampedIncrementer) UnmarshalJSON(data []byte) error {
 	if data == nil {
 		return fmt.Errorf("Incrementer.UnmarshalJSON(): data was nil")
 	}
@@ -113,34 +217,73 @@ func (c *ClampedIncrementer) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	data = data[1 : len(data)-1]
-	if _, err := fmt.Sscanf(string(data), `"min":%d,"max":%d,"incrementer":%s`, &c.min, &c.max, &iData); err != nil {
+	var j clampedIncrementerJSON
+	err := unmarshalVersioned(
+		TypeClampedIncrementer, "ClampedIncrementer", data, &j, "min", "max", "incrementer",
+	)
+	if err != nil {
 		return err
 	}
 
-	if c.max != 0 && c.min >= c.max {
-		return fmt.Errorf("invalid ClampedIncrementer: min must be less than max")
-	}
+	c.min = j.Min
+	c.max = j.Max
+	c.Incrementer = j.Incrementer
+
+	return c.validate()
+}
+
+// MarshalBinary returns a compact binary representation of the counter.
+func (c ClampedIncrementer) MarshalBinary() ([]byte, error) {
+	w := newBinaryWriter()
+	w.clamped(c)
+	return w.buf, nil
+}
 
-	i, err := NewIncrementerFromJSON(iData)
+// UnmarshalBinary parses a binary representation of the counter.
+func (c *ClampedIncrementer) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader("ClampedIncrementer", data)
 	if err != nil {
 		return err
 	}
 
-	c.Incrementer = i
-	if c.val < c.min {
+	n := r.clamped()
+	if err := r.close(); err != nil {
+		return err
+	}
+
+	if err := n.validate(); err != nil {
+		return err
+	}
+
+	*c = n
+	return nil
+}
+
+// MarshalText returns a compact text representation of the counter.
+func (c ClampedIncrementer) MarshalText() ([]byte, error) { return marshalText(c) }
+
+// UnmarshalText parses a text representation of the counter.
+func (c *ClampedIncrementer) UnmarshalText(text []byte) error { return unmarshalText(c, text) }
+
+func (c ClampedIncrementer) validate() error {
+	min, max := c.Min(), c.Max()
+	if !c.min.IsUnbounded() && !c.max.IsUnbounded() && min >= max {
+		return fmt.Errorf("invalid ClampedIncrementer: min must be less than max")
+	}
+
+	if c.val < min {
 		return fmt.Errorf("invalid ClampedIncrementer: Incrementer.val must be min or greater")
 	}
 
-	if c.orig < c.min {
+	if c.orig < min {
 		return fmt.Errorf("invalid ClampedIncrementer: Incrementer.orig must be min or greater")
 	}
 
-	if c.max != 0 && c.val > c.max {
+	if c.val > max {
 		return fmt.Errorf("invalid ClampedIncrementer: Incrementer.val must min <= val <= max")
 	}
 
-	if c.max != 0 && c.orig > c.max {
+	if c.orig > max {
 		return fmt.Errorf("invalid ClampedIncrementer: Incrementer.orig must min <= orig <= max")
 	}
 
@@ -1,6 +1,8 @@
 package incrementers
 
 import (
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -9,8 +11,8 @@ import (
 func TestClampedIncrementerNew(t *testing.T) {
 	require := require.New(t)
 	c := NewClampedIncrementer(-4, 4)
-	require.Equal(-4, c.min)
-	require.Equal(4, c.max)
+	require.Equal(BoundAt(-4), c.min)
+	require.Equal(BoundAt(4), c.max)
 	require.Equal(1, c.Inc())
 	require.Equal(0, c.Value())
 	require.Equal(0, c.Original())
@@ -21,8 +23,8 @@ func TestClampedIncrementerNewWithValue(t *testing.T) {
 
 	t.Run("zero", func(t *testing.T) {
 		c := NewClampedIncrementerWithValue(-4, 4, 0)
-		require.Equal(-4, c.min)
-		require.Equal(4, c.max)
+		require.Equal(BoundAt(-4), c.min)
+		require.Equal(BoundAt(4), c.max)
 		require.Equal(1, c.inc)
 		require.Equal(0, c.val)
 		require.Equal(0, c.orig)
@@ -30,8 +32,8 @@ func TestClampedIncrementerNewWithValue(t *testing.T) {
 
 	t.Run("positive", func(t *testing.T) {
 		c := NewClampedIncrementerWithValue(-4, 4, 3)
-		require.Equal(-4, c.min)
-		require.Equal(4, c.max)
+		require.Equal(BoundAt(-4), c.min)
+		require.Equal(BoundAt(4), c.max)
 		require.Equal(1, c.inc)
 		require.Equal(3, c.val)
 		require.Equal(3, c.orig)
@@ -39,8 +41,8 @@ func TestClampedIncrementerNewWithValue(t *testing.T) {
 
 	t.Run("negative", func(t *testing.T) {
 		c := NewClampedIncrementerWithValue(-4, 4, -3)
-		require.Equal(-4, c.min)
-		require.Equal(4, c.max)
+		require.Equal(BoundAt(-4), c.min)
+		require.Equal(BoundAt(4), c.max)
 		require.Equal(1, c.inc)
 		require.Equal(-3, c.val)
 		require.Equal(-3, c.orig)
@@ -48,20 +50,65 @@ func TestClampedIncrementerNewWithValue(t *testing.T) {
 
 	t.Run("no max", func(t *testing.T) {
 		c := NewClampedIncrementerWithValue(-4, 0, 25)
-		require.Equal(-4, c.min)
-		require.Equal(0, c.max)
+		require.Equal(BoundAt(-4), c.min)
+		require.Equal(Unbounded(), c.max)
 		require.Equal(1, c.inc)
 		require.Equal(25, c.val)
 		require.Equal(25, c.orig)
 	})
+
+	t.Run("no max round trip", func(t *testing.T) {
+		data, err := NewClampedIncrementer(0, 0).MarshalJSON()
+		require.NoError(err, "ClampedIncrementer.MarshalJSON() returned an error: %s", err)
+		c, err := NewClampedIncrementerFromJSON(data)
+		require.NoError(err, "ClampedIncrementer.UnmarshalJSON() returned an error: %s", err)
+		require.True(c.MaxBound().IsUnbounded())
+	})
+}
+
+func TestClampedIncrementerNewWithBounds(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("no max", func(t *testing.T) {
+		c := NewClampedIncrementerWithBounds(BoundAt(-4), Unbounded(), 25)
+		require.Equal(-4, c.Min())
+		require.Equal(math.MaxInt, c.Max())
+		require.True(c.MaxBound().IsUnbounded())
+		require.Equal(25, c.val)
+		require.Equal(25, c.orig)
+	})
+
+	t.Run("zero max", func(t *testing.T) {
+		c := NewClampedIncrementerWithBounds(BoundAt(-4), BoundAt(0), 25)
+		require.Equal(BoundAt(0), c.max)
+		require.Equal(0, c.val)
+		require.Equal(0, c.orig)
+		require.True(c.IsFull())
+	})
+
+	t.Run("no min", func(t *testing.T) {
+		c := NewClampedIncrementerWithBounds(Unbounded(), BoundAt(0), 5)
+		require.Equal(math.MinInt, c.Min())
+		require.Equal(0, c.Max())
+		require.Equal(0, c.val)
+
+		c.Remove(1000)
+		require.Equal(-1000, c.val)
+	})
+
+	t.Run("unbounded", func(t *testing.T) {
+		c := NewClampedIncrementerWithBounds(Unbounded(), Unbounded(), -25)
+		require.Equal(-25, c.val)
+		require.False(c.IsFull())
+	})
 }
 
 func TestClampedIncrementerNewFromJSON(t *testing.T) {
 	require := require.New(t)
 	c, err := NewClampedIncrementerFromJSON([]byte(`{"min":-4,"max":4,"incrementer":{"inc":1,"val":2,"orig":3}}`))
 	require.NoError(err, "ClampedIncrementer.NewFromJSON() returned an error: %s", err)
-	require.Equal(-4, c.min)
-	require.Equal(4, c.max)
+	require.Equal(BoundAt(-4), c.min)
+	require.Equal(BoundAt(4), c.max)
 	require.Equal(1, c.inc)
 	require.Equal(2, c.val)
 	require.Equal(3, c.orig)
@@ -69,7 +116,7 @@ func TestClampedIncrementerNewFromJSON(t *testing.T) {
 
 func TestClampedIncrementerIsFull(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("empty", func(t *testing.T) {
 		require.False(c.IsFull())
@@ -83,19 +130,19 @@ func TestClampedIncrementerIsFull(t *testing.T) {
 
 func TestClampedIncrementerMin(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 	require.Equal(-4, c.Min())
 }
 
 func TestClampedIncrementerMax(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 	require.Equal(4, c.Max())
 }
 
 func TestClampedIncrementerIncrement(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("zero", func(t *testing.T) {
 		c.val = 0
@@ -134,7 +181,7 @@ func TestClampedIncrementerIncrement(t *testing.T) {
 
 func TestClampedIncrementerDecrement(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("zero", func(t *testing.T) {
 		c.val = 4
@@ -174,7 +221,7 @@ func TestClampedIncrementerDecrement(t *testing.T) {
 
 func TestClampedIncrementerAdd(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("zero", func(t *testing.T) {
 		c.val = 4
@@ -209,7 +256,7 @@ func TestClampedIncrementerAdd(t *testing.T) {
 
 func TestClampedIncrementerRemove(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("zero", func(t *testing.T) {
 		c.val = 4
@@ -244,47 +291,47 @@ func TestClampedIncrementerRemove(t *testing.T) {
 
 func TestClampedIncrementerSetMin(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("zero", func(t *testing.T) {
 		c.SetMin(0)
-		require.Equal(0, c.min)
+		require.Equal(BoundAt(0), c.min)
 	})
 
 	t.Run("positive", func(t *testing.T) {
 		c.SetMin(1)
-		require.Equal(1, c.min)
+		require.Equal(BoundAt(1), c.min)
 	})
 
 	t.Run("negative", func(t *testing.T) {
 		c.SetMin(-1)
-		require.Equal(-1, c.min)
+		require.Equal(BoundAt(-1), c.min)
 	})
 }
 
 func TestClampedIncrementerSetMax(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("zero", func(t *testing.T) {
 		c.SetMax(0)
-		require.Equal(0, c.max)
+		require.Equal(Unbounded(), c.max)
 	})
 
 	t.Run("positive", func(t *testing.T) {
 		c.SetMax(1)
-		require.Equal(1, c.max)
+		require.Equal(BoundAt(1), c.max)
 	})
 
 	t.Run("negative", func(t *testing.T) {
 		c.SetMax(-1)
-		require.Equal(-1, c.max)
+		require.Equal(BoundAt(-1), c.max)
 	})
 }
 
 func TestClampedIncrementerSetValue(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("positive", func(t *testing.T) {
 		c.SetValue(4)
@@ -315,7 +362,7 @@ func TestClampedIncrementerSetValue(t *testing.T) {
 
 func TestClampedIncrementerSetOriginalValue(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("positive", func(t *testing.T) {
 		c.SetOriginalValue(4)
@@ -350,7 +397,7 @@ func TestClampedIncrementerSetOriginalValue(t *testing.T) {
 
 func TestClampedIncrementerClamp(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("min", func(t *testing.T) {
 		c.val = -5
@@ -365,7 +412,7 @@ func TestClampedIncrementerClamp(t *testing.T) {
 	})
 
 	t.Run("no max", func(t *testing.T) {
-		c.max = 0
+		c.max = Unbounded()
 		c.val = 5
 		c.Clamp()
 		require.Equal(5, c.val)
@@ -374,7 +421,7 @@ func TestClampedIncrementerClamp(t *testing.T) {
 
 func TestClampedIncrementerClampOriginalValue(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("min", func(t *testing.T) {
 		c.orig = -5
@@ -389,7 +436,7 @@ func TestClampedIncrementerClampOriginalValue(t *testing.T) {
 	})
 
 	t.Run("no max", func(t *testing.T) {
-		c.max = 0
+		c.max = Unbounded()
 		c.orig = 5
 		c.ClampOriginalValue()
 		require.Equal(5, c.orig)
@@ -398,23 +445,39 @@ func TestClampedIncrementerClampOriginalValue(t *testing.T) {
 
 func TestClampedIncrementerFill(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	c.Fill()
 	require.Equal(4, c.val)
+	require.True(c.IsFull())
+
+	t.Run("no max", func(t *testing.T) {
+		c.SetMaxBound(Unbounded())
+		c.val = 2
+		c.Fill()
+		require.Equal(2, c.val)
+		require.False(c.IsFull())
+	})
 }
 
 func TestClampedIncrementerFloor(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	c.Floor()
 	require.Equal(-4, c.val)
+
+	t.Run("no min", func(t *testing.T) {
+		c.SetMinBound(Unbounded())
+		c.val = 2
+		c.Floor()
+		require.Equal(2, c.val)
+	})
 }
 
 func TestClampedIncrementerString(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("default", func(t *testing.T) {
 		require.Equal("0/4", c.String())
@@ -429,23 +492,39 @@ func TestClampedIncrementerString(t *testing.T) {
 		c.val = -4
 		require.Equal("-4/4", c.String())
 	})
+
+	t.Run("no max", func(t *testing.T) {
+		c.max = Unbounded()
+		require.Equal("-4", c.String())
+	})
 }
 
 func TestClampedIncrementerMarshalJSON(t *testing.T) {
 	require := require.New(t)
-	c := ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{}}
+	c := ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{}}
 
 	t.Run("empty", func(t *testing.T) {
 		data, err := c.MarshalJSON()
 		require.NoError(err, "Incrementer.MarshalJSON() returned an error: %s", err)
-		require.Equal(`{"min":-4,"max":4,"incrementer":{"inc":0,"val":0,"orig":0}}`, string(data))
+		require.Equal(`{"version":3,"min":-4,"max":4,"incrementer":{"version":2,"inc":0,"val":0,"orig":0}}`, string(data))
 	})
 
 	t.Run("set", func(t *testing.T) {
-		c = ClampedIncrementer{min: -4, max: 4, Incrementer: Incrementer{inc: 1, val: 2, orig: 3}}
+		c = ClampedIncrementer{min: BoundAt(-4), max: BoundAt(4), Incrementer: Incrementer{inc: 1, val: 2, orig: 3}}
+		data, err := c.MarshalJSON()
+		require.NoError(err, "Incrementer.MarshalJSON() returned an error: %s", err)
+		require.Equal(`{"version":3,"min":-4,"max":4,"incrementer":{"version":2,"inc":1,"val":2,"orig":3}}`, string(data))
+	})
+
+	t.Run("unbounded", func(t *testing.T) {
+		c = NewClampedIncrementerWithBounds(Unbounded(), Unbounded(), 2)
 		data, err := c.MarshalJSON()
 		require.NoError(err, "Incrementer.MarshalJSON() returned an error: %s", err)
-		require.Equal(`{"min":-4,"max":4,"incrementer":{"inc":1,"val":2,"orig":3}}`, string(data))
+		require.Equal(`{"version":3,"min":null,"max":null,"incrementer":{"version":2,"inc":1,"val":2,"orig":2}}`, string(data))
+
+		var got ClampedIncrementer
+		require.NoError(got.UnmarshalJSON(data))
+		require.Equal(c, got)
 	})
 }
 
@@ -487,7 +566,7 @@ func TestClampedIncrementerUnmarshalJSON(t *testing.T) {
 		// Make "min" a string to force error.
 		err := c.UnmarshalJSON([]byte(`{"min":b,"max":4,"incrementer":{"inc":1,"val":2,"orig":3}}`))
 		require.Error(err, "ClampedIncrementer.UnmarshalJSON() did not return an error")
-		require.Equal("expected integer", err.Error())
+		require.Equal("invalid character 'b' looking for beginning of value", err.Error())
 	})
 
 	t.Run("invalid min", func(t *testing.T) {
@@ -516,7 +595,7 @@ func TestClampedIncrementerUnmarshalJSON(t *testing.T) {
 		// Make "inc" a string to force error.
 		err := c.UnmarshalJSON([]byte(`{"min":-4,"max":4,"incrementer":{"inc":b,"val":2,"orig":3}}`))
 		require.Error(err, "ClampedIncrementer.UnmarshalJSON() did not return error")
-		require.Equal("expected integer", err.Error())
+		require.Equal("invalid character 'b' looking for beginning of value", err.Error())
 	})
 
 	t.Run("invalid val", func(t *testing.T) {
@@ -558,3 +637,106 @@ func TestClampedIncrementer(t *testing.T) {
 	require.Equal(0, c.Value())
 }
 */
+
+func TestClampedIncrementerOverflow(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("saturate", func(t *testing.T) {
+		c := NewClampedIncrementerWithValue(-10, 10, 1)
+		c.Add(math.MaxInt)
+		require.Equal(10, c.Value(), "Add() wrapped to the wrong side")
+
+		c.Remove(math.MaxInt)
+		c.Remove(math.MaxInt)
+		require.Equal(-10, c.Value())
+
+		n := NewCounterWithValue(1)
+		n.Add(math.MaxInt)
+		require.Equal(math.MaxInt, n.Value())
+	})
+
+	t.Run("checked", func(t *testing.T) {
+		c := NewClampedIncrementerWithValue(-10, 10, 1)
+		require.ErrorIs(c.AddChecked(math.MaxInt), ErrOverflow)
+		require.Equal(1, c.Value())
+
+		require.ErrorIs(c.RemoveChecked(math.MinInt), ErrOverflow)
+		require.NoError(c.RemoveChecked(math.MaxInt))
+		require.Equal(-10, c.Value())
+	})
+}
+
+func FuzzClampedIncrementerAdd(f *testing.F) {
+	f.Add(-10, 10, 1, math.MaxInt)
+	f.Add(-10, 10, -10, math.MinInt)
+	f.Add(math.MinInt, math.MaxInt, math.MaxInt, 1)
+	f.Add(0, 0, 5, math.MaxInt)
+
+	f.Fuzz(func(t *testing.T, min, max, val, n int) {
+		if min > max {
+			return
+		}
+
+		c := NewClampedIncrementerWithBounds(BoundAt(min), BoundAt(max), val)
+		start := c.Value()
+		c.Add(n)
+
+		want, ok := bigResult(new(big.Int).Add(big.NewInt(int64(start)), big.NewInt(int64(n))))
+		want = Clamp(want, min, max)
+
+		if c.Value() != want {
+			t.Fatalf("%d/%d..%d Add(%d) = %d, want %d", start, min, max, n, c.Value(), want)
+		}
+
+		c.SetValue(start)
+		if err := c.AddChecked(n); ok != (err == nil) {
+			t.Fatalf("%d AddChecked(%d) returned %v", start, n, err)
+		}
+	})
+}
+
+func TestClampedIncrementerRatio(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("bounded", func(t *testing.T) {
+		c := NewClampedIncrementerWithValue(-10, 90, 27)
+		require.Equal(0.37, c.Ratio())
+		require.Equal(37, c.Percent())
+		require.Equal(1, c.Segments(3))
+		require.Equal(0, c.Segments(0))
+
+		c.SetValue(89)
+		require.Equal(99, c.Percent(), "Percent() rounded up before the incrementer was full")
+		c.Fill()
+		require.Equal(1.0, c.Ratio())
+		require.Equal(100, c.Percent())
+	})
+
+	t.Run("unbounded", func(t *testing.T) {
+		c := NewClampedIncrementerWithBounds(BoundAt(0), Unbounded(), 50)
+		require.Equal(0.0, c.Ratio())
+		require.Equal(0, c.Percent())
+		require.Equal(0, c.Segments(4))
+	})
+}
+
+func TestClampedIncrementerSetFraction(t *testing.T) {
+	require := require.New(t)
+	c := NewClampedIncrementerWithValue(0, 7, 0)
+
+	for mode, want := range map[RoundingMode]int{RoundDown: 3, RoundUp: 4, RoundNearest: 4} {
+		c.SetFraction(0.5, mode)
+		require.Equal(want, c.Value(), mode.String())
+	}
+
+	c.SetFraction(1.5, RoundDown)
+	require.Equal(7, c.Value())
+	c.SetFraction(math.NaN(), RoundDown)
+	require.Equal(7, c.Value())
+
+	t.Run("unbounded", func(t *testing.T) {
+		c := NewClampedIncrementerWithBounds(Unbounded(), BoundAt(10), 2)
+		c.SetFraction(0.5, RoundDown)
+		require.Equal(2, c.Value())
+	})
+}
@@ -28,14 +28,26 @@ type Clock interface {
 	UnmarshalJSON([]byte) error
 }
 
+// Progress reports and sets how far a value is from its min to its max. The Clocks made by NewClock
+// implement it, as do ClampedIncrementer, WrappingIncrementer, SnappedIncrementer, DynamicIncrementer
+// and Pool.
+type Progress interface {
+	Ratio() float64
+	Percent() int
+	Segments(int) int
+	SetFraction(float64, RoundingMode)
+}
+
 // NewClock creates a new clock with from 0 to the maximum value of steps.
 func NewClock(steps int) Clock {
-	return &ClampedIncrementer{min: 0, max: steps, Incrementer: Incrementer{inc: 1}}
+	return &ClampedIncrementer{min: BoundAt(0), max: BoundAt(steps), Incrementer: Incrementer{inc: 1}}
 }
 
 // NewClockWithTicks creates a new clock from 0 to the maximum steps, with a starting value of ticks.
 func NewClockWithTicks(steps, ticks int) Clock {
-	return &ClampedIncrementer{min: 0, max: steps, Incrementer: Incrementer{inc: 1, val: ticks, orig: ticks}}
+	return &ClampedIncrementer{
+		min: BoundAt(0), max: BoundAt(steps), Incrementer: Incrementer{inc: 1, val: ticks, orig: ticks},
+	}
 }
 
 // NewClockFromJSON creates a new clock from a JSON representation.
@@ -45,11 +57,11 @@ func NewClockFromJSON(data []byte) (Clock, error) {
 		return nil, err
 	}
 
-	if i.min != 0 {
+	if min, ok := i.min.Value(); !ok || min != 0 {
 		return nil, fmt.Errorf("invalid Clock: min must be 0")
 	}
 
-	if i.max < 1 {
+	if max, ok := i.max.Value(); !ok || max < 1 {
 		return nil, fmt.Errorf("invalid Clock: max must be greater than 0")
 	}
 
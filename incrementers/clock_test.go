@@ -6,6 +6,11 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var (
+	_ Progress = &ClampedIncrementer{}
+	_ Progress = &Pool{}
+)
+
 func TestClockNew(t *testing.T) {
 	require := require.New(t)
 	c := NewClock(4)
@@ -35,7 +40,7 @@ func TestClockNewFromJSON(t *testing.T) {
 	t.Run("invalid incrementer", func(t *testing.T) {
 		_, err := NewClockFromJSON([]byte(`{"min":0,"max":0,"incrementer":{"inc":b,"val":2,"orig":3}}`))
 		require.Error(err, "Clock.NewFromJSON() did not return an error")
-		require.Equal("expected integer", err.Error())
+		require.Equal("invalid character 'b' looking for beginning of value", err.Error())
 	})
 
 	t.Run("invalid min", func(t *testing.T) {
@@ -83,3 +88,15 @@ func TestClock(t *testing.T) {
 	c.Decrement()
 	require.Equal(0, c.Value())
 }
+
+func TestClockSegments(t *testing.T) {
+	require := require.New(t)
+	c, ok := NewClockWithTicks(8, 5).(Progress)
+	require.True(ok, "Clock is not a Progress")
+	require.Equal(2, c.Segments(4))
+	require.Equal(62, c.Percent())
+	require.Equal(0.625, c.Ratio())
+
+	c.SetFraction(0.25, RoundDown)
+	require.Equal(2, c.(Clock).Value())
+}
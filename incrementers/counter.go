@@ -29,17 +29,12 @@ type Counter interface {
 }
 
 // NewCounter creates a new counter with a minimum value of 0 and no maximum value.
-func NewCounter() Counter {
-	return &ClampedIncrementer{min: 0, max: 0, Incrementer: Incrementer{inc: 1}}
-}
+func NewCounter() Counter { return NewCounterWithValue(0) }
 
 // NewCounterWithValue creates a new counter with a starting value of 0 or greater.
 func NewCounterWithValue(val int) Counter {
-	if val < 0 {
-		val = 0
-	}
-
-	return &ClampedIncrementer{min: 0, max: 0, Incrementer: Incrementer{val: val, inc: 1, orig: val}}
+	c := NewClampedIncrementerWithBounds(BoundAt(0), Unbounded(), val)
+	return &c
 }
 
 func NewCounterFromJSON(data []byte) (Counter, error) {
@@ -48,12 +43,12 @@ func NewCounterFromJSON(data []byte) (Counter, error) {
 		return nil, err
 	}
 
-	if i.min != 0 {
+	if min, ok := i.min.Value(); !ok || min != 0 {
 		return nil, fmt.Errorf("invalid Counter: min must be 0")
 	}
 
-	if i.max != 0 {
-		return nil, fmt.Errorf("invalid Counter: max must be 0")
+	if !i.max.IsUnbounded() {
+		return nil, fmt.Errorf("invalid Counter: max must be unbounded")
 	}
 
 	return &i, nil
@@ -53,7 +53,7 @@ func TestCounterNewFromJSON(t *testing.T) {
 	t.Run("invalid incrementer", func(t *testing.T) {
 		_, err := NewCounterFromJSON([]byte(`{"min":0,"max":0,"incrementer":{"inc":b,"val":2,"orig":3}}`))
 		require.Error(err, "Counter.NewFromJSON() did not return an error")
-		require.Equal("expected integer", err.Error())
+		require.Equal("invalid character 'b' looking for beginning of value", err.Error())
 	})
 
 	t.Run("invalid min", func(t *testing.T) {
@@ -65,7 +65,15 @@ func TestCounterNewFromJSON(t *testing.T) {
 	t.Run("invalid max", func(t *testing.T) {
 		_, err := NewCounterFromJSON([]byte(`{"min":0,"max":4,"incrementer":{"inc":1,"val":2,"orig":3}}`))
 		require.Error(err, "Counter.NewFromJSON() did not return an error")
-		require.Equal("invalid Counter: max must be 0", err.Error())
+		require.Equal("invalid Counter: max must be unbounded", err.Error())
+	})
+
+	t.Run("null max", func(t *testing.T) {
+		c, err := NewCounterFromJSON([]byte(`{"version":3,"min":0,"max":null,"incrementer":{"inc":1,"val":2,"orig":3}}`))
+		require.NoError(err, "Counter.NewFromJSON() returned an error: %s", err)
+		c.Add(1000)
+		require.Equal(1002, c.Value())
+		require.False(c.IsFull())
 	})
 }
 
@@ -0,0 +1,387 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// The replicated counters in this package are conflict-free: each client, or replica, changes only
+// its own part of the state, and merging keeps the larger count for every part. Clients can change
+// their copy of a counter while offline and merge any other copies in any order, any number of times,
+// and every copy ends with the same value.
+//
+// Each client creates its copy with Fork from a counter it received, or from JSON, and gives it a
+// unique replica name. Merge only combines copies with the same starting value and bounds.
+
+// gcounter is a grow-only count per replica. Merging keeps the highest count for each replica.
+type gcounter map[string]int
+
+// sum returns the total of every count, or math.MaxInt if it does not fit in an int. Counts are never
+// negative, so the total is the same in any order.
+func (g gcounter) sum() int {
+	var total int
+	for _, n := range g {
+		total = AddSaturating(total, n)
+	}
+
+	return total
+}
+
+// net returns base + p - n, or math.MaxInt or math.MinInt if it does not fit in an int. p and n are
+// never negative, so p - n always fits.
+func net(base int, p, n gcounter) int { return AddSaturating(base, p.sum()-n.sum()) }
+
+func (g *gcounter) add(replica string, n int) {
+	if n <= 0 {
+		return
+	}
+
+	if *g == nil {
+		*g = make(gcounter)
+	}
+
+	(*g)[replica] = AddSaturating((*g)[replica], n)
+}
+
+func (g *gcounter) merge(o gcounter) {
+	for r, n := range o {
+		if n > (*g)[r] {
+			if *g == nil {
+				*g = make(gcounter)
+			}
+
+			(*g)[r] = n
+		}
+	}
+}
+
+func (g gcounter) clone() gcounter {
+	if g == nil {
+		return nil
+	}
+
+	c := make(gcounter, len(g))
+	for r, n := range g {
+		c[r] = n
+	}
+
+	return c
+}
+
+func (g gcounter) valid() bool {
+	for _, n := range g {
+		if n < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GCounter is a replicated counter which can only grow. Decrement, Remove, Empty and SetMax do
+// nothing, and SetValue and Reset only raise the value.
+type GCounter struct {
+	replica string
+	inc     int
+	orig    int
+	base    int
+	counts  gcounter
+}
+
+// NewGCounter creates a new GCounter with a value of 0 for the given replica.
+func NewGCounter(replica string) *GCounter { return NewGCounterWithValue(replica, 0) }
+
+// NewGCounterWithValue creates a new GCounter with a starting value for the given replica.
+func NewGCounterWithValue(replica string, val int) *GCounter {
+	return &GCounter{replica: replica, inc: 1, orig: val, base: val}
+}
+
+// NewGCounterFromJSON creates a new GCounter from a JSON representation.
+func NewGCounterFromJSON(data []byte) (*GCounter, error) {
+	g := &GCounter{}
+	err := g.UnmarshalJSON(data)
+	return g, err
+}
+
+// Replica returns the name of the replica which makes this copy's changes.
+func (g *GCounter) Replica() string { return g.replica }
+
+// Fork returns a copy of the GCounter for another replica.
+func (g *GCounter) Fork(replica string) *GCounter {
+	f := *g
+	f.replica = replica
+	f.counts = g.counts.clone()
+	return &f
+}
+
+// Merge adds the changes from another copy of the GCounter.
+func (g *GCounter) Merge(o *GCounter) error {
+	if g.base != o.base {
+		return fmt.Errorf("invalid GCounter: cannot merge counters with different starting values")
+	}
+
+	g.counts.merge(o.counts)
+	return nil
+}
+
+// Inc returns the amount Increment adds.
+func (g *GCounter) Inc() int { return g.inc }
+
+// Value returns the merged value of the GCounter.
+func (g *GCounter) Value() int { return net(g.base, g.counts, nil) }
+
+// Original returns the value Reset raises the GCounter to.
+func (g *GCounter) Original() int { return g.orig }
+
+// IsFull always returns false. A GCounter has no maximum.
+func (g *GCounter) IsFull() bool { return false }
+
+// IsEmpty returns true if the value is 0.
+func (g *GCounter) IsEmpty() bool { return g.Value() == 0 }
+
+// Increment adds the incrementer value if it is positive.
+func (g *GCounter) Increment() { g.Add(g.inc) }
+
+// Decrement does nothing. A GCounter can only grow.
+func (g *GCounter) Decrement() {}
+
+// Add adds val if it is positive.
+func (g *GCounter) Add(val int) { g.counts.add(g.replica, val) }
+
+// Remove does nothing. A GCounter can only grow.
+func (g *GCounter) Remove(int) {}
+
+// SetMax does nothing. A GCounter has no maximum.
+func (g *GCounter) SetMax(int) {}
+
+// SetIncrementer sets the amount Increment adds.
+func (g *GCounter) SetIncrementer(inc int) { g.inc = inc }
+
+// SetValue raises the value to val. Lower values are ignored.
+func (g *GCounter) SetValue(val int) { g.Add(SubSaturating(val, g.Value())) }
+
+// SetOriginalValue sets the value Reset raises the GCounter to.
+func (g *GCounter) SetOriginalValue(val int) { g.orig = val }
+
+// Empty does nothing. A GCounter can only grow.
+func (g *GCounter) Empty() {}
+
+// Reset raises the value to the original value.
+func (g *GCounter) Reset() { g.SetValue(g.orig) }
+
+// String returns the value of the GCounter.
+func (g *GCounter) String() string { return fmt.Sprintf("%d", g.Value()) }
+
+func (g *GCounter) view() formatView {
+	val := g.Value()
+	return formatView{name: "GCounter", val: val, str: g.String(), fields: []formatField{
+		{"replica", g.replica}, {"val", val}, {"inc", g.inc}, {"orig", g.orig},
+	}}
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (g *GCounter) Format(f fmt.State, verb rune) { g.view().format(f, verb) }
+
+type gCounterJSON struct {
+	Version int      `json:"version"`
+	Replica string   `json:"replica"`
+	Inc     int      `json:"inc"`
+	Orig    int      `json:"orig"`
+	Base    int      `json:"base"`
+	Counts  gcounter `json:"counts,omitempty"`
+}
+
+// MarshalJSON returns a JSON representation of the GCounter including every replica's count.
+func (g *GCounter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(gCounterJSON{
+		Version: gCounterVersion,
+		Replica: g.replica,
+		Inc:     g.inc,
+		Orig:    g.orig,
+		Base:    g.base,
+		Counts:  g.counts,
+	})
+}
+
+// UnmarshalJSON parses a JSON representation of the GCounter.
+func (g *GCounter) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("GCounter.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" || string(data) == `""` {
+		return nil
+	}
+
+	var j gCounterJSON
+	if err := unmarshalVersioned(TypeGCounter, "GCounter", data, &j, "replica", "base"); err != nil {
+		return err
+	}
+
+	if !j.Counts.valid() {
+		return fmt.Errorf("invalid GCounter: counts must be 0 or greater")
+	}
+
+	*g = GCounter{replica: j.Replica, inc: j.Inc, orig: j.Orig, base: j.Base, counts: j.Counts}
+	return nil
+}
+
+// PNCounter is a replicated counter which can grow and shrink. It has no minimum or maximum; use a
+// BoundedCounter to keep the value in a range.
+type PNCounter struct {
+	replica string
+	inc     int
+	orig    int
+	base    int
+	p       gcounter // Amount added by each replica.
+	n       gcounter // Amount removed by each replica.
+}
+
+// NewPNCounter creates a new PNCounter with a value of 0 for the given replica.
+func NewPNCounter(replica string) *PNCounter { return NewPNCounterWithValue(replica, 0) }
+
+// NewPNCounterWithValue creates a new PNCounter with a starting value for the given replica.
+func NewPNCounterWithValue(replica string, val int) *PNCounter {
+	return &PNCounter{replica: replica, inc: 1, orig: val, base: val}
+}
+
+// NewPNCounterFromJSON creates a new PNCounter from a JSON representation.
+func NewPNCounterFromJSON(data []byte) (*PNCounter, error) {
+	c := &PNCounter{}
+	err := c.UnmarshalJSON(data)
+	return c, err
+}
+
+// Replica returns the name of the replica which makes this copy's changes.
+func (c *PNCounter) Replica() string { return c.replica }
+
+// Fork returns a copy of the PNCounter for another replica.
+func (c *PNCounter) Fork(replica string) *PNCounter {
+	f := *c
+	f.replica = replica
+	f.p = c.p.clone()
+	f.n = c.n.clone()
+	return &f
+}
+
+// Merge adds the changes from another copy of the PNCounter.
+func (c *PNCounter) Merge(o *PNCounter) error {
+	if c.base != o.base {
+		return fmt.Errorf("invalid PNCounter: cannot merge counters with different starting values")
+	}
+
+	c.p.merge(o.p)
+	c.n.merge(o.n)
+	return nil
+}
+
+// Inc returns the amount Increment adds.
+func (c *PNCounter) Inc() int { return c.inc }
+
+// Value returns the merged value of the PNCounter.
+func (c *PNCounter) Value() int { return net(c.base, c.p, c.n) }
+
+// Original returns the value Reset sets the PNCounter to.
+func (c *PNCounter) Original() int { return c.orig }
+
+// IsFull always returns false. A PNCounter has no maximum.
+func (c *PNCounter) IsFull() bool { return false }
+
+// IsEmpty returns true if the value is 0.
+func (c *PNCounter) IsEmpty() bool { return c.Value() == 0 }
+
+// Increment increases the counter by the incrementer value.
+func (c *PNCounter) Increment() { c.Add(c.inc) }
+
+// Decrement decreases the counter by the incrementer value.
+func (c *PNCounter) Decrement() { c.Remove(c.inc) }
+
+// Add increases the counter by val.
+func (c *PNCounter) Add(val int) {
+	if val < 0 {
+		c.n.add(c.replica, SubSaturating(0, val))
+		return
+	}
+
+	c.p.add(c.replica, val)
+}
+
+// Remove decreases the counter by val.
+func (c *PNCounter) Remove(val int) { c.Add(SubSaturating(0, val)) }
+
+// SetMax does nothing. A PNCounter has no maximum.
+func (c *PNCounter) SetMax(int) {}
+
+// SetIncrementer sets the amount Increment and Decrement change the counter by.
+func (c *PNCounter) SetIncrementer(inc int) { c.inc = inc }
+
+// SetValue changes the counter by the difference between val and the merged value.
+func (c *PNCounter) SetValue(val int) { c.Add(SubSaturating(val, c.Value())) }
+
+// SetOriginalValue sets the value Reset sets the PNCounter to.
+func (c *PNCounter) SetOriginalValue(val int) { c.orig = val }
+
+// Empty sets the value to 0.
+func (c *PNCounter) Empty() { c.SetValue(0) }
+
+// Reset sets the value to the original value.
+func (c *PNCounter) Reset() { c.SetValue(c.orig) }
+
+// String returns the value of the PNCounter.
+func (c *PNCounter) String() string { return fmt.Sprintf("%d", c.Value()) }
+
+func (c *PNCounter) view() formatView {
+	val := c.Value()
+	return formatView{name: "PNCounter", val: val, str: c.String(), fields: []formatField{
+		{"replica", c.replica}, {"val", val}, {"inc", c.inc}, {"orig", c.orig},
+	}}
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (c *PNCounter) Format(f fmt.State, verb rune) { c.view().format(f, verb) }
+
+type pnCounterJSON struct {
+	Version int      `json:"version"`
+	Replica string   `json:"replica"`
+	Inc     int      `json:"inc"`
+	Orig    int      `json:"orig"`
+	Base    int      `json:"base"`
+	P       gcounter `json:"p,omitempty"`
+	N       gcounter `json:"n,omitempty"`
+}
+
+// MarshalJSON returns a JSON representation of the PNCounter including every replica's counts.
+func (c *PNCounter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pnCounterJSON{
+		Version: pnCounterVersion,
+		Replica: c.replica,
+		Inc:     c.inc,
+		Orig:    c.orig,
+		Base:    c.base,
+		P:       c.p,
+		N:       c.n,
+	})
+}
+
+// UnmarshalJSON parses a JSON representation of the PNCounter.
+func (c *PNCounter) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("PNCounter.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" || string(data) == `""` {
+		return nil
+	}
+
+	var j pnCounterJSON
+	if err := unmarshalVersioned(TypePNCounter, "PNCounter", data, &j, "replica", "base"); err != nil {
+		return err
+	}
+
+	if !j.P.valid() || !j.N.valid() {
+		return fmt.Errorf("invalid PNCounter: counts must be 0 or greater")
+	}
+
+	*c = PNCounter{replica: j.Replica, inc: j.Inc, orig: j.Orig, base: j.Base, p: j.P, n: j.N}
+	return nil
+}
@@ -0,0 +1,315 @@
+package incrementers
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ Counter = (*GCounter)(nil)
+	_ Counter = (*PNCounter)(nil)
+	_ Counter = (*BoundedCounter)(nil)
+)
+
+type replicated[T any] interface {
+	Counter
+	Fork(replica string) T
+	Merge(o T) error
+}
+
+// randomMerges forks origin into replicas which make random changes with op and gossip with each other
+// in a random order, then merges every copy into every other copy in a random order. check is called on
+// each replica after every change and merge.
+func randomMerges[T replicated[T]](
+	t *testing.T, seed int64, origin T, op func(*rand.Rand, T), check func(T),
+) []T {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(seed))
+
+	replicas := []T{origin}
+	for i := 1; i < 4; i++ {
+		replicas = append(replicas, origin.Fork(fmt.Sprintf("r%d", i)))
+	}
+
+	for step := 0; step < 500; step++ {
+		r := replicas[rng.Intn(len(replicas))]
+		if rng.Intn(3) == 0 {
+			require.NoError(r.Merge(replicas[rng.Intn(len(replicas))]))
+		} else {
+			op(rng, r)
+		}
+
+		check(r)
+	}
+
+	for _, i := range rng.Perm(len(replicas)) {
+		for _, j := range rng.Perm(len(replicas)) {
+			require.NoError(replicas[i].Merge(replicas[j]))
+			check(replicas[i])
+		}
+	}
+
+	for _, r := range replicas {
+		require.NoError(r.Merge(replicas[rng.Intn(len(replicas))]))
+		require.Equal(replicas[0].Value(), r.Value(), "%s did not converge", r)
+	}
+
+	return replicas
+}
+
+func TestGCounter(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("new", func(t *testing.T) {
+		g := NewGCounterWithValue("a", 3)
+		require.Equal("a", g.Replica())
+		require.Equal(1, g.Inc())
+		require.Equal(3, g.Value())
+		require.Equal(3, g.Original())
+	})
+
+	t.Run("grow only", func(t *testing.T) {
+		g := NewGCounter("a")
+		g.Increment()
+		g.Add(4)
+		g.Add(-2)
+		g.Decrement()
+		g.Remove(3)
+		g.Empty()
+		g.SetValue(2)
+		require.Equal(5, g.Value())
+		require.False(g.IsEmpty())
+		require.False(g.IsFull())
+
+		g.SetValue(7)
+		require.Equal(7, g.Value())
+		require.Equal("7", g.String())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		g := NewGCounter("a")
+		g.SetOriginalValue(4)
+		g.Reset()
+		require.Equal(4, g.Value())
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		a := NewGCounter("a")
+		b := a.Fork("b")
+		a.Add(2)
+		b.Add(3)
+		require.NoError(a.Merge(b))
+		require.NoError(a.Merge(b))
+		require.NoError(b.Merge(a))
+		require.Equal(5, a.Value())
+		require.Equal(5, b.Value())
+		require.Equal("b", b.Replica())
+	})
+
+	t.Run("merge error", func(t *testing.T) {
+		err := NewGCounter("a").Merge(NewGCounterWithValue("b", 1))
+		require.Error(err, "GCounter.Merge() did not return an error")
+		require.Equal("invalid GCounter: cannot merge counters with different starting values", err.Error())
+	})
+
+	t.Run("random merges", func(t *testing.T) {
+		var want int
+		op := func(rng *rand.Rand, g *GCounter) {
+			n := rng.Intn(5)
+			want += n
+			g.Add(n)
+		}
+
+		replicas := randomMerges(t, 1, NewGCounterWithValue("r0", 2), op, func(*GCounter) {})
+		require.Equal(want+2, replicas[0].Value())
+	})
+}
+
+func TestGCounterJSON(t *testing.T) {
+	require := require.New(t)
+	g := NewGCounterWithValue("a", 2)
+	g.Add(3)
+
+	data, err := g.MarshalJSON()
+	require.NoError(err)
+	require.Equal(`{"version":1,"replica":"a","inc":1,"orig":2,"base":2,"counts":{"a":3}}`, string(data))
+
+	t.Run("round trip", func(t *testing.T) {
+		n, err := NewGCounterFromJSON(data)
+		require.NoError(err)
+		require.Equal(g, n)
+	})
+
+	t.Run("negative count", func(t *testing.T) {
+		_, err := NewGCounterFromJSON([]byte(`{"replica":"a","base":0,"counts":{"a":-1}}`))
+		require.Error(err, "GCounter.UnmarshalJSON() did not return an error")
+		require.Equal("invalid GCounter: counts must be 0 or greater", err.Error())
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		_, err := NewGCounterFromJSON([]byte(`{"replica":"a"}`))
+		require.Error(err, "GCounter.UnmarshalJSON() did not return an error")
+		require.Equal("invalid GCounter: base is required", err.Error())
+	})
+}
+
+func TestPNCounter(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("new", func(t *testing.T) {
+		c := NewPNCounterWithValue("a", 3)
+		require.Equal("a", c.Replica())
+		require.Equal(3, c.Value())
+		require.Equal(3, c.Original())
+	})
+
+	t.Run("add and remove", func(t *testing.T) {
+		c := NewPNCounter("a")
+		c.Increment()
+		c.Add(4)
+		c.Add(-2)
+		c.Decrement()
+		c.Remove(3)
+		require.Equal(-1, c.Value())
+		require.Equal("-1", c.String())
+		require.False(c.IsFull())
+
+		c.SetValue(6)
+		require.Equal(6, c.Value())
+
+		c.Empty()
+		require.True(c.IsEmpty())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		c := NewPNCounterWithValue("a", 5)
+		c.Remove(2)
+		c.Reset()
+		require.Equal(5, c.Value())
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		a := NewPNCounterWithValue("a", 10)
+		b := a.Fork("b")
+		a.Remove(4)
+		b.Add(1)
+		require.NoError(b.Merge(a))
+		require.NoError(a.Merge(b))
+		require.Equal(7, a.Value())
+		require.Equal(7, b.Value())
+	})
+
+	t.Run("merge error", func(t *testing.T) {
+		err := NewPNCounter("a").Merge(NewPNCounterWithValue("b", 1))
+		require.Error(err, "PNCounter.Merge() did not return an error")
+		require.Equal("invalid PNCounter: cannot merge counters with different starting values", err.Error())
+	})
+
+	t.Run("random merges", func(t *testing.T) {
+		var want int
+		op := func(rng *rand.Rand, c *PNCounter) {
+			n := rng.Intn(11) - 5
+			want += n
+			c.Add(n)
+		}
+
+		replicas := randomMerges(t, 1, NewPNCounterWithValue("r0", 2), op, func(*PNCounter) {})
+		require.Equal(want+2, replicas[0].Value())
+	})
+}
+
+func TestPNCounterJSON(t *testing.T) {
+	require := require.New(t)
+	c := NewPNCounter("a")
+	c.Add(3)
+	c.Remove(1)
+
+	data, err := c.MarshalJSON()
+	require.NoError(err)
+	require.Equal(`{"version":1,"replica":"a","inc":1,"orig":0,"base":0,"p":{"a":3},"n":{"a":1}}`, string(data))
+
+	t.Run("round trip", func(t *testing.T) {
+		n, err := NewPNCounterFromJSON(data)
+		require.NoError(err)
+		require.Equal(c, n)
+	})
+
+	t.Run("negative count", func(t *testing.T) {
+		_, err := NewPNCounterFromJSON([]byte(`{"replica":"a","base":0,"n":{"a":-1}}`))
+		require.Error(err, "PNCounter.UnmarshalJSON() did not return an error")
+		require.Equal("invalid PNCounter: counts must be 0 or greater", err.Error())
+	})
+
+	t.Run("typed", func(t *testing.T) {
+		typed, err := UnmarshalTyped([]byte(`{"type":"pncounter","data":` + string(data) + `}`))
+		require.NoError(err)
+		require.Equal(c, typed.Value)
+	})
+}
+
+func TestCRDTOverflow(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("gcounter", func(t *testing.T) {
+		g := NewGCounter("a")
+		g.Add(math.MaxInt)
+		g.Add(1)
+		require.Equal(math.MaxInt, g.Value())
+
+		b := g.Fork("b")
+		b.Add(math.MaxInt)
+		require.NoError(g.Merge(b))
+		require.Equal(math.MaxInt, g.Value())
+
+		data, err := g.MarshalJSON()
+		require.NoError(err, "GCounter.MarshalJSON() returned an error: %s", err)
+		got, err := NewGCounterFromJSON(data)
+		require.NoError(err, "GCounter.UnmarshalJSON() returned an error: %s", err)
+		require.Equal(math.MaxInt, got.Value())
+	})
+
+	t.Run("pncounter", func(t *testing.T) {
+		c := NewPNCounterWithValue("a", 5)
+		c.Add(math.MaxInt)
+		require.Equal(math.MaxInt, c.Value())
+
+		c = NewPNCounterWithValue("a", -5)
+		c.Remove(math.MaxInt)
+		c.Add(math.MinInt)
+		require.Equal(math.MinInt, c.Value())
+
+		c = NewPNCounter("a")
+		c.Remove(math.MinInt)
+		require.Equal(math.MaxInt, c.Value())
+
+		data, err := c.MarshalJSON()
+		require.NoError(err, "PNCounter.MarshalJSON() returned an error: %s", err)
+		got, err := NewPNCounterFromJSON(data)
+		require.NoError(err, "PNCounter.UnmarshalJSON() returned an error: %s", err)
+		require.Equal(math.MaxInt, got.Value())
+	})
+
+	t.Run("bounded counter", func(t *testing.T) {
+		c, err := NewBoundedCounter("a", math.MinInt, math.MaxInt, 0)
+		require.NoError(err)
+		down, up := c.Rights()
+		require.Equal(math.MaxInt, down)
+		require.Equal(math.MaxInt, up)
+
+		c.Add(math.MaxInt)
+		c.Add(1)
+		require.Equal(math.MaxInt, c.Value())
+		require.True(c.IsFull())
+
+		c.Remove(math.MaxInt)
+		require.Equal(0, c.Value())
+
+		c.SetMax(0)
+		c.SetMax(math.MaxInt)
+		require.Equal(math.MaxInt, c.Max())
+	})
+}
@@ -0,0 +1,208 @@
+package incrementers
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DeltaOp is how a Delta changes an incrementer.
+type DeltaOp int
+
+const (
+	OpAdd    DeltaOp = iota // Adds N to the value. Use a negative N to remove.
+	OpSet                   // Sets the value to N.
+	OpSetMin                // Sets the minimum to N.
+	OpSetMax                // Sets the maximum to N.
+)
+
+var deltaOps = []string{"add", "set", "setMin", "setMax"}
+
+// String returns the name of the DeltaOp.
+func (o DeltaOp) String() string {
+	if o < 0 || int(o) >= len(deltaOps) {
+		return fmt.Sprintf("DeltaOp(%d)", int(o))
+	}
+
+	return deltaOps[o]
+}
+
+// MarshalText returns the name of the DeltaOp.
+func (o DeltaOp) MarshalText() ([]byte, error) {
+	if o < 0 || int(o) >= len(deltaOps) {
+		return nil, fmt.Errorf("invalid DeltaOp: %d", int(o))
+	}
+
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText parses the name of a DeltaOp.
+func (o *DeltaOp) UnmarshalText(data []byte) error {
+	for i, name := range deltaOps {
+		if name == string(data) {
+			*o = DeltaOp(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid DeltaOp: %s", data)
+}
+
+// Delta is a single change to an incrementer, such as "+1 to clock doom", which can be sent to other
+// clients instead of the whole incrementer. Deltas are ordered by their Lamport Time and then by
+// Replica, so clients which apply the same deltas to the same starting state end up with the same
+// value no matter what order the deltas arrived in.
+type Delta struct {
+	Target  string  `json:"target,omitempty"` // Name of the Registry entry to change.
+	Op      DeltaOp `json:"op"`
+	N       int     `json:"n"`
+	Time    uint64  `json:"time"`    // Lamport timestamp from the Replica which made the change.
+	Replica string  `json:"replica"` // Client which made the change.
+}
+
+// Less returns true if d is applied before o.
+func (d Delta) Less(o Delta) bool {
+	if d.Time != o.Time {
+		return d.Time < o.Time
+	}
+
+	return d.Replica < o.Replica
+}
+
+// Apply applies the Delta to v. v must have the method the operation needs: Add, SetValue, SetMin or
+// SetMax.
+func (d Delta) Apply(v any) error {
+	if err := d.check(v); err != nil {
+		return err
+	}
+
+	switch d.Op {
+	case OpAdd:
+		v.(interface{ Add(int) }).Add(d.N)
+	case OpSet:
+		v.(interface{ SetValue(int) }).SetValue(d.N)
+	case OpSetMin:
+		v.(interface{ SetMin(int) }).SetMin(d.N)
+	case OpSetMax:
+		v.(interface{ SetMax(int) }).SetMax(d.N)
+	}
+
+	return nil
+}
+
+// check returns an error if the Delta can't be applied to v.
+func (d Delta) check(v any) error {
+	var ok bool
+	switch d.Op {
+	case OpAdd:
+		_, ok = v.(interface{ Add(int) })
+	case OpSet:
+		_, ok = v.(interface{ SetValue(int) })
+	case OpSetMin:
+		_, ok = v.(interface{ SetMin(int) })
+	case OpSetMax:
+		_, ok = v.(interface{ SetMax(int) })
+	default:
+		return fmt.Errorf("invalid Delta: unknown op %s", d.Op)
+	}
+
+	if !ok {
+		return fmt.Errorf("invalid Delta: %T does not support %s", v, d.Op)
+	}
+
+	return nil
+}
+
+// String returns a short description of the Delta such as "doom add 1 @3/alice".
+func (d Delta) String() string {
+	return fmt.Sprintf("%s %s %d @%d/%s", d.Target, d.Op, d.N, d.Time, d.Replica)
+}
+
+// Patch is a list of Deltas.
+type Patch []Delta
+
+// Merge returns a new Patch with the Deltas of p and others sorted in the order they should be applied.
+// A Delta received more than once, with the same Replica and Time, is only kept once.
+func (p Patch) Merge(others ...Patch) Patch {
+	type id struct {
+		replica string
+		time    uint64
+	}
+
+	seen := make(map[id]bool)
+	var merged Patch
+	for _, patch := range append([]Patch{p}, others...) {
+		for _, d := range patch {
+			if k := (id{d.Replica, d.Time}); !seen[k] {
+				seen[k] = true
+				merged = append(merged, d)
+			}
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Less(merged[j]) })
+	return merged
+}
+
+// For returns the Deltas for the given target.
+func (p Patch) For(target string) Patch {
+	var f Patch
+	for _, d := range p {
+		if d.Target == target {
+			f = append(f, d)
+		}
+	}
+
+	return f
+}
+
+// Apply applies every Delta in order to v, ignoring their targets. Merge the Patch first to apply the
+// Deltas in the same order as other clients.
+func (p Patch) Apply(v any) error {
+	for _, d := range p {
+		if err := d.Apply(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Replica creates Deltas for one client, keeping a Lamport clock so its Deltas are ordered after every
+// Delta it has seen.
+type Replica struct {
+	name string
+	time uint64
+}
+
+// NewReplica creates a new Replica with the given unique client name.
+func NewReplica(name string) *Replica { return &Replica{name: name} }
+
+// Name returns the client name of the Replica.
+func (r *Replica) Name() string { return r.name }
+
+// Time returns the Lamport time of the last Delta the Replica created or observed.
+func (r *Replica) Time() uint64 { return r.time }
+
+// Observe moves the Lamport clock past the Deltas received from other clients.
+func (r *Replica) Observe(deltas ...Delta) {
+	for _, d := range deltas {
+		r.time = max(r.time, d.Time)
+	}
+}
+
+// Add returns a Delta which adds n to the target's value.
+func (r *Replica) Add(target string, n int) Delta { return r.delta(target, OpAdd, n) }
+
+// Set returns a Delta which sets the target's value to n.
+func (r *Replica) Set(target string, n int) Delta { return r.delta(target, OpSet, n) }
+
+// SetMin returns a Delta which sets the target's minimum to n.
+func (r *Replica) SetMin(target string, n int) Delta { return r.delta(target, OpSetMin, n) }
+
+// SetMax returns a Delta which sets the target's maximum to n.
+func (r *Replica) SetMax(target string, n int) Delta { return r.delta(target, OpSetMax, n) }
+
+func (r *Replica) delta(target string, op DeltaOp, n int) Delta {
+	r.time++
+	return Delta{Target: target, Op: op, N: n, Time: r.time, Replica: r.name}
+}
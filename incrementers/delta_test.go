@@ -0,0 +1,166 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeltaOp(t *testing.T) {
+	require := require.New(t)
+	require.Equal("setMax", OpSetMax.String())
+	require.Equal("DeltaOp(9)", DeltaOp(9).String())
+
+	_, err := DeltaOp(9).MarshalText()
+	require.Error(err, "DeltaOp.MarshalText() did not return an error")
+	require.Equal("invalid DeltaOp: 9", err.Error())
+
+	var op DeltaOp
+	require.NoError(op.UnmarshalText([]byte("setMin")))
+	require.Equal(OpSetMin, op)
+
+	err = op.UnmarshalText([]byte("multiply"))
+	require.Error(err, "DeltaOp.UnmarshalText() did not return an error")
+	require.Equal("invalid DeltaOp: multiply", err.Error())
+}
+
+func TestDeltaApply(t *testing.T) {
+	require := require.New(t)
+	c := NewClampedIncrementerWithValue(0, 6, 2)
+
+	t.Run("ops", func(t *testing.T) {
+		require.NoError(Delta{Op: OpAdd, N: 3}.Apply(&c))
+		require.Equal(5, c.Value())
+
+		require.NoError(Delta{Op: OpAdd, N: -1}.Apply(&c))
+		require.Equal(4, c.Value())
+
+		require.NoError(Delta{Op: OpSetMax, N: 3}.Apply(&c))
+		require.Equal(3, c.Max())
+		require.Equal(3, c.Value())
+
+		require.NoError(Delta{Op: OpSetMin, N: 1}.Apply(&c))
+		require.Equal(1, c.Min())
+
+		require.NoError(Delta{Op: OpSet, N: 2}.Apply(&c))
+		require.Equal(2, c.Value())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		i := NewIncrementer()
+		err := Delta{Op: OpSetMax, N: 3}.Apply(&i)
+		require.Error(err, "Delta.Apply() did not return an error")
+		require.Equal("invalid Delta: *incrementers.Incrementer does not support setMax", err.Error())
+
+		err = Delta{Op: DeltaOp(9)}.Apply(&i)
+		require.Error(err, "Delta.Apply() did not return an error")
+		require.Equal("invalid Delta: unknown op DeltaOp(9)", err.Error())
+	})
+
+	t.Run("string", func(t *testing.T) {
+		require.Equal("doom add 1 @3/alice", Delta{Target: "doom", Op: OpAdd, N: 1, Time: 3, Replica: "alice"}.String())
+	})
+}
+
+func TestDeltaJSON(t *testing.T) {
+	require := require.New(t)
+	d := NewReplica("alice").Add("doom", 1)
+
+	data, err := json.Marshal(d)
+	require.NoError(err, "json.Marshal() returned an error: %s", err)
+	require.Equal(`{"target":"doom","op":"add","n":1,"time":1,"replica":"alice"}`, string(data))
+
+	var got Delta
+	require.NoError(json.Unmarshal(data, &got))
+	require.Equal(d, got)
+}
+
+func TestReplica(t *testing.T) {
+	require := require.New(t)
+	alice := NewReplica("alice")
+	require.Equal("alice", alice.Name())
+
+	require.Equal(Delta{Target: "doom", Op: OpAdd, N: 1, Time: 1, Replica: "alice"}, alice.Add("doom", 1))
+	require.Equal(Delta{Target: "doom", Op: OpSet, N: 0, Time: 2, Replica: "alice"}, alice.Set("doom", 0))
+	require.Equal(uint64(2), alice.Time())
+
+	alice.Observe(Delta{Time: 7}, Delta{Time: 4})
+	require.Equal(uint64(7), alice.Time())
+	require.Equal(uint64(8), alice.SetMax("doom", 8).Time)
+	require.Equal(uint64(9), alice.SetMin("doom", 1).Time)
+}
+
+func TestPatchMerge(t *testing.T) {
+	require := require.New(t)
+	alice := NewReplica("alice")
+	bob := NewReplica("bob")
+
+	a1 := alice.Add("doom", 1)
+	b1 := bob.Add("doom", 1)
+	bob.Observe(a1)
+	b2 := bob.Set("doom", 0)
+	a2 := alice.Add("plan", 2)
+
+	merged := Patch{b2, a1}.Merge(Patch{a2, b1, a1}, Patch{b2})
+	require.Equal(Patch{a1, b1, a2, b2}, merged)
+	require.Equal(Patch{a1, b1, b2}, merged.For("doom"))
+	require.Nil(merged.For("missing"))
+}
+
+func TestPatchConcurrentTicks(t *testing.T) {
+	require := require.New(t)
+	alice := NewReplica("alice")
+	bob := NewReplica("bob")
+
+	// Both clients tick the same clock before seeing each other's change. Sending snapshots would keep
+	// only one of the ticks.
+	fromAlice := Patch{alice.Add("doom", 1)}
+	fromBob := Patch{bob.Add("doom", 1)}
+
+	a := NewClockWithTicks(6, 2)
+	require.NoError(fromAlice.Merge(fromBob).Apply(a))
+
+	b := NewClockWithTicks(6, 2)
+	require.NoError(fromBob.Merge(fromAlice).Apply(b))
+
+	require.Equal(4, a.Value())
+	require.Equal(a.Value(), b.Value())
+}
+
+func TestPatchReorder(t *testing.T) {
+	require := require.New(t)
+	replicas := []*Replica{NewReplica("alice"), NewReplica("bob"), NewReplica("carol")}
+	rng := rand.New(rand.NewSource(1))
+
+	var all Patch
+	for i := 0; i < 60; i++ {
+		r := replicas[rng.Intn(len(replicas))]
+		switch rng.Intn(4) {
+		case 0:
+			all = append(all, r.Set("hp", rng.Intn(40)))
+		case 1:
+			all = append(all, r.SetMax("hp", 20+rng.Intn(20)))
+		default:
+			all = append(all, r.Add("hp", rng.Intn(11)-5))
+		}
+
+		if rng.Intn(3) == 0 {
+			replicas[rng.Intn(len(replicas))].Observe(all[len(all)-1])
+		}
+	}
+
+	want := NewClampedIncrementerWithValue(0, 30, 15)
+	require.NoError(all.Merge().Apply(&want))
+
+	for i := 0; i < 20; i++ {
+		shuffled := append(Patch(nil), all...)
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		half := len(shuffled) / 2
+
+		got := NewClampedIncrementerWithValue(0, 30, 15)
+		require.NoError(shuffled[half:].Merge(shuffled[:half], shuffled[:3]).Apply(&got))
+		require.Equal(want, got)
+	}
+}
@@ -0,0 +1,348 @@
+package incrementers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrBindingCycle is returned when binding a bound would make an incrementer depend on itself.
+var ErrBindingCycle = errors.New("binding cycle")
+
+// Valuer is anything with a current value, such as the incrementers in this package.
+type Valuer interface {
+	Value() int
+}
+
+// Dependent is a Valuer computed from other Valuers, such as a DynamicIncrementer or a
+// formulas.Derived. Binding cycles are found by following Sources.
+type Dependent interface {
+	Valuer
+	Sources() []Valuer
+}
+
+// BoundFunc computes a bound from the values of its sources, in the order they were bound.
+type BoundFunc func(values ...int) int
+
+// Sum is a BoundFunc which adds the values of its sources together, stopping at math.MaxInt or
+// math.MinInt instead of overflowing.
+func Sum(values ...int) int {
+	var sum int
+	for _, v := range values {
+		sum = AddSaturating(sum, v)
+	}
+
+	return sum
+}
+
+type binding struct {
+	fn      BoundFunc
+	sources []Valuer
+}
+
+func (b *binding) value() int {
+	values := make([]int, len(b.sources))
+	for i, s := range b.sources {
+		values[i] = s.Value()
+	}
+
+	return b.fn(values...)
+}
+
+// DynamicIncrementer is a ClampedIncrementer whose min and max can be bound to other incrementers.
+// Bound min and max values are recomputed, and the value re-clamped, every time the
+// DynamicIncrementer is used.
+//
+//	con := NewIncrementerWithValue(14)
+//	hp := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 1, 1))
+//	err := hp.BindMax(func(v ...int) int { return 10 + (v[0]-10)/2 }, &con)
+type DynamicIncrementer struct {
+	ClampedIncrementer
+	min *binding
+	max *binding
+}
+
+// NewDynamicIncrementer creates a new DynamicIncrementer from c with no bindings.
+func NewDynamicIncrementer(c ClampedIncrementer) *DynamicIncrementer {
+	return &DynamicIncrementer{ClampedIncrementer: c}
+}
+
+// BindMin binds the minimum value to fn of the values of sources. Returns ErrBindingCycle if any
+// source depends on d, following every source which is a Dependent, and ErrInvalidBounds if the
+// minimum would not be below the maximum. If the sources later move the minimum to or above the
+// maximum, the value is clamped to the maximum.
+func (d *DynamicIncrementer) BindMin(fn BoundFunc, sources ...Valuer) error {
+	b, err := d.bind(fn, sources)
+	if err != nil {
+		return err
+	}
+
+	d.Refresh()
+	if err := checkBounds(BoundAt(b.value()), d.ClampedIncrementer.max); err != nil {
+		return err
+	}
+
+	d.min = b
+	d.Refresh()
+	return nil
+}
+
+// BindMax binds the maximum value to fn of the values of sources. Returns ErrBindingCycle if any
+// source depends on d, following every source which is a Dependent, and ErrInvalidBounds if the
+// maximum would not be above the minimum. If the sources later move the maximum to or below the
+// minimum, the value is clamped to the maximum.
+func (d *DynamicIncrementer) BindMax(fn BoundFunc, sources ...Valuer) error {
+	b, err := d.bind(fn, sources)
+	if err != nil {
+		return err
+	}
+
+	d.Refresh()
+	if err := checkBounds(d.ClampedIncrementer.min, BoundAt(b.value())); err != nil {
+		return err
+	}
+
+	d.max = b
+	d.Refresh()
+	return nil
+}
+
+// checkBounds returns ErrInvalidBounds if min and max are both set and min is not below max.
+func checkBounds(min, max Bound) error {
+	lo, hasMin := min.Value()
+	hi, hasMax := max.Value()
+	if hasMin && hasMax && lo >= hi {
+		return fmt.Errorf("%w: min %d >= max %d", ErrInvalidBounds, lo, hi)
+	}
+
+	return nil
+}
+
+// UnbindMin removes the minimum binding. The minimum keeps its last computed value.
+func (d *DynamicIncrementer) UnbindMin() { d.Refresh(); d.min = nil }
+
+// UnbindMax removes the maximum binding. The maximum keeps its last computed value.
+func (d *DynamicIncrementer) UnbindMax() { d.Refresh(); d.max = nil }
+
+// IsMinBound returns true if the minimum value is bound.
+func (d *DynamicIncrementer) IsMinBound() bool { return d.min != nil }
+
+// IsMaxBound returns true if the maximum value is bound.
+func (d *DynamicIncrementer) IsMaxBound() bool { return d.max != nil }
+
+// Sources returns the sources of the min and max bindings.
+func (d *DynamicIncrementer) Sources() []Valuer {
+	var sources []Valuer
+	for _, b := range []*binding{d.min, d.max} {
+		if b != nil {
+			sources = append(sources, b.sources...)
+		}
+	}
+
+	return sources
+}
+
+// Refresh recomputes the bound min and max values and clamps the value to them. The original value is
+// kept as it was set and only clamped when it is read, so it comes back if the bounds widen again.
+func (d *DynamicIncrementer) Refresh() {
+	if d.min != nil {
+		d.ClampedIncrementer.min = BoundAt(d.min.value())
+	}
+
+	if d.max != nil {
+		d.ClampedIncrementer.max = BoundAt(d.max.value())
+	}
+
+	d.Clamp()
+}
+
+// current returns the ClampedIncrementer with the current bounds and the original value clamped to
+// them.
+func (d *DynamicIncrementer) current() ClampedIncrementer {
+	d.Refresh()
+	c := d.ClampedIncrementer
+	c.ClampOriginalValue()
+	return c
+}
+
+// Value returns the current value clamped to the current bounds.
+func (d *DynamicIncrementer) Value() int { d.Refresh(); return d.val }
+
+// Original returns the original value clamped to the current bounds.
+func (d *DynamicIncrementer) Original() int { c := d.current(); return c.orig }
+
+// Min returns the current minimum value, or math.MinInt if there is no minimum.
+func (d *DynamicIncrementer) Min() int { d.Refresh(); return d.ClampedIncrementer.Min() }
+
+// Max returns the current maximum value, or math.MaxInt if there is no maximum.
+func (d *DynamicIncrementer) Max() int { d.Refresh(); return d.ClampedIncrementer.Max() }
+
+// MinBound returns the current minimum.
+func (d *DynamicIncrementer) MinBound() Bound { d.Refresh(); return d.ClampedIncrementer.min }
+
+// MaxBound returns the current maximum.
+func (d *DynamicIncrementer) MaxBound() Bound { d.Refresh(); return d.ClampedIncrementer.max }
+
+// IsFull returns true if the value is at the current maximum value.
+func (d *DynamicIncrementer) IsFull() bool { d.Refresh(); return d.ClampedIncrementer.IsFull() }
+
+// IsEmpty returns true if the value is 0.
+func (d *DynamicIncrementer) IsEmpty() bool { d.Refresh(); return d.ClampedIncrementer.IsEmpty() }
+
+// Increment increases the value by the incrementer value clamped to the current bounds.
+func (d *DynamicIncrementer) Increment() { d.Refresh(); d.ClampedIncrementer.Increment() }
+
+// Decrement decreases the value by the incrementer value clamped to the current bounds.
+func (d *DynamicIncrementer) Decrement() { d.Refresh(); d.ClampedIncrementer.Decrement() }
+
+// Add increases the value by the given number of val clamped to the current bounds.
+func (d *DynamicIncrementer) Add(val int) { d.Refresh(); d.ClampedIncrementer.Add(val) }
+
+// Remove decreases the value by the given number of val clamped to the current bounds.
+func (d *DynamicIncrementer) Remove(val int) { d.Refresh(); d.ClampedIncrementer.Remove(val) }
+
+// AddChecked increases the value by val clamped, or returns ErrOverflow and leaves the value unchanged
+// if the unclamped result does not fit in an int.
+func (d *DynamicIncrementer) AddChecked(val int) error {
+	d.Refresh()
+	return d.ClampedIncrementer.AddChecked(val)
+}
+
+// RemoveChecked decreases the value by val clamped, or returns ErrOverflow and leaves the value
+// unchanged if the unclamped result does not fit in an int.
+func (d *DynamicIncrementer) RemoveChecked(val int) error {
+	d.Refresh()
+	return d.ClampedIncrementer.RemoveChecked(val)
+}
+
+// SetMin removes the minimum binding and sets the minimum value.
+func (d *DynamicIncrementer) SetMin(min int) {
+	d.min = nil
+	d.Refresh()
+	d.ClampedIncrementer.SetMin(min)
+}
+
+// SetMax removes the maximum binding and sets the maximum value.
+func (d *DynamicIncrementer) SetMax(max int) {
+	d.max = nil
+	d.Refresh()
+	d.ClampedIncrementer.SetMax(max)
+}
+
+// SetMinBound removes the minimum binding and sets the minimum.
+func (d *DynamicIncrementer) SetMinBound(min Bound) {
+	d.min = nil
+	d.Refresh()
+	d.ClampedIncrementer.SetMinBound(min)
+}
+
+// SetMaxBound removes the maximum binding and sets the maximum.
+func (d *DynamicIncrementer) SetMaxBound(max Bound) {
+	d.max = nil
+	d.Refresh()
+	d.ClampedIncrementer.SetMaxBound(max)
+}
+
+// SetValue sets the value to the given number of val clamped to the current bounds.
+func (d *DynamicIncrementer) SetValue(val int) { d.Refresh(); d.ClampedIncrementer.SetValue(val) }
+
+// SetOriginalValue sets the original value to the given number of val clamped to the current bounds.
+func (d *DynamicIncrementer) SetOriginalValue(val int) {
+	d.Refresh()
+	d.ClampedIncrementer.SetOriginalValue(val)
+}
+
+// Fill sets the value to the current maximum value.
+func (d *DynamicIncrementer) Fill() { d.Refresh(); d.ClampedIncrementer.Fill() }
+
+// Floor sets the value to the current minimum value.
+func (d *DynamicIncrementer) Floor() { d.Refresh(); d.ClampedIncrementer.Floor() }
+
+// Reset sets the value to the original value clamped to the current bounds.
+func (d *DynamicIncrementer) Reset() { d.Refresh(); d.val = d.Original() }
+
+// Ratio returns how far the value is from the current min to max, from 0 to 1.
+func (d *DynamicIncrementer) Ratio() float64 { d.Refresh(); return d.ClampedIncrementer.Ratio() }
+
+// Percent returns how far the value is from the current min to max, from 0 to 100, rounded down.
+func (d *DynamicIncrementer) Percent() int { d.Refresh(); return d.ClampedIncrementer.Percent() }
+
+// Segments returns how many of n equal segments from the current min to max the value has filled.
+func (d *DynamicIncrementer) Segments(n int) int {
+	d.Refresh()
+	return d.ClampedIncrementer.Segments(n)
+}
+
+// SetFraction sets the value to the given fraction of the way from the current min to max.
+func (d *DynamicIncrementer) SetFraction(f float64, mode RoundingMode) {
+	d.Refresh()
+	d.ClampedIncrementer.SetFraction(f, mode)
+}
+
+// String returns a string representation of the DynamicIncrementer.
+func (d *DynamicIncrementer) String() string { d.Refresh(); return d.ClampedIncrementer.String() }
+
+func (d *DynamicIncrementer) view() formatView {
+	c := d.current()
+	v := c.view()
+	v.name = "DynamicIncrementer"
+	v.fields = append(v.fields, formatField{"minBound", d.IsMinBound()}, formatField{"maxBound", d.IsMaxBound()})
+	return v
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (d *DynamicIncrementer) Format(f fmt.State, verb rune) { d.view().format(f, verb) }
+
+// MarshalJSON returns a JSON representation of the DynamicIncrementer's current state. Bindings are not
+// included.
+func (d *DynamicIncrementer) MarshalJSON() ([]byte, error) {
+	c := d.current()
+	return c.MarshalJSON()
+}
+
+// MarshalBinary returns a compact binary representation of the DynamicIncrementer's current state.
+// Bindings are not included.
+func (d *DynamicIncrementer) MarshalBinary() ([]byte, error) {
+	c := d.current()
+	return c.MarshalBinary()
+}
+
+// MarshalText returns a compact text representation of the DynamicIncrementer's current state.
+// Bindings are not included.
+func (d *DynamicIncrementer) MarshalText() ([]byte, error) {
+	c := d.current()
+	return c.MarshalText()
+}
+
+func (d *DynamicIncrementer) bind(fn BoundFunc, sources []Valuer) (*binding, error) {
+	visited := make(map[Valuer]bool)
+	for _, s := range sources {
+		if dependsOn(s, d, visited) {
+			return nil, ErrBindingCycle
+		}
+	}
+
+	return &binding{fn: fn, sources: sources}, nil
+}
+
+// dependsOn returns true if v is target or v is a Dependent with a source which depends on target.
+// Only comparable Dependents, such as pointers, are followed.
+func dependsOn(v Valuer, target *DynamicIncrementer, visited map[Valuer]bool) bool {
+	if d, ok := v.(*DynamicIncrementer); ok && d == target {
+		return true
+	}
+
+	dep, ok := v.(Dependent)
+	if !ok || !reflect.ValueOf(v).Comparable() || visited[v] {
+		return false
+	}
+
+	visited[v] = true
+	for _, s := range dep.Sources() {
+		if dependsOn(s, target, visited) {
+			return true
+		}
+	}
+
+	return false
+}
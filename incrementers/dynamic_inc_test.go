@@ -0,0 +1,283 @@
+package incrementers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ Counter  = &DynamicIncrementer{}
+	_ Clock    = &DynamicIncrementer{}
+	_ Progress = &DynamicIncrementer{}
+)
+
+func conMaxHP(v ...int) int { return 10 + v[0]*2 }
+
+func TestDynamicIncrementerSum(t *testing.T) {
+	require := require.New(t)
+	require.Equal(0, Sum())
+	require.Equal(6, Sum(1, 2, 3))
+}
+
+func TestDynamicIncrementerBindMax(t *testing.T) {
+	require := require.New(t)
+	con := NewIncrementerWithValue(3)
+	hp := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 100, 50))
+
+	t.Run("bind", func(t *testing.T) {
+		require.NoError(hp.BindMax(conMaxHP, &con))
+		require.True(hp.IsMaxBound())
+		require.Equal(16, hp.Max())
+		require.Equal(16, hp.Value())
+		require.True(hp.IsFull())
+	})
+
+	t.Run("source raised", func(t *testing.T) {
+		con.Increment()
+		require.Equal(18, hp.Max())
+		require.Equal(16, hp.Value())
+
+		hp.Fill()
+		require.Equal(18, hp.Value())
+	})
+
+	t.Run("source lowered", func(t *testing.T) {
+		con.SetValue(1)
+		require.Equal(12, hp.Value())
+		require.Equal("12/12", hp.String())
+	})
+
+	t.Run("unbind", func(t *testing.T) {
+		hp.UnbindMax()
+		require.False(hp.IsMaxBound())
+		con.SetValue(10)
+		require.Equal(12, hp.Max())
+	})
+}
+
+func TestDynamicIncrementerBindMin(t *testing.T) {
+	require := require.New(t)
+	floor := NewClampedIncrementerWithValue(0, 10, 2)
+	d := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 10, 0))
+
+	require.NoError(d.BindMin(Sum, &floor))
+	require.True(d.IsMinBound())
+	require.Equal(2, d.Min())
+	require.Equal(2, d.Value())
+
+	floor.Add(3)
+	d.Decrement()
+	require.Equal(5, d.Value())
+
+	d.UnbindMin()
+	require.False(d.IsMinBound())
+	require.Equal(5, d.Min())
+}
+
+func TestDynamicIncrementerChained(t *testing.T) {
+	require := require.New(t)
+	str := NewIncrementerWithValue(10)
+	capacity := NewDynamicIncrementer(NewClampedIncrementer(0, 1))
+	load := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 1000, 200))
+
+	require.NoError(capacity.BindMax(func(v ...int) int { return v[0] * 15 }, &str))
+	capacity.Fill()
+	require.NoError(load.BindMax(Sum, capacity))
+	require.Equal(150, load.Value())
+
+	str.SetValue(20)
+	capacity.Fill()
+	load.Add(500)
+	require.Equal(300, load.Value())
+}
+
+func TestDynamicIncrementerCycle(t *testing.T) {
+	require := require.New(t)
+	a := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 10, 5))
+	b := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 10, 5))
+	c := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 10, 5))
+
+	t.Run("self", func(t *testing.T) {
+		require.ErrorIs(a.BindMax(Sum, a), ErrBindingCycle)
+		require.False(a.IsMaxBound())
+	})
+
+	t.Run("indirect", func(t *testing.T) {
+		require.NoError(a.BindMax(Sum, b))
+		require.NoError(b.BindMin(Sum, c))
+		require.ErrorIs(c.BindMax(Sum, a), ErrBindingCycle)
+		require.False(c.IsMaxBound())
+	})
+
+	t.Run("diamond", func(t *testing.T) {
+		d := NewDynamicIncrementer(NewClampedIncrementer(0, 10))
+		require.NoError(d.BindMax(Sum, a, b))
+	})
+
+	t.Run("through a dependent", func(t *testing.T) {
+		require.Equal([]Valuer{b}, a.Sources())
+		require.ErrorIs(c.BindMin(Sum, &doubled{src: a}), ErrBindingCycle)
+		require.False(c.IsMinBound())
+
+		d := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 10, 5))
+		require.NoError(d.BindMax(Sum, &doubled{src: a}))
+	})
+}
+
+// doubled is a Dependent whose value is twice its source.
+type doubled struct{ src Valuer }
+
+func (d *doubled) Value() int        { return d.src.Value() * 2 }
+func (d *doubled) Sources() []Valuer { return []Valuer{d.src} }
+
+func TestDynamicIncrementerInvalidBinding(t *testing.T) {
+	require := require.New(t)
+	src := NewIncrementerWithValue(5)
+	d := NewDynamicIncrementer(NewClampedIncrementerWithValue(10, 100, 50))
+
+	err := d.BindMax(Sum, &src)
+	require.ErrorIs(err, ErrInvalidBounds)
+	require.Equal("invalid bounds: min 10 >= max 5", err.Error())
+	require.False(d.IsMaxBound())
+	require.Equal(100, d.Max())
+
+	src.SetValue(100)
+	require.ErrorIs(d.BindMin(Sum, &src), ErrInvalidBounds)
+	require.False(d.IsMinBound())
+	require.Equal(10, d.Min())
+
+	t.Run("sources cross later", func(t *testing.T) {
+		require.NoError(d.BindMax(Sum, &src))
+		src.SetValue(5)
+		require.Equal(5, d.Value())
+	})
+}
+
+func TestDynamicIncrementerOriginal(t *testing.T) {
+	require := require.New(t)
+	con := NewIncrementerWithValue(3)
+	hp := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 100, 50))
+	require.NoError(hp.BindMax(conMaxHP, &con))
+	require.Equal(16, hp.Original())
+
+	data, err := hp.MarshalJSON()
+	require.NoError(err, "DynamicIncrementer.MarshalJSON() returned an error: %s", err)
+	require.Equal(`{"version":3,"min":0,"max":16,"incrementer":{"version":2,"inc":1,"val":16,"orig":16}}`, string(data))
+
+	con.SetValue(30)
+	require.Equal(50, hp.Original(), "a lower max permanently lowered the original value")
+
+	hp.Reset()
+	require.Equal(50, hp.Value())
+}
+
+func TestDynamicIncrementerSetMinMax(t *testing.T) {
+	require := require.New(t)
+	src := NewIncrementerWithValue(5)
+	d := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 10, 8))
+	require.NoError(d.BindMin(Sum, &src))
+	require.NoError(d.BindMax(func(v ...int) int { return v[0] * 2 }, &src))
+
+	d.SetMax(7)
+	require.False(d.IsMaxBound())
+	require.Equal(7, d.Max())
+	require.Equal(7, d.Value())
+
+	d.SetMin(1)
+	require.False(d.IsMinBound())
+	d.SetValue(0)
+	require.Equal(1, d.Value())
+
+	t.Run("bounds", func(t *testing.T) {
+		require.NoError(d.BindMax(Sum, &src))
+		require.Equal(BoundAt(5), d.MaxBound())
+
+		d.SetMaxBound(Unbounded())
+		require.False(d.IsMaxBound())
+		require.Equal(math.MaxInt, d.Max())
+		d.Add(100)
+		require.Equal(101, d.Value())
+
+		d.SetMinBound(Unbounded())
+		require.True(d.MinBound().IsUnbounded())
+		d.SetValue(-100)
+		require.Equal(-100, d.Value())
+	})
+}
+
+func TestDynamicIncrementerMutators(t *testing.T) {
+	require := require.New(t)
+	src := NewIncrementerWithValue(4)
+	d := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 10, 8))
+	require.NoError(d.BindMax(Sum, &src))
+	require.Equal(4, d.Value())
+
+	src.SetValue(6)
+	d.Increment()
+	require.Equal(5, d.Value())
+
+	d.Remove(10)
+	require.True(d.IsEmpty())
+
+	d.SetOriginalValue(9)
+	require.Equal(6, d.Original())
+
+	d.Reset()
+	require.Equal(6, d.Value())
+
+	d.Floor()
+	require.Equal(0, d.Value())
+}
+
+func TestDynamicIncrementerRatio(t *testing.T) {
+	require := require.New(t)
+	con := NewIncrementerWithValue(5)
+	hp := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 100, 10))
+	require.NoError(hp.BindMax(conMaxHP, &con))
+	require.Equal(50, hp.Percent())
+
+	con.SetValue(0)
+	require.Equal(100, hp.Percent(), "Percent() did not refresh the bound max")
+	require.Equal(1.0, hp.Ratio())
+	require.Equal(3, hp.Segments(3))
+
+	con.SetValue(10)
+	hp.SetFraction(0.25, RoundDown)
+	require.Equal(7, hp.Value())
+}
+
+func TestDynamicIncrementerMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	src := NewIncrementerWithValue(4)
+	d := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 10, 8))
+	require.NoError(d.BindMax(Sum, &src))
+	src.SetValue(3)
+
+	data, err := d.MarshalJSON()
+	require.NoError(err, "DynamicIncrementer.MarshalJSON() returned an error: %s", err)
+	require.Equal(`{"version":3,"min":0,"max":3,"incrementer":{"version":2,"inc":1,"val":3,"orig":3}}`, string(data))
+}
+
+func TestDynamicIncrementerOverflow(t *testing.T) {
+	require := require.New(t)
+	require.Equal(math.MaxInt, Sum(math.MaxInt, 1))
+
+	max := NewIncrementerWithValue(10)
+	d := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 1, 1))
+	require.NoError(d.BindMax(Sum, &max))
+
+	d.Add(math.MaxInt)
+	require.Equal(10, d.Value())
+
+	require.ErrorIs(d.AddChecked(math.MaxInt), ErrOverflow)
+	require.NoError(d.RemoveChecked(4))
+	require.Equal(6, d.Value())
+
+	max.SetValue(3)
+	require.NoError(d.AddChecked(0))
+	require.Equal(3, d.Value())
+	require.NoError(d.RemoveChecked(1))
+	require.Equal(2, d.Value())
+}
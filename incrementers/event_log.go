@@ -0,0 +1,197 @@
+package incrementers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Record is a single entry in an EventLog. Exactly one of Event and Snapshot is set.
+type Record struct {
+	Event    *Event    `json:"event,omitempty"`
+	Snapshot *Snapshot `json:"snapshot,omitempty"`
+}
+
+// EventLog is an append-only list of Records written by an EventStore.
+type EventLog interface {
+	// Append adds a Record to the end of the log.
+	Append(rec Record) error
+	// Records returns every Record in the order they were appended.
+	Records() ([]Record, error)
+}
+
+// MemoryLog is an EventLog kept in memory.
+type MemoryLog struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// NewMemoryLog creates a new empty MemoryLog.
+func NewMemoryLog() *MemoryLog { return &MemoryLog{} }
+
+// Append adds a Record to the end of the log.
+func (l *MemoryLog) Append(rec Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, rec)
+	return nil
+}
+
+// Records returns a copy of every Record in the log.
+func (l *MemoryLog) Records() ([]Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Record(nil), l.records...), nil
+}
+
+// FileLog is an EventLog stored in a file with one JSON Record per line. Records are only ever
+// appended to the file, so a log from an earlier session can be reopened and continued. A last line
+// without a newline was torn by a crash during Append; Records skips it and OpenFileLog removes it.
+type FileLog struct {
+	mu   sync.Mutex
+	path string
+	file logFile
+}
+
+// logFile is the part of *os.File used by a FileLog.
+type logFile interface {
+	io.Writer
+	io.ReaderAt
+	io.Closer
+	Stat() (os.FileInfo, error)
+	Sync() error
+	Truncate(size int64) error
+}
+
+// OpenFileLog opens the FileLog at path, creating the file if it does not exist. A torn last line is
+// removed so the next Record starts on its own line.
+func OpenFileLog(path string) (*FileLog, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := truncateTorn(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &FileLog{path: path, file: file}, nil
+}
+
+// truncateTorn removes everything after the last newline of file.
+func truncateTorn(file logFile) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4096)
+	for end := info.Size(); end > 0; {
+		start := max(end-int64(len(buf)), 0)
+		chunk := buf[:end-start]
+		if _, err := file.ReadAt(chunk, start); err != nil {
+			return err
+		}
+
+		if i := bytes.LastIndexByte(chunk, '\n'); i >= 0 {
+			end = start + int64(i) + 1
+			if end == info.Size() {
+				return nil
+			}
+
+			return file.Truncate(end)
+		}
+
+		end = start
+	}
+
+	return file.Truncate(0)
+}
+
+// Path returns the path of the log file.
+func (l *FileLog) Path() string { return l.path }
+
+// Append writes a Record to the end of the log file and syncs it to disk. If the write fails the file
+// is truncated back to its old size, so a partial line never ends up in the middle of the log.
+func (l *FileLog) Append(rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return fmt.Errorf("invalid FileLog: log is closed")
+	}
+
+	info, err := l.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		return errors.Join(err, l.file.Truncate(info.Size()))
+	}
+
+	return l.file.Sync()
+}
+
+// Records reads every Record from the log file, skipping a torn last line.
+func (l *FileLog) Records() ([]Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []Record
+	r := bufio.NewReader(file)
+	for n := 1; ; n++ {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) && len(line) == 0 {
+			return records, nil
+		}
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		if err != nil {
+			return records, nil // A last line without a newline is torn.
+		}
+
+		if line = bytes.TrimSpace(line); len(line) == 0 {
+			continue
+		}
+
+		var rec Record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return nil, fmt.Errorf("invalid FileLog: line %d: %w", n, err)
+		}
+
+		records = append(records, rec)
+	}
+}
+
+// Close closes the log file. Records can still be read after the log is closed.
+func (l *FileLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
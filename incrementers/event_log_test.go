@@ -0,0 +1,167 @@
+package incrementers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLog(t *testing.T) {
+	require := require.New(t)
+	log := NewMemoryLog()
+	require.NoError(log.Append(Record{Event: &Event{Seq: 1}}))
+
+	records, err := log.Records()
+	require.NoError(err)
+	require.Len(records, 1)
+
+	records[0].Event = nil
+	records, err = log.Records()
+	require.NoError(err)
+	require.Equal(uint64(1), records[0].Event.Seq, "Records() did not return a copy")
+}
+
+func TestFileLog(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	clock := NewManualTime(schedulerStart)
+
+	log, err := OpenFileLog(path)
+	require.NoError(err)
+	require.Equal(path, log.Path())
+
+	s, err := NewEventStore(log, clock, 0)
+	require.NoError(err)
+
+	doom := NewClockWithTicks(6, 1)
+	r, err := s.Track("doom", TypeClock, doom)
+	require.NoError(err)
+	clock.Advance(time.Hour)
+	require.NoError(r.Increment())
+	require.NoError(r.Increment())
+	require.NoError(log.Close())
+
+	t.Run("closed", func(t *testing.T) {
+		err := r.Increment()
+		require.Error(err, "Recorder.Increment() did not return an error")
+		require.Equal("invalid FileLog: log is closed", err.Error())
+		require.Equal(3, doom.Value())
+		require.NoError(log.Close())
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		log, err := OpenFileLog(path)
+		require.NoError(err)
+		defer log.Close()
+
+		s, err := NewEventStore(log, clock, 0)
+		require.NoError(err)
+		require.Equal(uint64(2), s.Seq())
+
+		v, err := s.StateAt("doom", 1)
+		require.NoError(err)
+		require.Equal(2, v.(Clock).Value())
+
+		r, err := s.Track("doom", TypeClock, v)
+		require.NoError(err)
+		require.NoError(r.Fill())
+
+		events, err := s.Events("doom")
+		require.NoError(err)
+		require.Len(events, 3)
+		require.Equal("#3 doom fill 0", events[2].String())
+	})
+
+	t.Run("torn line", func(t *testing.T) {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+		require.NoError(err)
+		_, err = f.WriteString(`{"event":{"seq":4,"tar`)
+		require.NoError(err)
+		require.NoError(f.Close())
+
+		records, err := log.Records()
+		require.NoError(err, "FileLog.Records() returned an error: %s", err)
+		require.Len(records, 5)
+
+		log, err := OpenFileLog(path)
+		require.NoError(err)
+		defer log.Close()
+
+		data, err := os.ReadFile(path)
+		require.NoError(err)
+		require.Equal(byte('\n'), data[len(data)-1], "OpenFileLog() did not remove the torn line")
+
+		require.NoError(log.Append(Record{Event: &Event{Seq: 4, Target: "doom", Op: EventIncrement}}))
+		records, err = log.Records()
+		require.NoError(err, "FileLog.Records() returned an error: %s", err)
+		require.Len(records, 6)
+		require.Equal(uint64(4), records[5].Event.Seq)
+	})
+
+	t.Run("only torn line", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "torn.jsonl")
+		require.NoError(os.WriteFile(path, []byte(`{"snap`), 0o644))
+
+		log, err := OpenFileLog(path)
+		require.NoError(err)
+		defer log.Close()
+
+		records, err := log.Records()
+		require.NoError(err, "FileLog.Records() returned an error: %s", err)
+		require.Empty(records)
+	})
+
+	t.Run("invalid line", func(t *testing.T) {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+		require.NoError(err)
+		_, err = f.WriteString(`{"event":{"seq":4,"op":"multiply"}}` + "\n")
+		require.NoError(err)
+		require.NoError(f.Close())
+
+		log, err := OpenFileLog(path)
+		require.NoError(err)
+		defer log.Close()
+
+		_, err = log.Records()
+		require.Error(err, "FileLog.Records() did not return an error")
+		require.Equal("invalid FileLog: line 7: invalid EventOp: multiply", err.Error())
+	})
+
+	t.Run("failed write", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "failed.jsonl")
+		log, err := OpenFileLog(path)
+		require.NoError(err)
+		defer log.Close()
+		require.NoError(log.Append(Record{Event: &Event{Seq: 1, Target: "doom", Op: EventIncrement}}))
+
+		file := log.file
+		log.file = shortWriter{file.(*os.File)}
+		err = log.Append(Record{Event: &Event{Seq: 2, Target: "doom", Op: EventIncrement}})
+		require.Error(err, "FileLog.Append() did not return an error")
+		require.Equal("disk full", err.Error())
+
+		log.file = file
+		require.NoError(log.Append(Record{Event: &Event{Seq: 3, Target: "doom", Op: EventIncrement}}))
+		records, err := log.Records()
+		require.NoError(err, "FileLog.Records() returned an error: %s", err)
+		require.Len(records, 2)
+		require.Equal(uint64(3), records[1].Event.Seq)
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := OpenFileLog(filepath.Join(t.TempDir(), "missing", "session.jsonl"))
+		require.Error(err, "OpenFileLog() did not return an error")
+	})
+}
+
+// shortWriter is a log file which writes half of every write and then fails.
+type shortWriter struct{ *os.File }
+
+func (w shortWriter) Write(p []byte) (int, error) {
+	n, _ := w.File.Write(p[:len(p)/2])
+	return n, errors.New("disk full")
+}
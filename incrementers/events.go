@@ -0,0 +1,464 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// EventOp is the method an Event calls on an incrementer.
+type EventOp int
+
+const (
+	EventIncrement EventOp = iota
+	EventDecrement
+	EventAdd
+	EventRemove
+	EventSetIncrementer
+	EventSetValue
+	EventSetOriginalValue
+	EventSetMin
+	EventSetMax
+	EventEmpty
+	EventReset
+	EventFill
+	EventFloor
+	EventSetMinBound
+	EventSetMaxBound
+	EventSetFraction
+)
+
+var eventOps = []string{
+	"increment", "decrement", "add", "remove", "setInc", "set", "setOrig", "setMin", "setMax", "empty",
+	"reset", "fill", "floor", "setMinBound", "setMaxBound", "setFraction",
+}
+
+// String returns the name of the EventOp.
+func (o EventOp) String() string {
+	if o < 0 || int(o) >= len(eventOps) {
+		return fmt.Sprintf("EventOp(%d)", int(o))
+	}
+
+	return eventOps[o]
+}
+
+// MarshalText returns the name of the EventOp.
+func (o EventOp) MarshalText() ([]byte, error) {
+	if o < 0 || int(o) >= len(eventOps) {
+		return nil, fmt.Errorf("invalid EventOp: %d", int(o))
+	}
+
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText parses the name of an EventOp.
+func (o *EventOp) UnmarshalText(data []byte) error {
+	for i, name := range eventOps {
+		if name == string(data) {
+			*o = EventOp(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid EventOp: %s", data)
+}
+
+// Event is a single recorded call to a method which changes an incrementer. Replaying the Events of a
+// target in Seq order on top of a Snapshot rebuilds its state.
+type Event struct {
+	Seq       uint64       `json:"seq"`
+	Time      time.Time    `json:"time"`
+	Target    string       `json:"target"`
+	Op        EventOp      `json:"op"`
+	N         int          `json:"n,omitempty"`         // Argument of Add, Remove and the Set methods.
+	Unbounded bool         `json:"unbounded,omitempty"` // SetMinBound or SetMaxBound removes the bound.
+	Fraction  float64      `json:"fraction,omitempty"`  // Argument of SetFraction.
+	Mode      RoundingMode `json:"mode,omitempty"`      // Rounding of SetFraction.
+}
+
+// bound returns the argument of SetMinBound and SetMaxBound.
+func (e Event) bound() Bound {
+	if e.Unbounded {
+		return Unbounded()
+	}
+
+	return BoundAt(e.N)
+}
+
+// Apply calls the method of the Event on v.
+func (e Event) Apply(v any) error {
+	if err := e.check(v); err != nil {
+		return err
+	}
+
+	switch e.Op {
+	case EventIncrement:
+		v.(interface{ Increment() }).Increment()
+	case EventDecrement:
+		v.(interface{ Decrement() }).Decrement()
+	case EventAdd:
+		v.(interface{ Add(int) }).Add(e.N)
+	case EventRemove:
+		v.(interface{ Remove(int) }).Remove(e.N)
+	case EventSetIncrementer:
+		v.(interface{ SetIncrementer(int) }).SetIncrementer(e.N)
+	case EventSetValue:
+		v.(interface{ SetValue(int) }).SetValue(e.N)
+	case EventSetOriginalValue:
+		v.(interface{ SetOriginalValue(int) }).SetOriginalValue(e.N)
+	case EventSetMin:
+		v.(interface{ SetMin(int) }).SetMin(e.N)
+	case EventSetMax:
+		v.(interface{ SetMax(int) }).SetMax(e.N)
+	case EventEmpty:
+		v.(interface{ Empty() }).Empty()
+	case EventReset:
+		v.(interface{ Reset() }).Reset()
+	case EventFill:
+		v.(interface{ Fill() }).Fill()
+	case EventFloor:
+		v.(interface{ Floor() }).Floor()
+	case EventSetMinBound:
+		v.(interface{ SetMinBound(Bound) }).SetMinBound(e.bound())
+	case EventSetMaxBound:
+		v.(interface{ SetMaxBound(Bound) }).SetMaxBound(e.bound())
+	case EventSetFraction:
+		v.(interface{ SetFraction(float64, RoundingMode) }).SetFraction(e.Fraction, e.Mode)
+	}
+
+	return nil
+}
+
+// check returns an error if the Event can't be applied to v.
+func (e Event) check(v any) error {
+	var ok bool
+	switch e.Op {
+	case EventIncrement:
+		_, ok = v.(interface{ Increment() })
+	case EventDecrement:
+		_, ok = v.(interface{ Decrement() })
+	case EventAdd:
+		_, ok = v.(interface{ Add(int) })
+	case EventRemove:
+		_, ok = v.(interface{ Remove(int) })
+	case EventSetIncrementer:
+		_, ok = v.(interface{ SetIncrementer(int) })
+	case EventSetValue:
+		_, ok = v.(interface{ SetValue(int) })
+	case EventSetOriginalValue:
+		_, ok = v.(interface{ SetOriginalValue(int) })
+	case EventSetMin:
+		_, ok = v.(interface{ SetMin(int) })
+	case EventSetMax:
+		_, ok = v.(interface{ SetMax(int) })
+	case EventEmpty:
+		_, ok = v.(interface{ Empty() })
+	case EventReset:
+		_, ok = v.(interface{ Reset() })
+	case EventFill:
+		_, ok = v.(interface{ Fill() })
+	case EventFloor:
+		_, ok = v.(interface{ Floor() })
+	case EventSetMinBound:
+		_, ok = v.(interface{ SetMinBound(Bound) })
+	case EventSetMaxBound:
+		_, ok = v.(interface{ SetMaxBound(Bound) })
+	case EventSetFraction:
+		_, ok = v.(interface{ SetFraction(float64, RoundingMode) })
+	default:
+		return fmt.Errorf("invalid Event: unknown op %s", e.Op)
+	}
+
+	if !ok {
+		return fmt.Errorf("invalid Event: %T does not support %s", v, e.Op)
+	}
+
+	return nil
+}
+
+// String returns a short description of the Event such as "#4 hp add 3", "#5 hp setMaxBound none" or
+// "#6 hp setFraction 0.5 nearest".
+func (e Event) String() string {
+	switch e.Op {
+	case EventSetMinBound, EventSetMaxBound:
+		return fmt.Sprintf("#%d %s %s %s", e.Seq, e.Target, e.Op, e.bound())
+	case EventSetFraction:
+		return fmt.Sprintf("#%d %s %s %v %s", e.Seq, e.Target, e.Op, e.Fraction, e.Mode)
+	}
+
+	return fmt.Sprintf("#%d %s %s %d", e.Seq, e.Target, e.Op, e.N)
+}
+
+// Snapshot is the full Typed JSON state of a target after every Event up to and including Seq.
+type Snapshot struct {
+	Seq    uint64          `json:"seq"`
+	Time   time.Time       `json:"time"`
+	Target string          `json:"target"`
+	State  json.RawMessage `json:"state"`
+}
+
+// EventStore records every change made through its Recorders in an EventLog so the state of any
+// target can be rebuilt at any point in a session. A Snapshot of a target is written when it is
+// tracked and after every N Events so rebuilding only replays the Events since the last Snapshot.
+type EventStore struct {
+	mu     sync.Mutex
+	log    EventLog
+	source TimeSource
+	every  int
+	seq    uint64
+}
+
+// NewEventStore creates a new EventStore which writes to log and takes a Snapshot of a target after
+// every N Events. N of 0 only takes a Snapshot when a target is tracked. Records already in the log
+// are kept and new Events are numbered after them.
+func NewEventStore(log EventLog, source TimeSource, every int) (*EventStore, error) {
+	if every < 0 {
+		return nil, fmt.Errorf("invalid EventStore: snapshot interval must be 0 or greater")
+	}
+
+	records, err := log.Records()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &EventStore{log: log, source: source, every: every}
+	for _, rec := range records {
+		if rec.Event != nil {
+			s.seq = max(s.seq, rec.Event.Seq)
+		}
+	}
+
+	return s, nil
+}
+
+// Seq returns the Seq of the last recorded Event.
+func (s *EventStore) Seq() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seq
+}
+
+// Track writes a Snapshot of v, a value of a type registered with RegisterType, and returns a
+// Recorder which records every change made through it. Changes made to v without the Recorder are
+// not recorded.
+func (s *EventStore) Track(target, typ string, v json.Marshaler) (*Recorder, error) {
+	if !IsRegisteredType(typ) {
+		return nil, fmt.Errorf("invalid EventStore: unknown type %s", typ)
+	}
+
+	r := &Recorder{store: s, target: target, typ: typ, v: v}
+	if err := r.Snapshot(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Events returns every Event recorded for target in Seq order.
+func (s *EventStore) Events(target string) ([]Event, error) {
+	records, err := s.log.Records()
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for _, rec := range records {
+		if rec.Event != nil && rec.Event.Target == target {
+			events = append(events, *rec.Event)
+		}
+	}
+
+	return events, nil
+}
+
+// StateAt rebuilds the state of target after every Event up to and including seq.
+func (s *EventStore) StateAt(target string, seq uint64) (json.Marshaler, error) {
+	records, err := s.log.Records()
+	if err != nil {
+		return nil, err
+	}
+
+	var snap *Snapshot
+	for _, rec := range records {
+		if rec.Snapshot != nil && rec.Snapshot.Target == target && rec.Snapshot.Seq <= seq {
+			snap = rec.Snapshot
+		}
+	}
+
+	if snap == nil {
+		return nil, fmt.Errorf("invalid EventStore: no snapshot of %s at %d", target, seq)
+	}
+
+	typed, err := UnmarshalTyped(snap.State)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rec := range records {
+		e := rec.Event
+		if e == nil || e.Target != target || e.Seq <= snap.Seq || e.Seq > seq {
+			continue
+		}
+
+		if err := e.Apply(typed.Value); err != nil {
+			return nil, fmt.Errorf("invalid EventStore: event %d: %w", e.Seq, err)
+		}
+	}
+
+	return typed.Value, nil
+}
+
+// StateAtTime rebuilds the state of target after every Event recorded at or before t.
+func (s *EventStore) StateAtTime(target string, t time.Time) (json.Marshaler, error) {
+	records, err := s.log.Records()
+	if err != nil {
+		return nil, err
+	}
+
+	var seq uint64
+	var tracked bool
+	for _, rec := range records {
+		if rec.Event != nil && !rec.Event.Time.After(t) {
+			seq = max(seq, rec.Event.Seq)
+		}
+
+		if rec.Snapshot != nil && !rec.Snapshot.Time.After(t) {
+			seq = max(seq, rec.Snapshot.Seq)
+			tracked = tracked || rec.Snapshot.Target == target
+		}
+	}
+
+	if !tracked {
+		return nil, fmt.Errorf("invalid EventStore: no snapshot of %s at %s", target, t.Format(time.RFC3339))
+	}
+
+	return s.StateAt(target, seq)
+}
+
+// Recorder changes a tracked value and records every change as an Event in its EventStore. Each
+// method returns an error, without changing the value, if the value doesn't have the method or the
+// Event could not be written. An error writing a periodic Snapshot is returned after the change.
+type Recorder struct {
+	store  *EventStore
+	target string
+	typ    string
+	v      json.Marshaler
+	since  int // Events since the last Snapshot.
+}
+
+// Target returns the name the Recorder records Events under.
+func (r *Recorder) Target() string { return r.target }
+
+// Increment calls Increment on the tracked value.
+func (r *Recorder) Increment() error { return r.record(Event{Op: EventIncrement}) }
+
+// Decrement calls Decrement on the tracked value.
+func (r *Recorder) Decrement() error { return r.record(Event{Op: EventDecrement}) }
+
+// Add calls Add on the tracked value.
+func (r *Recorder) Add(val int) error { return r.record(Event{Op: EventAdd, N: val}) }
+
+// Remove calls Remove on the tracked value.
+func (r *Recorder) Remove(val int) error { return r.record(Event{Op: EventRemove, N: val}) }
+
+// SetIncrementer calls SetIncrementer on the tracked value.
+func (r *Recorder) SetIncrementer(inc int) error {
+	return r.record(Event{Op: EventSetIncrementer, N: inc})
+}
+
+// SetValue calls SetValue on the tracked value.
+func (r *Recorder) SetValue(val int) error { return r.record(Event{Op: EventSetValue, N: val}) }
+
+// SetOriginalValue calls SetOriginalValue on the tracked value.
+func (r *Recorder) SetOriginalValue(val int) error {
+	return r.record(Event{Op: EventSetOriginalValue, N: val})
+}
+
+// SetMin calls SetMin on the tracked value.
+func (r *Recorder) SetMin(min int) error { return r.record(Event{Op: EventSetMin, N: min}) }
+
+// SetMax calls SetMax on the tracked value.
+func (r *Recorder) SetMax(max int) error { return r.record(Event{Op: EventSetMax, N: max}) }
+
+// SetMinBound calls SetMinBound on the tracked value.
+func (r *Recorder) SetMinBound(min Bound) error { return r.record(boundEvent(EventSetMinBound, min)) }
+
+// SetMaxBound calls SetMaxBound on the tracked value.
+func (r *Recorder) SetMaxBound(max Bound) error { return r.record(boundEvent(EventSetMaxBound, max)) }
+
+// SetFraction calls SetFraction on the tracked value. f must be a number so the Event can be saved.
+func (r *Recorder) SetFraction(f float64, mode RoundingMode) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("invalid fraction: %v", f)
+	}
+
+	return r.record(Event{Op: EventSetFraction, Fraction: f, Mode: mode})
+}
+
+// Empty calls Empty on the tracked value.
+func (r *Recorder) Empty() error { return r.record(Event{Op: EventEmpty}) }
+
+// Reset calls Reset on the tracked value.
+func (r *Recorder) Reset() error { return r.record(Event{Op: EventReset}) }
+
+// Fill calls Fill on the tracked value.
+func (r *Recorder) Fill() error { return r.record(Event{Op: EventFill}) }
+
+// Floor calls Floor on the tracked value.
+func (r *Recorder) Floor() error { return r.record(Event{Op: EventFloor}) }
+
+// boundEvent returns the Event of SetMinBound or SetMaxBound with b.
+func boundEvent(op EventOp, b Bound) Event {
+	val, ok := b.Value()
+	return Event{Op: op, N: val, Unbounded: !ok}
+}
+
+// Snapshot writes the current state of the tracked value to the EventStore.
+func (r *Recorder) Snapshot() error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.snapshot()
+}
+
+func (r *Recorder) snapshot() error {
+	state, err := NewTyped(r.typ, r.v).MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	snap := &Snapshot{Seq: r.store.seq, Time: r.store.source.Now(), Target: r.target, State: state}
+	if err := r.store.log.Append(Record{Snapshot: snap}); err != nil {
+		return err
+	}
+
+	r.since = 0
+	return nil
+}
+
+// record applies e, with its Seq, Time and Target filled in, and writes it to the EventStore.
+func (r *Recorder) record(e Event) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	e.Seq, e.Time, e.Target = r.store.seq+1, r.store.source.Now(), r.target
+	if err := e.check(r.v); err != nil {
+		return err
+	}
+
+	if err := r.store.log.Append(Record{Event: &e}); err != nil {
+		return err
+	}
+
+	r.store.seq = e.Seq
+	if err := e.Apply(r.v); err != nil {
+		return err
+	}
+
+	if r.since++; r.store.every > 0 && r.since >= r.store.every {
+		return r.snapshot()
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventOp(t *testing.T) {
+	require := require.New(t)
+	require.Equal("setOrig", EventSetOriginalValue.String())
+	require.Equal("EventOp(20)", EventOp(20).String())
+
+	_, err := EventOp(20).MarshalText()
+	require.Error(err, "EventOp.MarshalText() did not return an error")
+	require.Equal("invalid EventOp: 20", err.Error())
+
+	var op EventOp
+	require.NoError(op.UnmarshalText([]byte("floor")))
+	require.Equal(EventFloor, op)
+
+	err = op.UnmarshalText([]byte("multiply"))
+	require.Error(err, "EventOp.UnmarshalText() did not return an error")
+	require.Equal("invalid EventOp: multiply", err.Error())
+}
+
+func TestEventApply(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("clamped", func(t *testing.T) {
+		c := NewClampedIncrementerWithValue(0, 10, 5)
+		for _, e := range []Event{
+			{Op: EventIncrement}, {Op: EventAdd, N: 3}, {Op: EventDecrement}, {Op: EventRemove, N: 2},
+			{Op: EventSetIncrementer, N: 2}, {Op: EventSetMin, N: 1}, {Op: EventSetMax, N: 8},
+			{Op: EventSetOriginalValue, N: 3}, {Op: EventSetValue, N: 9},
+		} {
+			require.NoError(e.Apply(&c), e.Op.String())
+		}
+
+		require.Equal(8, c.Value())
+		require.Equal(3, c.Original())
+		require.Equal(2, c.Inc())
+		require.Equal(1, c.Min())
+
+		for op, want := range map[EventOp]int{EventFloor: 1, EventFill: 8, EventReset: 3, EventEmpty: 0} {
+			require.NoError(Event{Op: op}.Apply(&c))
+			require.Equal(want, c.Value(), op.String())
+		}
+	})
+
+	t.Run("bounds and fraction", func(t *testing.T) {
+		c := NewClampedIncrementerWithValue(0, 10, 5)
+		require.NoError(Event{Op: EventSetMaxBound, Unbounded: true}.Apply(&c))
+		require.True(c.MaxBound().IsUnbounded())
+		require.NoError(Event{Op: EventSetMaxBound, N: 8}.Apply(&c))
+		require.NoError(Event{Op: EventSetMinBound, N: 2}.Apply(&c))
+		require.Equal(BoundAt(2), c.MinBound())
+		require.Equal(BoundAt(8), c.MaxBound())
+
+		require.NoError(Event{Op: EventSetFraction, Fraction: 0.5, Mode: RoundUp}.Apply(&c))
+		require.Equal(5, c.Value())
+
+		i := NewIncrementer()
+		err := Event{Op: EventSetFraction, Fraction: 0.5}.Apply(&i)
+		require.Error(err, "Event.Apply() did not return an error")
+		require.Equal("invalid Event: *incrementers.Incrementer does not support setFraction", err.Error())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		i := NewIncrementer()
+		err := Event{Op: EventFill}.Apply(&i)
+		require.Error(err, "Event.Apply() did not return an error")
+		require.Equal("invalid Event: *incrementers.Incrementer does not support fill", err.Error())
+
+		err = Event{Op: EventOp(20)}.Apply(&i)
+		require.Error(err, "Event.Apply() did not return an error")
+		require.Equal("invalid Event: unknown op EventOp(20)", err.Error())
+	})
+
+	t.Run("string", func(t *testing.T) {
+		require.Equal("#4 hp add 3", Event{Seq: 4, Target: "hp", Op: EventAdd, N: 3}.String())
+		require.Equal("#5 hp setMaxBound none", Event{Seq: 5, Target: "hp", Op: EventSetMaxBound, Unbounded: true}.String())
+		require.Equal("#6 hp setMinBound 2", Event{Seq: 6, Target: "hp", Op: EventSetMinBound, N: 2}.String())
+		require.Equal(
+			"#7 hp setFraction 0.5 nearest",
+			Event{Seq: 7, Target: "hp", Op: EventSetFraction, Fraction: 0.5, Mode: RoundNearest}.String(),
+		)
+	})
+}
+
+func TestNewEventStore(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("existing log", func(t *testing.T) {
+		log := NewMemoryLog()
+		require.NoError(log.Append(Record{Event: &Event{Seq: 7}}))
+		s, err := NewEventStore(log, NewManualTime(schedulerStart), 0)
+		require.NoError(err)
+		require.Equal(uint64(7), s.Seq())
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		_, err := NewEventStore(NewMemoryLog(), SystemTime{}, -1)
+		require.Error(err, "NewEventStore() did not return an error")
+		require.Equal("invalid EventStore: snapshot interval must be 0 or greater", err.Error())
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		s, err := NewEventStore(NewMemoryLog(), SystemTime{}, 0)
+		require.NoError(err)
+		_, err = s.Track("hp", "gauge", nil)
+		require.Error(err, "EventStore.Track() did not return an error")
+		require.Equal("invalid EventStore: unknown type gauge", err.Error())
+	})
+}
+
+func TestEventStoreRecorder(t *testing.T) {
+	require := require.New(t)
+	clock := NewManualTime(schedulerStart)
+	log := NewMemoryLog()
+	s, err := NewEventStore(log, clock, 3)
+	require.NoError(err)
+
+	hp := NewClampedIncrementerWithValue(0, 20, 20)
+	r, err := s.Track("hp", TypeClampedIncrementer, &hp)
+	require.NoError(err)
+	require.Equal("hp", r.Target())
+
+	xp := NewIncrementer()
+	x, err := s.Track("xp", TypeIncrementer, &xp)
+	require.NoError(err)
+
+	var values []int
+	for _, change := range []func() error{
+		func() error { return r.Remove(6) },
+		func() error { return x.Add(100) },
+		func() error { return r.Decrement() },
+		func() error { return r.SetMax(25) },
+		func() error { return r.Add(10) },
+		func() error { return r.Floor() },
+		func() error { return x.Increment() },
+		func() error { return r.Reset() },
+	} {
+		clock.Advance(time.Minute)
+		require.NoError(change())
+		values = append(values, hp.Value())
+	}
+
+	require.Equal(uint64(8), s.Seq())
+
+	t.Run("unsupported", func(t *testing.T) {
+		err := x.Fill()
+		require.Error(err, "Recorder.Fill() did not return an error")
+		require.Equal("invalid Event: *incrementers.Incrementer does not support fill", err.Error())
+		require.Equal(uint64(8), s.Seq())
+	})
+
+	t.Run("snapshots", func(t *testing.T) {
+		records, err := log.Records()
+		require.NoError(err)
+
+		var seqs []uint64
+		for _, rec := range records {
+			if rec.Snapshot != nil && rec.Snapshot.Target == "hp" {
+				seqs = append(seqs, rec.Snapshot.Seq)
+			}
+		}
+
+		require.Equal([]uint64{0, 4, 8}, seqs)
+	})
+
+	t.Run("events", func(t *testing.T) {
+		events, err := s.Events("xp")
+		require.NoError(err)
+		require.Len(events, 2)
+		require.Equal("#2 xp add 100", events[0].String())
+		require.Equal(schedulerStart.Add(2*time.Minute), events[0].Time)
+	})
+
+	t.Run("state at", func(t *testing.T) {
+		v, err := s.StateAt("hp", 0)
+		require.NoError(err)
+		require.Equal(20, v.(*ClampedIncrementer).Value())
+
+		for seq, want := range values {
+			v, err := s.StateAt("hp", uint64(seq+1))
+			require.NoError(err)
+			require.Equal(want, v.(*ClampedIncrementer).Value(), "seq %d", seq+1)
+		}
+
+		v, err = s.StateAt("xp", 6)
+		require.NoError(err)
+		require.Equal(100, v.(*Incrementer).Value())
+	})
+
+	t.Run("state at time", func(t *testing.T) {
+		v, err := s.StateAtTime("hp", schedulerStart.Add(4*time.Minute+time.Second))
+		require.NoError(err)
+		require.Equal(25, v.(*ClampedIncrementer).Max())
+		require.Equal(13, v.(*ClampedIncrementer).Value())
+
+		v, err = s.StateAtTime("xp", schedulerStart.Add(time.Hour))
+		require.NoError(err)
+		require.Equal(101, v.(*Incrementer).Value())
+	})
+
+	t.Run("no snapshot", func(t *testing.T) {
+		_, err := s.StateAt("mp", 4)
+		require.Error(err, "EventStore.StateAt() did not return an error")
+		require.Equal("invalid EventStore: no snapshot of mp at 4", err.Error())
+
+		_, err = s.StateAtTime("hp", schedulerStart.Add(-time.Hour))
+		require.Error(err, "EventStore.StateAtTime() did not return an error")
+		require.Equal("invalid EventStore: no snapshot of hp at 2023-12-31T23:00:00Z", err.Error())
+	})
+}
+
+type failingLog struct {
+	MemoryLog
+	fail bool
+}
+
+func (l *failingLog) Append(rec Record) error {
+	if l.fail {
+		return fmt.Errorf("disk full")
+	}
+
+	return l.MemoryLog.Append(rec)
+}
+
+func TestEventStoreAppendError(t *testing.T) {
+	require := require.New(t)
+	log := &failingLog{}
+	s, err := NewEventStore(log, SystemTime{}, 0)
+	require.NoError(err)
+
+	c := NewCounter()
+	r, err := s.Track("doom", TypeCounter, c)
+	require.NoError(err)
+
+	log.fail = true
+	require.EqualError(r.Increment(), "disk full")
+	require.Equal(0, c.Value(), "the value changed without an event")
+	require.Equal(uint64(0), s.Seq())
+}
+
+func TestEventStoreRecords(t *testing.T) {
+	require := require.New(t)
+	clock := NewManualTime(schedulerStart)
+	log := NewMemoryLog()
+	s, err := NewEventStore(log, clock, 0)
+	require.NoError(err)
+
+	clock.Advance(time.Second)
+	pool := NewPool(10)
+	r, err := s.Track("gold", TypePool, &pool)
+	require.NoError(err)
+	require.NoError(r.SetValue(6))
+
+	records, err := log.Records()
+	require.NoError(err)
+	data, err := json.Marshal(records)
+	require.NoError(err)
+	require.Equal(
+		`[{"snapshot":{"seq":0,"time":"2024-01-01T00:00:01Z","target":"gold","state":{"type":"pool","data":`+
+			`{"version":2,"max":{"version":2,"inc":1,"val":10,"orig":10},`+
+			`"reduction":{"version":2,"inc":1,"val":0,"orig":0},"current":{"version":2,"inc":1,"val":10,"orig":10},`+
+			`"temporary":{"version":2,"inc":1,"val":0,"orig":0}}}}},`+
+			`{"event":{"seq":1,"time":"2024-01-01T00:00:01Z","target":"gold","op":"set","n":6}}]`,
+		string(data),
+	)
+}
+
+func TestEventStoreBoundsAndFraction(t *testing.T) {
+	require := require.New(t)
+	log := NewMemoryLog()
+	s, err := NewEventStore(log, NewManualTime(schedulerStart), 0)
+	require.NoError(err)
+
+	c := NewClampedIncrementerWithValue(0, 10, 5)
+	r, err := s.Track("hp", TypeClampedIncrementer, &c)
+	require.NoError(err)
+	require.NoError(r.SetMaxBound(Unbounded()))
+	require.NoError(r.SetMaxBound(BoundAt(20)))
+	require.NoError(r.SetMinBound(BoundAt(-4)))
+	require.NoError(r.SetFraction(0.25, RoundNearest))
+	require.Equal(2, c.Value())
+
+	records, err := log.Records()
+	require.NoError(err)
+	data, err := json.Marshal(records[1:])
+	require.NoError(err)
+	require.Equal(
+		`[{"event":{"seq":1,"time":"2024-01-01T00:00:00Z","target":"hp","op":"setMaxBound","unbounded":true}},`+
+			`{"event":{"seq":2,"time":"2024-01-01T00:00:00Z","target":"hp","op":"setMaxBound","n":20}},`+
+			`{"event":{"seq":3,"time":"2024-01-01T00:00:00Z","target":"hp","op":"setMinBound","n":-4}},`+
+			`{"event":{"seq":4,"time":"2024-01-01T00:00:00Z","target":"hp","op":"setFraction","fraction":0.25,"mode":"nearest"}}]`,
+		string(data),
+	)
+
+	var decoded []Record
+	require.NoError(json.Unmarshal(data, &decoded))
+	require.Equal(records[1:], decoded)
+
+	v, err := s.StateAt("hp", 1)
+	require.NoError(err)
+	require.True(v.(*ClampedIncrementer).MaxBound().IsUnbounded())
+
+	v, err = s.StateAt("hp", 4)
+	require.NoError(err)
+	require.Equal(&c, v)
+
+	require.EqualError(r.SetFraction(math.NaN(), RoundDown), "invalid fraction: NaN")
+	require.EqualError(r.SetFraction(math.Inf(1), RoundDown), "invalid fraction: +Inf")
+	require.Equal(2, c.Value())
+	require.Equal(uint64(4), s.Seq())
+}
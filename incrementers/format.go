@@ -0,0 +1,103 @@
+package incrementers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Every incrementer in this package implements fmt.Formatter with these verbs:
+//
+//	%v, %s    the String form, such as "3/8"
+//	%d        the value only; flags and width apply as they do to an int
+//	%m        the value and max, such as "3/8", or only the value if there is no max
+//	%r        the range, such as "0..8", with a missing bound left empty as in "0.."
+//	%P        the percent of the way from min to max, such as "37%", or "0%" without both bounds
+//	%+v, %#v  a verbose debug form, such as "ClampedIncrementer{val:3 min:0 max:8 inc:1 orig:3}"
+//
+// Width and the '-' flag pad every form except %d, which uses the usual integer flags.
+
+// formatField is a named value shown in the verbose form.
+type formatField struct {
+	name string
+	val  any
+}
+
+// formatView is what an incrementer shows to Format.
+type formatView struct {
+	name   string
+	val    int
+	min    Bound
+	max    Bound
+	str    string
+	fields []formatField
+}
+
+// format writes v to f for verb.
+func (v formatView) format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), v.val)
+	case 's':
+		v.pad(f, v.str)
+	case 'v':
+		if f.Flag('+') || f.Flag('#') {
+			v.pad(f, v.verbose())
+			return
+		}
+
+		v.pad(f, v.str)
+	case 'm':
+		if max, ok := v.max.Value(); ok {
+			v.pad(f, fmt.Sprintf("%d/%d", v.val, max))
+			return
+		}
+
+		v.pad(f, fmt.Sprintf("%d", v.val))
+	case 'r':
+		v.pad(f, v.bound(v.min)+".."+v.bound(v.max))
+	case 'P':
+		min, hasMin := v.min.Value()
+		max, hasMax := v.max.Value()
+		pct := 0
+		if hasMin && hasMax {
+			pct = segments(v.val, min, max, 100)
+		}
+
+		v.pad(f, fmt.Sprintf("%d%%", pct))
+	default:
+		fmt.Fprintf(f, "%%!%c(incrementers.%s=%s)", verb, v.name, v.str)
+	}
+}
+
+// pad writes s to f with the width and '-' flag of f.
+func (v formatView) pad(f fmt.State, s string) {
+	format := "%"
+	if f.Flag('-') {
+		format += "-"
+	}
+
+	if w, ok := f.Width(); ok {
+		format += fmt.Sprintf("%d", w)
+	}
+
+	fmt.Fprintf(f, format+"s", s)
+}
+
+// bound returns b as a string for the range form, or an empty string if b is unbounded.
+func (v formatView) bound(b Bound) string {
+	if b.IsUnbounded() {
+		return ""
+	}
+
+	return b.String()
+}
+
+// verbose returns the debug form of v such as "Incrementer{val:3 inc:1 orig:0}".
+func (v formatView) verbose() string {
+	parts := make([]string, len(v.fields))
+	for i, fld := range v.fields {
+		parts[i] = fmt.Sprintf("%s:%v", fld.name, fld.val)
+	}
+
+	return v.name + "{" + strings.Join(parts, " ") + "}"
+}
@@ -0,0 +1,128 @@
+package incrementers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ fmt.Formatter = Incrementer{}
+	_ fmt.Formatter = UIncrementer{}
+	_ fmt.Formatter = ClampedIncrementer{}
+	_ fmt.Formatter = WrappingIncrementer{}
+	_ fmt.Formatter = TickingIncrementer{}
+	_ fmt.Formatter = SnappedIncrementer{}
+	_ fmt.Formatter = SequenceIncrementer{}
+	_ fmt.Formatter = ModifierStack{}
+	_ fmt.Formatter = CascadingCounter{}
+	_ fmt.Formatter = Pool{}
+	_ fmt.Formatter = &DynamicIncrementer{}
+	_ fmt.Formatter = &GCounter{}
+	_ fmt.Formatter = &PNCounter{}
+	_ fmt.Formatter = &BoundedCounter{}
+	_ fmt.Formatter = &StrictClampedIncrementer{}
+	_ fmt.Formatter = &StrictUIncrementer{}
+)
+
+func TestFormatClampedIncrementer(t *testing.T) {
+	require := require.New(t)
+	c := NewClampedIncrementerWithValue(0, 8, 3)
+	c.Decrement()
+	c.Increment()
+
+	tests := []struct{ format, want string }{
+		{"%v", "3/8"},
+		{"%s", "3/8"},
+		{"%d", "3"},
+		{"%03d", "003"},
+		{"%m", "3/8"},
+		{"%r", "0..8"},
+		{"%P", "37%"},
+		{"%+v", "ClampedIncrementer{val:3 min:0 max:8 inc:1 orig:3}"},
+		{"%#v", "ClampedIncrementer{val:3 min:0 max:8 inc:1 orig:3}"},
+		{"%6m", "   3/8"},
+		{"%-6r|", "0..8  |"},
+		{"%x", "%!x(incrementers.ClampedIncrementer=3/8)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			require.Equal(tt.want, fmt.Sprintf(tt.format, c))
+		})
+	}
+}
+
+func TestFormatUnbounded(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("Incrementer", func(t *testing.T) {
+		i := NewIncrementerWithValue(-4)
+		require.Equal("-4", fmt.Sprintf("%m", i))
+		require.Equal("..", fmt.Sprintf("%r", i))
+		require.Equal("0%", fmt.Sprintf("%P", i))
+		require.Equal("Incrementer{val:-4 inc:1 orig:-4}", fmt.Sprintf("%+v", i))
+	})
+
+	t.Run("UIncrementer", func(t *testing.T) {
+		u := NewUIncrementerWithValue(2)
+		require.Equal("0..", fmt.Sprintf("%r", u))
+		require.Equal("UIncrementer{val:2 inc:1 orig:2}", fmt.Sprintf("%+v", u))
+	})
+
+	t.Run("no max", func(t *testing.T) {
+		c := NewClampedIncrementerWithBounds(BoundAt(0), Unbounded(), 5)
+		require.Equal("5", fmt.Sprintf("%m", c))
+		require.Equal("0..", fmt.Sprintf("%r", c))
+		require.Equal("ClampedIncrementer{val:5 min:0 max:none inc:1 orig:5}", fmt.Sprintf("%+v", c))
+	})
+}
+
+func TestFormatTypes(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("WrappingIncrementer", func(t *testing.T) {
+		w := NewWrappingIncrementerWithValue(1, 12, 12)
+		w.Increment()
+		require.Equal("1..12", fmt.Sprintf("%r", w))
+		require.Equal("WrappingIncrementer{val:1 min:1 max:12 wraps:1 inc:1 orig:12}", fmt.Sprintf("%+v", w))
+	})
+
+	t.Run("DynamicIncrementer", func(t *testing.T) {
+		src := NewIncrementerWithValue(4)
+		d := NewDynamicIncrementer(NewClampedIncrementerWithValue(0, 10, 8))
+		require.NoError(d.BindMax(Sum, &src))
+		require.Equal("4/4", fmt.Sprintf("%m", d))
+		require.Equal("DynamicIncrementer{val:4 min:0 max:4 inc:1 orig:4 minBound:false maxBound:true}",
+			fmt.Sprintf("%+v", d))
+	})
+
+	t.Run("ModifierStack", func(t *testing.T) {
+		s := NewClampedModifierStack(NewClampedIncrementerWithValue(0, 20, 10))
+		s.Add(2)
+		require.Equal("12", fmt.Sprintf("%v", s))
+		require.Equal("12/20", fmt.Sprintf("%m", s))
+		require.Equal("60%", fmt.Sprintf("%P", s))
+	})
+
+	t.Run("Pool", func(t *testing.T) {
+		p := NewPool(20)
+		p.Damage(5)
+		require.Equal("15", fmt.Sprintf("%d", p))
+		require.Equal("75%", fmt.Sprintf("%P", p))
+		require.Equal("Pool{val:15 max:20 base:20 reduction:0 temporary:0 orig:20}", fmt.Sprintf("%+v", p))
+	})
+
+	t.Run("StrictClampedIncrementer", func(t *testing.T) {
+		s, err := NewStrictClampedIncrementer(0, 4, 1)
+		require.NoError(err)
+		require.Equal("25%", fmt.Sprintf("%P", s))
+		require.Equal("StrictClampedIncrementer{val:1 min:0 max:4 inc:1 orig:1}", fmt.Sprintf("%+v", s))
+	})
+
+	t.Run("GCounter", func(t *testing.T) {
+		g := NewGCounterWithValue("a", 3)
+		require.Equal("GCounter{replica:a val:3 inc:1 orig:3}", fmt.Sprintf("%+v", g))
+	})
+}
@@ -43,16 +43,41 @@ func (i Incrementer) IsEmpty() bool { return i.val == 0 }
 func (i Incrementer) IsUnchanged() bool { return i.val == i.orig }
 
 // Increment increments the Incrementer by the incrementer value.
-func (i *Incrementer) Increment() { i.val += i.inc }
+func (i *Incrementer) Increment() { i.val = AddSaturating(i.val, i.inc) }
 
 // Decrement decrements the Incrementer by the incrementer value.
-func (i *Incrementer) Decrement() { i.val -= i.inc }
+func (i *Incrementer) Decrement() { i.val = SubSaturating(i.val, i.inc) }
 
-// Add increments the Incrementer by the given number of val.
-func (i *Incrementer) Add(val int) { i.val += val }
+// Add increments the Incrementer by the given number of val. The value stops at math.MaxInt or
+// math.MinInt instead of overflowing.
+func (i *Incrementer) Add(val int) { i.val = AddSaturating(i.val, val) }
 
 // Remove decrements the Incrementer by the given number of val.
-func (i *Incrementer) Remove(val int) { i.val -= val }
+func (i *Incrementer) Remove(val int) { i.val = SubSaturating(i.val, val) }
+
+// AddChecked increments the Incrementer by val, or returns ErrOverflow and leaves the value unchanged
+// if the result does not fit in an int.
+func (i *Incrementer) AddChecked(val int) error {
+	v, err := AddChecked(i.val, val)
+	if err != nil {
+		return err
+	}
+
+	i.val = v
+	return nil
+}
+
+// RemoveChecked decrements the Incrementer by val, or returns ErrOverflow and leaves the value
+// unchanged if the result does not fit in an int.
+func (i *Incrementer) RemoveChecked(val int) error {
+	v, err := SubChecked(i.val, val)
+	if err != nil {
+		return err
+	}
+
+	i.val = v
+	return nil
+}
 
 // SetIncrementer sets the number the Incrementer will increment by to the given number of inc.
 func (i *Incrementer) SetIncrementer(inc int) { i.inc = inc }
@@ -72,12 +97,31 @@ func (i *Incrementer) Reset() { i.val = i.orig }
 // String returns a string representation of the Incrementer.
 func (i Incrementer) String() string { return fmt.Sprintf("%d", i.val) }
 
+func (i Incrementer) view() formatView {
+	return formatView{name: "Incrementer", val: i.val, str: i.String(), fields: []formatField{
+		{"val", i.val}, {"inc", i.inc}, {"orig", i.orig},
+	}}
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (i Incrementer) Format(f fmt.State, verb rune) { i.view().format(f, verb) }
+
 // MarshalJSON returns a JSON representation of the Incrementer.
 func (i Incrementer) MarshalJSON() ([]byte, error) {
-	return []byte(fmt.Sprintf(`{"inc":%d,"val":%d,"orig":%d}`, i.inc, i.val, i.orig)), nil
+	return []byte(fmt.Sprintf(
+		`{"version":%d,"inc":%d,"val":%d,"orig":%d}`,
+		incrementerVersion, i.inc, i.val, i.orig,
+	)), nil
 }
 
-// UnmarshalJSON parses a JSON representation of the Incrementer.
+type incrementerJSON struct {
+	Inc  int `json:"inc"`
+	Val  int `json:"val"`
+	Orig int `json:"orig"`
+}
+
+// UnmarshalJSON parses a JSON representation of the Incrementer. Older versions are migrated to the
+// latest version first.
 func (i *Incrementer) UnmarshalJSON(data []byte) error {
 	if data == nil {
 		return fmt.Errorf("Incrementer.UnmarshalJSON(): data was nil")
@@ -87,14 +131,43 @@ func (i *Incrementer) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	var inc, val, orig int
-	if _, err := fmt.Sscanf(string(data), `{"inc":%d,"val":%d,"orig":%d}`, &inc, &val, &orig); err != nil {
+	var j incrementerJSON
+	if err := unmarshalVersioned(TypeIncrementer, "Incrementer", data, &j, "inc", "val", "orig"); err != nil {
 		return err
 	}
 
-	i.inc = inc
-	i.val = val
-	i.orig = orig
+	i.inc = j.Inc
+	i.val = j.Val
+	i.orig = j.Orig
 
 	return nil
 }
+
+// MarshalBinary returns a compact binary representation of the Incrementer.
+func (i Incrementer) MarshalBinary() ([]byte, error) {
+	w := newBinaryWriter()
+	w.int(i.inc, i.val, i.orig)
+	return w.buf, nil
+}
+
+// UnmarshalBinary parses a binary representation of the Incrementer.
+func (i *Incrementer) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader("Incrementer", data)
+	if err != nil {
+		return err
+	}
+
+	n := Incrementer{inc: r.int(), val: r.int(), orig: r.int()}
+	if err := r.close(); err != nil {
+		return err
+	}
+
+	*i = n
+	return nil
+}
+
+// MarshalText returns a compact text representation of the Incrementer.
+func (i Incrementer) MarshalText() ([]byte, error) { return marshalText(i) }
+
+// UnmarshalText parses a text representation of the Incrementer.
+func (i *Incrementer) UnmarshalText(text []byte) error { return unmarshalText(i, text) }
@@ -1,6 +1,7 @@
 package incrementers
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -320,14 +321,14 @@ func TestIncrementerMarshalJSON(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		data, err := i.MarshalJSON()
 		require.NoError(err, "Incrementer.MarshalJSON() returned an error: %s", err)
-		require.Equal(`{"inc":0,"val":0,"orig":0}`, string(data))
+		require.Equal(`{"version":2,"inc":0,"val":0,"orig":0}`, string(data))
 	})
 
 	t.Run("set", func(t *testing.T) {
 		i = Incrementer{inc: 1, val: 2, orig: 3}
 		data, err := i.MarshalJSON()
 		require.NoError(err, "Incrementer.MarshalJSON() returned an error: %s", err)
-		require.Equal(`{"inc":1,"val":2,"orig":3}`, string(data))
+		require.Equal(`{"version":2,"inc":1,"val":2,"orig":3}`, string(data))
 	})
 }
 
@@ -351,10 +352,10 @@ func TestIncrementerUnmarshalJSON(t *testing.T) {
 		require.NoError(err, "Incrementer.UnmarshalJSON() returned an error: %w", err)
 	})
 
-	t.Run("scan error", func(t *testing.T) {
+	t.Run("missing field", func(t *testing.T) {
 		err := i.UnmarshalJSON([]byte(`{"inc":0,"val":0}`))
 		require.Error(err, "Incrementer.UnmarshalJSON() did not return an error")
-		require.Equal("input does not match format", err.Error())
+		require.Equal("invalid Incrementer: orig is required", err.Error())
 	})
 
 	t.Run("valid", func(t *testing.T) {
@@ -365,3 +366,35 @@ func TestIncrementerUnmarshalJSON(t *testing.T) {
 		require.Equal(3, i.orig)
 	})
 }
+
+func TestIncrementerOverflow(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("saturate", func(t *testing.T) {
+		i := NewIncrementerWithValue(math.MaxInt - 1)
+		i.SetIncrementer(5)
+		i.Increment()
+		require.Equal(math.MaxInt, i.Value())
+
+		i.Add(math.MaxInt)
+		require.Equal(math.MaxInt, i.Value())
+
+		i.SetValue(math.MinInt)
+		i.Decrement()
+		i.Remove(1)
+		require.Equal(math.MinInt, i.Value())
+	})
+
+	t.Run("checked", func(t *testing.T) {
+		i := NewIncrementerWithValue(math.MaxInt - 1)
+		require.NoError(i.AddChecked(1))
+		require.Equal(math.MaxInt, i.Value())
+
+		require.ErrorIs(i.AddChecked(1), ErrOverflow)
+		require.Equal(math.MaxInt, i.Value())
+
+		require.NoError(i.RemoveChecked(math.MaxInt))
+		require.ErrorIs(i.RemoveChecked(math.MinInt), ErrOverflow)
+		require.Equal(0, i.Value())
+	})
+}
@@ -1,5 +1,15 @@
 package incrementers
 
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrOverflow is returned by the checked arithmetic functions and methods when a result does not fit
+// in an int.
+var ErrOverflow = errors.New("integer overflow")
+
 func IsClamped(v, min, max int) bool {
 	return v >= min && v <= max
 }
@@ -23,3 +33,110 @@ func ClampMax(v, max int) int {
 
 	return v
 }
+
+// ClampAdd adds n to v without overflowing and clamps the result between min and max.
+func ClampAdd(v, n, min, max int) int { return Clamp(AddSaturating(v, n), min, max) }
+
+// AddSaturating returns a + b, or math.MaxInt or math.MinInt if the sum does not fit in an int.
+func AddSaturating(a, b int) int {
+	s, err := AddChecked(a, b)
+	if err != nil && b > 0 {
+		return math.MaxInt
+	}
+
+	if err != nil {
+		return math.MinInt
+	}
+
+	return s
+}
+
+// SubSaturating returns a - b, or math.MaxInt or math.MinInt if the difference does not fit in an int.
+func SubSaturating(a, b int) int {
+	d, err := SubChecked(a, b)
+	if err != nil && b < 0 {
+		return math.MaxInt
+	}
+
+	if err != nil {
+		return math.MinInt
+	}
+
+	return d
+}
+
+// MulSaturating returns a * b, or math.MaxInt or math.MinInt if the product does not fit in an int.
+func MulSaturating(a, b int) int {
+	p, err := MulChecked(a, b)
+	if err != nil && (a < 0) == (b < 0) {
+		return math.MaxInt
+	}
+
+	if err != nil {
+		return math.MinInt
+	}
+
+	return p
+}
+
+// AddChecked returns a + b, or ErrOverflow if the sum does not fit in an int.
+func AddChecked(a, b int) (int, error) {
+	s := a + b
+	if (b > 0 && s < a) || (b < 0 && s > a) {
+		return 0, fmt.Errorf("%w: %d + %d", ErrOverflow, a, b)
+	}
+
+	return s, nil
+}
+
+// SubChecked returns a - b, or ErrOverflow if the difference does not fit in an int.
+func SubChecked(a, b int) (int, error) {
+	d := a - b
+	if (b > 0 && d > a) || (b < 0 && d < a) {
+		return 0, fmt.Errorf("%w: %d - %d", ErrOverflow, a, b)
+	}
+
+	return d, nil
+}
+
+// MulChecked returns a * b, or ErrOverflow if the product does not fit in an int.
+func MulChecked(a, b int) (int, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	p := a * b
+	if p/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, fmt.Errorf("%w: %d * %d", ErrOverflow, a, b)
+	}
+
+	return p, nil
+}
+
+// floorToInt converts f to an int rounding toward negative infinity, saturating at math.MaxInt and
+// math.MinInt. NaN converts to 0.
+func floorToInt(f float64) int {
+	switch f = math.Floor(f); {
+	case math.IsNaN(f):
+		return 0
+	case f >= math.MaxInt:
+		return math.MaxInt
+	case f <= math.MinInt:
+		return math.MinInt
+	}
+
+	return int(f)
+}
+
+// floorDiv divides a by b rounding toward negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+
+	return q
+}
+
+// mod returns a modulo b with the sign of b.
+func mod(a, b int) int { return a - floorDiv(a, b)*b }
@@ -1,6 +1,9 @@
 package incrementers
 
 import (
+	"errors"
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -31,3 +34,119 @@ func TestMathClampMax(t *testing.T) {
 	require.Equal(ClampMax(5, 10), 5, "5 should be clamped below 10")
 	require.Equal(ClampMax(15, 10), 10, "15 should not be clamped below 10")
 }
+
+func TestMathChecked(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("in range", func(t *testing.T) {
+		v, err := AddChecked(math.MaxInt-1, 1)
+		require.NoError(err)
+		require.Equal(math.MaxInt, v)
+
+		v, err = SubChecked(math.MinInt+1, 1)
+		require.NoError(err)
+		require.Equal(math.MinInt, v)
+
+		v, err = MulChecked(math.MinInt/2, 2)
+		require.NoError(err)
+		require.Equal(math.MinInt, v)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		_, err := AddChecked(math.MaxInt, 1)
+		require.ErrorIs(err, ErrOverflow)
+		require.Equal("integer overflow: 9223372036854775807 + 1", err.Error())
+
+		_, err = SubChecked(0, math.MinInt)
+		require.ErrorIs(err, ErrOverflow)
+
+		_, err = MulChecked(-1, math.MinInt)
+		require.ErrorIs(err, ErrOverflow)
+	})
+
+	t.Run("saturating", func(t *testing.T) {
+		require.Equal(math.MaxInt, AddSaturating(math.MaxInt, math.MaxInt))
+		require.Equal(math.MinInt, AddSaturating(math.MinInt, -1))
+		require.Equal(math.MaxInt, SubSaturating(1, math.MinInt))
+		require.Equal(math.MinInt, SubSaturating(-2, math.MaxInt))
+		require.Equal(math.MaxInt, MulSaturating(math.MinInt, math.MinInt))
+		require.Equal(math.MinInt, MulSaturating(math.MaxInt, -2))
+	})
+
+	t.Run("clamp add", func(t *testing.T) {
+		require.Equal(10, ClampAdd(1, math.MaxInt, 0, 10))
+		require.Equal(0, ClampAdd(-1, math.MinInt, 0, 10))
+	})
+}
+
+// bigResult returns the exact result of an operation and whether it fits in an int.
+func bigResult(r *big.Int) (int, bool) {
+	if !r.IsInt64() {
+		if r.Sign() > 0 {
+			return math.MaxInt, false
+		}
+
+		return math.MinInt, false
+	}
+
+	return int(r.Int64()), true
+}
+
+func FuzzMathSaturating(f *testing.F) {
+	for _, v := range [][2]int{
+		{0, 0}, {1, -1}, {math.MaxInt, 1}, {math.MinInt, -1}, {math.MinInt, math.MinInt},
+		{math.MaxInt, math.MinInt}, {-1, math.MinInt}, {math.MaxInt / 2, 3}, {math.MinInt / 2, 2},
+	} {
+		f.Add(v[0], v[1])
+	}
+
+	f.Fuzz(func(t *testing.T, a, b int) {
+		x, y := big.NewInt(int64(a)), big.NewInt(int64(b))
+		for _, op := range []struct {
+			name      string
+			want      *big.Int
+			saturated int
+			checked   func(int, int) (int, error)
+		}{
+			{"add", new(big.Int).Add(x, y), AddSaturating(a, b), AddChecked},
+			{"sub", new(big.Int).Sub(x, y), SubSaturating(a, b), SubChecked},
+			{"mul", new(big.Int).Mul(x, y), MulSaturating(a, b), MulChecked},
+		} {
+			want, ok := bigResult(op.want)
+			if op.saturated != want {
+				t.Fatalf("%s(%d, %d) saturated to %d, want %d", op.name, a, b, op.saturated, want)
+			}
+
+			got, err := op.checked(a, b)
+			if ok && (err != nil || got != want) {
+				t.Fatalf("%s(%d, %d) = %d, %v, want %d", op.name, a, b, got, err, want)
+			}
+
+			if !ok && !errors.Is(err, ErrOverflow) {
+				t.Fatalf("%s(%d, %d) did not return ErrOverflow", op.name, a, b)
+			}
+		}
+	})
+}
+
+func FuzzMathClampAdd(f *testing.F) {
+	f.Add(1, math.MaxInt, 0, 10)
+	f.Add(math.MinInt, -1, math.MinInt, 0)
+	f.Add(math.MaxInt, math.MaxInt, -10, math.MaxInt)
+
+	f.Fuzz(func(t *testing.T, v, n, min, max int) {
+		if min > max {
+			return
+		}
+
+		got := ClampAdd(v, n, min, max)
+		if !IsClamped(got, min, max) {
+			t.Fatalf("ClampAdd(%d, %d, %d, %d) = %d, outside the range", v, n, min, max, got)
+		}
+
+		want, _ := bigResult(new(big.Int).Add(big.NewInt(int64(v)), big.NewInt(int64(n))))
+		if got != Clamp(want, min, max) {
+			t.Fatalf("ClampAdd(%d, %d, %d, %d) = %d, want %d", v, n, min, max, got, Clamp(want, min, max))
+		}
+	})
+}
@@ -0,0 +1,410 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ModifierKind is how a Modifier changes a value.
+type ModifierKind int
+
+const (
+	Additive       ModifierKind = iota // Adds Amount to the value.
+	Multiplicative                     // Multiplies the value by Factor.
+	Override                           // Replaces the value with Amount.
+)
+
+var modifierKinds = []string{"additive", "multiplicative", "override"}
+
+// String returns the name of the ModifierKind.
+func (k ModifierKind) String() string {
+	if k < 0 || int(k) >= len(modifierKinds) {
+		return fmt.Sprintf("ModifierKind(%d)", int(k))
+	}
+
+	return modifierKinds[k]
+}
+
+// MarshalText returns the name of the ModifierKind.
+func (k ModifierKind) MarshalText() ([]byte, error) {
+	if k < 0 || int(k) >= len(modifierKinds) {
+		return nil, fmt.Errorf("invalid ModifierKind: %d", int(k))
+	}
+
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText parses the name of a ModifierKind.
+func (k *ModifierKind) UnmarshalText(data []byte) error {
+	for i, name := range modifierKinds {
+		if name == string(data) {
+			*k = ModifierKind(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid ModifierKind: %s", data)
+}
+
+// Modifier is a buff or debuff on a ModifierStack.
+type Modifier struct {
+	Source string       `json:"source"` // What applied the modifier, such as "ring of protection".
+	Type   string       `json:"type"`   // Stacking group, such as "enhancement". See StackingRule.
+	Kind   ModifierKind `json:"kind"`
+	Amount int          `json:"amount,omitempty"` // Used by Additive and Override modifiers.
+	Factor float64      `json:"factor,omitempty"` // Used by Multiplicative modifiers.
+	Turns  int          `json:"turns"`            // Turns left before the modifier expires. 0 never expires.
+}
+
+// NewAdditiveModifier creates a Modifier which adds amount to the value for the given number of turns.
+func NewAdditiveModifier(source, typ string, amount, turns int) Modifier {
+	return Modifier{Source: source, Type: typ, Kind: Additive, Amount: amount, Turns: ClampMin(turns, 0)}
+}
+
+// NewMultiplicativeModifier creates a Modifier which multiplies the value by factor for the given
+// number of turns.
+func NewMultiplicativeModifier(source, typ string, factor float64, turns int) Modifier {
+	return Modifier{Source: source, Type: typ, Kind: Multiplicative, Factor: factor, Turns: ClampMin(turns, 0)}
+}
+
+// NewOverrideModifier creates a Modifier which replaces the value with val for the given number of
+// turns.
+func NewOverrideModifier(source, typ string, val, turns int) Modifier {
+	return Modifier{Source: source, Type: typ, Kind: Override, Amount: val, Turns: ClampMin(turns, 0)}
+}
+
+// StackingRule decides which modifiers of the same type and kind apply.
+type StackingRule int
+
+const (
+	StackAll     StackingRule = iota // Every modifier applies.
+	StackHighest                     // Only the highest modifier applies.
+	StackLowest                      // Only the lowest modifier applies.
+)
+
+// check returns an error if the StackingRule is not one of the rules above.
+func (r StackingRule) check() error {
+	if r < StackAll || r > StackLowest {
+		return fmt.Errorf("invalid ModifierStack: unknown stacking rule %d", int(r))
+	}
+
+	return nil
+}
+
+// ModifierStack is a base value with a stack of modifiers applied on top of it. The embedded
+// ClampedIncrementer is the base value; use Effective to get the modified value.
+//
+// The effective value is the most recently added Override if there is one, otherwise
+// (base + additive modifiers) * multiplicative modifiers rounded down. The effective value is clamped
+// to the min and max of the base.
+type ModifierStack struct {
+	ClampedIncrementer
+	mods  []Modifier
+	rules map[string]StackingRule
+}
+
+// NewModifierStack creates a new ModifierStack around an unbounded Incrementer.
+func NewModifierStack(base Incrementer) ModifierStack {
+	return ModifierStack{ClampedIncrementer: ClampedIncrementer{Incrementer: base}}
+}
+
+// NewClampedModifierStack creates a new ModifierStack around a ClampedIncrementer.
+func NewClampedModifierStack(base ClampedIncrementer) ModifierStack {
+	return ModifierStack{ClampedIncrementer: base}
+}
+
+// NewModifierStackFromJSON creates a new ModifierStack from a JSON representation.
+func NewModifierStackFromJSON(data []byte) (ModifierStack, error) {
+	var s ModifierStack
+	err := s.UnmarshalJSON(data)
+	return s, err
+}
+
+// Modifiers returns every modifier on the stack, including ones suppressed by stacking rules.
+func (s ModifierStack) Modifiers() []Modifier {
+	return append([]Modifier(nil), s.mods...)
+}
+
+// Rule returns the StackingRule for modifiers of the given type.
+func (s ModifierStack) Rule(typ string) StackingRule { return s.rules[typ] }
+
+// SetRule sets the StackingRule for modifiers of the given type. Modifiers with no type always stack.
+func (s *ModifierStack) SetRule(typ string, rule StackingRule) {
+	rules := make(map[string]StackingRule, len(s.rules)+1)
+	for t, r := range s.rules {
+		rules[t] = r
+	}
+
+	rules[typ] = rule
+	s.rules = rules
+}
+
+// AddModifier adds m to the stack. A modifier with the same source, type and kind is replaced.
+func (s *ModifierStack) AddModifier(m Modifier) {
+	mods := s.without(func(o Modifier) bool { return o.Source == m.Source && o.Type == m.Type && o.Kind == m.Kind })
+	s.mods = append(mods, m)
+}
+
+// RemoveSource removes every modifier applied by source.
+func (s *ModifierStack) RemoveSource(source string) {
+	s.mods = s.without(func(m Modifier) bool { return m.Source == source })
+}
+
+// ClearModifiers removes every modifier from the stack.
+func (s *ModifierStack) ClearModifiers() { s.mods = nil }
+
+// EndTurn counts down the turns of every timed modifier and removes the ones that run out. The
+// expired modifiers are returned.
+func (s *ModifierStack) EndTurn() []Modifier {
+	var expired []Modifier
+	mods := make([]Modifier, 0, len(s.mods))
+
+	for _, m := range s.mods {
+		if m.Turns > 0 {
+			m.Turns--
+			if m.Turns == 0 {
+				expired = append(expired, m)
+				continue
+			}
+		}
+
+		mods = append(mods, m)
+	}
+
+	s.mods = mods
+	return expired
+}
+
+// without returns a new slice of the modifiers on the stack except the ones drop returns true for.
+// The stack's own slice is never changed in place, so copies of a ModifierStack stay independent.
+func (s ModifierStack) without(drop func(Modifier) bool) []Modifier {
+	mods := make([]Modifier, 0, len(s.mods)+1)
+	for _, m := range s.mods {
+		if !drop(m) {
+			mods = append(mods, m)
+		}
+	}
+
+	return mods
+}
+
+// Effective returns the base value with every active modifier applied.
+func (s ModifierStack) Effective() int {
+	active := s.active()
+	c := s.ClampedIncrementer
+
+	for i := len(active) - 1; i >= 0; i-- {
+		if active[i].Kind == Override {
+			c.SetValue(active[i].Amount)
+			return c.Value()
+		}
+	}
+
+	val := s.val
+	factor := 1.0
+	for _, m := range active {
+		switch m.Kind {
+		case Additive:
+			val = AddSaturating(val, m.Amount)
+		case Multiplicative:
+			factor *= m.Factor
+		}
+	}
+
+	c.SetValue(floorToInt(float64(val) * factor))
+	return c.Value()
+}
+
+// String returns the effective value of the ModifierStack.
+func (s ModifierStack) String() string { return fmt.Sprintf("%d", s.Effective()) }
+
+func (s ModifierStack) view() formatView {
+	val := s.Effective()
+	return formatView{name: "ModifierStack", val: val, min: s.min, max: s.max, str: s.String(),
+		fields: []formatField{
+			{"val", val}, {"base", s.val}, {"min", s.min}, {"max", s.max}, {"inc", s.inc}, {"orig", s.orig},
+			{"mods", len(s.mods)},
+		}}
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (s ModifierStack) Format(f fmt.State, verb rune) { s.view().format(f, verb) }
+
+type modifierStackJSON struct {
+	Version   int                     `json:"version"`
+	Base      ClampedIncrementer      `json:"base"`
+	Modifiers []Modifier              `json:"modifiers"`
+	Rules     map[string]StackingRule `json:"rules,omitempty"`
+}
+
+// MarshalJSON returns a JSON representation of the ModifierStack.
+func (s ModifierStack) MarshalJSON() ([]byte, error) {
+	return json.Marshal(modifierStackJSON{
+		Version:   modifierStackVersion,
+		Base:      s.ClampedIncrementer,
+		Modifiers: s.Modifiers(),
+		Rules:     s.rules,
+	})
+}
+
+// UnmarshalJSON parses a JSON representation of the ModifierStack. Older versions are migrated to the
+// latest version first.
+func (s *ModifierStack) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("ModifierStack.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" || string(data) == `""` {
+		return nil
+	}
+
+	var j modifierStackJSON
+	if err := unmarshalVersioned(TypeModifierStack, "ModifierStack", data, &j, "base"); err != nil {
+		return err
+	}
+
+	for _, rule := range j.Rules {
+		if err := rule.check(); err != nil {
+			return err
+		}
+	}
+
+	s.ClampedIncrementer = j.Base
+	s.mods = j.Modifiers
+	s.rules = j.Rules
+
+	return nil
+}
+
+// MarshalBinary returns a compact binary representation of the ModifierStack.
+func (s ModifierStack) MarshalBinary() ([]byte, error) {
+	w := newBinaryWriter()
+	w.clamped(s.ClampedIncrementer)
+
+	w.uint(uint64(len(s.mods)))
+	for _, m := range s.mods {
+		w.string(m.Source)
+		w.string(m.Type)
+		w.int(int(m.Kind), m.Amount)
+		w.float(m.Factor)
+		w.int(m.Turns)
+	}
+
+	types := make([]string, 0, len(s.rules))
+	for typ := range s.rules {
+		types = append(types, typ)
+	}
+
+	sort.Strings(types)
+	w.uint(uint64(len(types)))
+	for _, typ := range types {
+		w.string(typ)
+		w.int(int(s.rules[typ]))
+	}
+
+	return w.buf, nil
+}
+
+// UnmarshalBinary parses a binary representation of the ModifierStack.
+func (s *ModifierStack) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader("ModifierStack", data)
+	if err != nil {
+		return err
+	}
+
+	n := ModifierStack{ClampedIncrementer: r.clamped()}
+	for count := r.uint(); count > 0 && r.err == nil; count-- {
+		m := Modifier{Source: r.string(), Type: r.string(), Kind: ModifierKind(r.int()), Amount: r.int()}
+		m.Factor = r.float()
+		m.Turns = r.int()
+		if m.Kind < Additive || m.Kind > Override {
+			return fmt.Errorf("invalid ModifierStack: unknown modifier kind %d", int(m.Kind))
+		}
+
+		n.mods = append(n.mods, m)
+	}
+
+	for count := r.uint(); count > 0 && r.err == nil; count-- {
+		typ, rule := r.string(), StackingRule(r.int())
+		if err := rule.check(); err != nil {
+			return err
+		}
+
+		n.SetRule(typ, rule)
+	}
+
+	if err := r.close(); err != nil {
+		return err
+	}
+
+	if err := n.validate(); err != nil {
+		return err
+	}
+
+	*s = n
+	return nil
+}
+
+// MarshalText returns a compact text representation of the ModifierStack.
+func (s ModifierStack) MarshalText() ([]byte, error) { return marshalText(s) }
+
+// UnmarshalText parses a text representation of the ModifierStack.
+func (s *ModifierStack) UnmarshalText(text []byte) error { return unmarshalText(s, text) }
+
+// active returns the modifiers that apply after stacking rules, in the order they were added.
+func (s ModifierStack) active() []Modifier {
+	var active []Modifier
+	for i, m := range s.mods {
+		if s.suppressed(i, m) {
+			continue
+		}
+
+		active = append(active, m)
+	}
+
+	return active
+}
+
+// suppressed returns true if the modifier at index i is beaten by another modifier of the same type
+// and kind under the stacking rule for its type. Ties go to the modifier added first.
+func (s ModifierStack) suppressed(i int, m Modifier) bool {
+	rule := s.rules[m.Type]
+	if m.Type == "" || rule == StackAll {
+		return false
+	}
+
+	for j, o := range s.mods {
+		if j == i || o.Type != m.Type || o.Kind != m.Kind {
+			continue
+		}
+
+		cmp := compareModifiers(o, m)
+		if rule == StackLowest {
+			cmp = -cmp
+		}
+
+		if cmp > 0 || (cmp == 0 && j < i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compareModifiers returns 1 if a is stronger than b, -1 if weaker and 0 if equal.
+func compareModifiers(a, b Modifier) int {
+	av, bv := float64(a.Amount), float64(b.Amount)
+	if a.Kind == Multiplicative {
+		av, bv = a.Factor, b.Factor
+	}
+
+	switch {
+	case av > bv:
+		return 1
+	case av < bv:
+		return -1
+	}
+
+	return 0
+}
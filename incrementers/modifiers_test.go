@@ -0,0 +1,271 @@
+package incrementers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestModifierKindString(t *testing.T) {
+	require := require.New(t)
+	require.Equal("additive", Additive.String())
+	require.Equal("multiplicative", Multiplicative.String())
+	require.Equal("override", Override.String())
+	require.Equal("ModifierKind(7)", ModifierKind(7).String())
+}
+
+func TestModifierKindText(t *testing.T) {
+	require := require.New(t)
+
+	data, err := Override.MarshalText()
+	require.NoError(err, "ModifierKind.MarshalText() returned an error: %s", err)
+	require.Equal("override", string(data))
+
+	_, err = ModifierKind(-1).MarshalText()
+	require.Error(err, "ModifierKind.MarshalText() did not return an error")
+
+	var k ModifierKind
+	require.NoError(k.UnmarshalText([]byte("multiplicative")))
+	require.Equal(Multiplicative, k)
+
+	err = k.UnmarshalText([]byte("divide"))
+	require.Error(err, "ModifierKind.UnmarshalText() did not return an error")
+	require.Equal("invalid ModifierKind: divide", err.Error())
+}
+
+func TestModifierStackNew(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("unbounded", func(t *testing.T) {
+		s := NewModifierStack(NewIncrementerWithValue(-20))
+		require.Equal(-20, s.Value())
+		require.Equal(-20, s.Effective())
+
+		s.AddModifier(NewAdditiveModifier("curse", "", -100, 0))
+		require.Equal(-120, s.Effective())
+	})
+
+	t.Run("clamped", func(t *testing.T) {
+		s := NewClampedModifierStack(NewClampedIncrementerWithValue(1, 20, 10))
+		require.Equal(10, s.Effective())
+		require.Empty(s.Modifiers())
+	})
+}
+
+func TestModifierStackEffective(t *testing.T) {
+	require := require.New(t)
+	s := NewClampedModifierStack(NewClampedIncrementerWithValue(0, 30, 10))
+
+	t.Run("additive", func(t *testing.T) {
+		s.AddModifier(NewAdditiveModifier("ring", "enhancement", 2, 0))
+		s.AddModifier(NewAdditiveModifier("exhaustion", "", -1, 0))
+		require.Equal(11, s.Effective())
+		require.Equal(10, s.Value())
+	})
+
+	t.Run("multiplicative", func(t *testing.T) {
+		s.AddModifier(NewMultiplicativeModifier("rage", "", 1.5, 0))
+		require.Equal(16, s.Effective())
+	})
+
+	t.Run("clamped", func(t *testing.T) {
+		s.AddModifier(NewMultiplicativeModifier("enlarge", "", 2, 0))
+		require.Equal(30, s.Effective())
+	})
+
+	t.Run("override", func(t *testing.T) {
+		s.AddModifier(NewOverrideModifier("gauntlets", "", 19, 0))
+		require.Equal(19, s.Effective())
+
+		s.AddModifier(NewOverrideModifier("belt", "", 21, 0))
+		require.Equal(21, s.Effective())
+
+		s.AddModifier(NewOverrideModifier("tome", "", 40, 0))
+		require.Equal(30, s.Effective())
+	})
+
+	t.Run("base changes", func(t *testing.T) {
+		s.ClearModifiers()
+		s.AddModifier(NewAdditiveModifier("ring", "enhancement", 2, 0))
+		s.Add(3)
+		require.Equal(15, s.Effective())
+		require.Equal("15", s.String())
+	})
+}
+
+func TestModifierStackAddModifier(t *testing.T) {
+	require := require.New(t)
+	s := NewModifierStack(NewIncrementerWithValue(10))
+
+	s.AddModifier(NewAdditiveModifier("bless", "", 1, 3))
+	s.AddModifier(NewAdditiveModifier("bless", "", 2, 5))
+	require.Equal([]Modifier{NewAdditiveModifier("bless", "", 2, 5)}, s.Modifiers())
+	require.Equal(12, s.Effective())
+
+	s.AddModifier(NewMultiplicativeModifier("bless", "", 2, 0))
+	require.Len(s.Modifiers(), 2)
+}
+
+func TestModifierStackRemoveSource(t *testing.T) {
+	require := require.New(t)
+	s := NewModifierStack(NewIncrementerWithValue(10))
+	s.AddModifier(NewAdditiveModifier("rage", "", 2, 0))
+	s.AddModifier(NewMultiplicativeModifier("rage", "", 2, 0))
+	s.AddModifier(NewAdditiveModifier("ring", "", 1, 0))
+
+	s.RemoveSource("rage")
+	require.Equal([]Modifier{NewAdditiveModifier("ring", "", 1, 0)}, s.Modifiers())
+	require.Equal(11, s.Effective())
+}
+
+func TestModifierStackRules(t *testing.T) {
+	require := require.New(t)
+	s := NewModifierStack(NewIncrementerWithValue(10))
+	s.AddModifier(NewAdditiveModifier("ring", "deflection", 1, 0))
+	s.AddModifier(NewAdditiveModifier("spell", "deflection", 3, 0))
+	s.AddModifier(NewAdditiveModifier("cloak", "deflection", 3, 0))
+	s.AddModifier(NewAdditiveModifier("shield", "", 2, 0))
+
+	t.Run("stack all", func(t *testing.T) {
+		require.Equal(StackAll, s.Rule("deflection"))
+		require.Equal(19, s.Effective())
+	})
+
+	t.Run("stack highest", func(t *testing.T) {
+		s.SetRule("deflection", StackHighest)
+		require.Equal(StackHighest, s.Rule("deflection"))
+		require.Equal(15, s.Effective())
+	})
+
+	t.Run("stack lowest", func(t *testing.T) {
+		s.SetRule("deflection", StackLowest)
+		require.Equal(13, s.Effective())
+	})
+
+	t.Run("kinds are separate", func(t *testing.T) {
+		s.SetRule("deflection", StackHighest)
+		s.AddModifier(NewMultiplicativeModifier("haste", "deflection", 2, 0))
+		require.Equal(30, s.Effective())
+	})
+}
+
+func TestModifierStackEndTurn(t *testing.T) {
+	require := require.New(t)
+	s := NewModifierStack(NewIncrementerWithValue(10))
+	s.AddModifier(NewAdditiveModifier("bless", "", 1, 1))
+	s.AddModifier(NewAdditiveModifier("haste", "", 2, 2))
+	s.AddModifier(NewAdditiveModifier("ring", "", 3, 0))
+	require.Equal(16, s.Effective())
+
+	require.Equal([]Modifier{NewAdditiveModifier("bless", "", 1, 0)}, s.EndTurn())
+	require.Equal(15, s.Effective())
+
+	require.Equal([]Modifier{NewAdditiveModifier("haste", "", 2, 0)}, s.EndTurn())
+	require.Equal(13, s.Effective())
+
+	require.Empty(s.EndTurn())
+	require.Equal(13, s.Effective())
+}
+
+func TestModifierStackCopy(t *testing.T) {
+	require := require.New(t)
+	s := NewModifierStack(NewIncrementerWithValue(10))
+	s.AddModifier(NewAdditiveModifier("bless", "", 1, 1))
+	s.AddModifier(NewAdditiveModifier("haste", "", 2, 2))
+	s.AddModifier(NewAdditiveModifier("ring", "deflection", 3, 0))
+	s.SetRule("deflection", StackHighest)
+	want := s.Modifiers()
+
+	c := s
+	c.EndTurn()
+	c.RemoveSource("haste")
+	c.AddModifier(NewAdditiveModifier("rage", "", 4, 0))
+	c.AddModifier(NewAdditiveModifier("ring", "deflection", 5, 0))
+	c.SetRule("deflection", StackLowest)
+
+	require.Equal(want, s.Modifiers(), "changing a copy changed the original")
+	require.Equal(StackHighest, s.Rule("deflection"))
+	require.Equal(16, s.Effective())
+	require.Equal(19, c.Effective())
+}
+
+func TestModifierStackMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	s := NewClampedModifierStack(NewClampedIncrementerWithValue(0, 30, 10))
+	s.AddModifier(NewAdditiveModifier("ring", "deflection", 2, 0))
+	s.AddModifier(NewMultiplicativeModifier("rage", "", 1.5, 3))
+	s.SetRule("deflection", StackHighest)
+
+	data, err := s.MarshalJSON()
+	require.NoError(err, "ModifierStack.MarshalJSON() returned an error: %s", err)
+	require.Equal(
+		`{"version":2,"base":{"version":3,"min":0,"max":30,"incrementer":{"version":2,"inc":1,"val":10,"orig":10}},"modifiers":[`+
+			`{"source":"ring","type":"deflection","kind":"additive","amount":2,"turns":0},`+
+			`{"source":"rage","type":"","kind":"multiplicative","factor":1.5,"turns":3}],"rules":{"deflection":1}}`,
+		string(data),
+	)
+}
+
+func TestModifierStackUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		s, err := NewModifierStackFromJSON([]byte(
+			`{"base":{"min":0,"max":30,"incrementer":{"inc":1,"val":10,"orig":10}},"modifiers":[` +
+				`{"source":"ring","type":"deflection","kind":"additive","amount":2,"turns":0},` +
+				`{"source":"rage","type":"","kind":"multiplicative","factor":1.5,"turns":3}],"rules":{"deflection":1}}`,
+		))
+		require.NoError(err, "ModifierStack.UnmarshalJSON() returned an error: %s", err)
+		require.Equal(10, s.Value())
+		require.Equal(18, s.Effective())
+		require.Equal(StackHighest, s.Rule("deflection"))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var s ModifierStack
+		err := s.UnmarshalJSON(nil)
+		require.Error(err, "ModifierStack.UnmarshalJSON() did not return an error")
+		require.Equal("ModifierStack.UnmarshalJSON(): data was nil", err.Error())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var s ModifierStack
+		require.NoError(s.UnmarshalJSON([]byte(`null`)))
+		require.Equal(ModifierStack{}, s)
+	})
+
+	t.Run("invalid kind", func(t *testing.T) {
+		_, err := NewModifierStackFromJSON([]byte(
+			`{"base":{"min":0,"max":30,"incrementer":{"inc":1,"val":10,"orig":10}},"modifiers":[` +
+				`{"source":"ring","type":"","kind":"divide","amount":2,"turns":0}]}`,
+		))
+		require.Error(err, "ModifierStack.UnmarshalJSON() did not return an error")
+		require.Equal("invalid ModifierKind: divide", err.Error())
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		s := NewModifierStack(NewIncrementerWithValue(4))
+		err := s.UnmarshalJSON([]byte(
+			`{"version":2,"base":{"version":3,"min":0,"max":30,"incrementer":{"version":2,"inc":1,"val":10,"orig":10}},` +
+				`"modifiers":[],"rules":{"deflection":7}}`,
+		))
+		require.Error(err, "ModifierStack.UnmarshalJSON() did not return an error")
+		require.Equal("invalid ModifierStack: unknown stacking rule 7", err.Error())
+		require.Equal(4, s.Value())
+	})
+}
+
+func TestModifierStackOverflow(t *testing.T) {
+	require := require.New(t)
+	s := NewModifierStack(NewIncrementerWithValue(math.MaxInt))
+
+	s.AddModifier(NewAdditiveModifier("bless", "", 1, 0))
+	require.Equal(math.MaxInt, s.Effective())
+
+	s.AddModifier(NewMultiplicativeModifier("enlarge", "", 2, 0))
+	require.Equal(math.MaxInt, s.Effective())
+
+	s.AddModifier(NewMultiplicativeModifier("shrink", "", -2, 0))
+	require.Equal(math.MinInt, s.Effective())
+}
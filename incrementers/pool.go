@@ -0,0 +1,219 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Pool is a layered resource such as hit points. It has a base maximum which can be lowered by a
+// reduction, a current value between 0 and the reduced maximum, and a temporary value which absorbs
+// damage before the current value does.
+type Pool struct {
+	max       UIncrementer
+	reduction UIncrementer
+	current   UIncrementer
+	temporary UIncrementer
+}
+
+// NewPool creates a new full Pool with a base maximum of max.
+func NewPool(max int) Pool {
+	max = ClampMin(max, 0)
+	return Pool{
+		max:       NewUIncrementerWithValue(max),
+		reduction: NewUIncrementer(),
+		current:   NewUIncrementerWithValue(max),
+		temporary: NewUIncrementer(),
+	}
+}
+
+// NewPoolFromJSON creates a new Pool from a JSON representation.
+func NewPoolFromJSON(data []byte) (Pool, error) {
+	var p Pool
+	err := p.UnmarshalJSON(data)
+	return p, err
+}
+
+// Value returns the current value of the Pool, not including temporary values.
+func (p Pool) Value() int { return p.current.Value() }
+
+// Original returns the value the Pool was created with.
+func (p Pool) Original() int { return p.current.Original() }
+
+// Max returns the maximum value of the Pool after reductions.
+func (p Pool) Max() int { return ClampMin(p.max.Value()-p.reduction.Value(), 0) }
+
+// BaseMax returns the maximum value of the Pool before reductions.
+func (p Pool) BaseMax() int { return p.max.Value() }
+
+// Reduction returns how much the maximum value of the Pool is reduced by.
+func (p Pool) Reduction() int { return p.reduction.Value() }
+
+// Temporary returns the temporary value of the Pool.
+func (p Pool) Temporary() int { return p.temporary.Value() }
+
+// Total returns the current value plus the temporary value.
+func (p Pool) Total() int { return AddSaturating(p.current.Value(), p.temporary.Value()) }
+
+// IsFull returns true if the current value is at the maximum.
+func (p Pool) IsFull() bool { return p.current.Value() == p.Max() }
+
+// IsEmpty returns true if the current value is 0.
+func (p Pool) IsEmpty() bool { return p.current.IsEmpty() }
+
+// Damage removes val from the Pool. The temporary value absorbs damage first, then the current value.
+// Damage beyond 0 is returned as overflow.
+func (p *Pool) Damage(val int) (overflow int) {
+	if val <= 0 {
+		return 0
+	}
+
+	absorbed := ClampMax(val, p.temporary.Value())
+	p.temporary.Remove(absorbed)
+	val -= absorbed
+
+	overflow = ClampMin(val-p.current.Value(), 0)
+	p.current.Remove(val)
+
+	return overflow
+}
+
+// Heal adds val to the current value of the Pool. Healing beyond the maximum is returned as overflow.
+func (p *Pool) Heal(val int) (overflow int) {
+	if val <= 0 {
+		return 0
+	}
+
+	overflow = ClampMin(val-(p.Max()-p.current.Value()), 0)
+	p.current.Add(val - overflow)
+
+	return overflow
+}
+
+// SetValue sets the current value of the Pool clamped between 0 and the maximum.
+func (p *Pool) SetValue(val int) { p.current.SetValue(ClampMax(val, p.Max())) }
+
+// SetTemporary replaces the temporary value of the Pool with val.
+func (p *Pool) SetTemporary(val int) { p.temporary.SetValue(val) }
+
+// GrantTemporary sets the temporary value to val if it is higher than the current temporary value.
+// Temporary values do not stack.
+func (p *Pool) GrantTemporary(val int) {
+	if val > p.temporary.Value() {
+		p.temporary.SetValue(val)
+	}
+}
+
+// SetBaseMax sets the maximum value of the Pool before reductions. The current value is clamped to the
+// new maximum.
+func (p *Pool) SetBaseMax(max int) { p.max.SetValue(max); p.clamp() }
+
+// ReduceMax lowers the maximum value of the Pool by val. The current value is clamped to the new
+// maximum.
+func (p *Pool) ReduceMax(val int) { p.reduction.Add(val); p.clamp() }
+
+// RestoreMax removes up to val from the maximum value reduction of the Pool.
+func (p *Pool) RestoreMax(val int) { p.reduction.Remove(val) }
+
+// Fill sets the current value to the maximum.
+func (p *Pool) Fill() { p.current.SetValue(p.Max()) }
+
+// Empty sets the current and temporary values to 0.
+func (p *Pool) Empty() { p.current.Empty(); p.temporary.Empty() }
+
+// Reset sets every layer of the Pool back to its original value.
+func (p *Pool) Reset() {
+	p.max.Reset()
+	p.reduction.Reset()
+	p.temporary.Reset()
+	p.current.Reset()
+	p.clamp()
+}
+
+// Ratio returns the current value as a fraction of the maximum, from 0 to 1. Temporary values are not
+// included. Returns 0 if the maximum is 0.
+func (p Pool) Ratio() float64 { return ratio(p.current.Value(), 0, p.Max()) }
+
+// Percent returns the current value as a percent of the maximum, from 0 to 100, rounded down so only a
+// full Pool is 100.
+func (p Pool) Percent() int { return segments(p.current.Value(), 0, p.Max(), 100) }
+
+// Segments returns how many of n equal segments of the maximum the current value has completely filled.
+// Returns 0 if n is less than 1.
+func (p Pool) Segments(n int) int { return segments(p.current.Value(), 0, p.Max(), n) }
+
+// SetFraction sets the current value to the given fraction of the maximum, rounded with mode. f is
+// clamped to 0 to 1. Does nothing if f is NaN.
+func (p *Pool) SetFraction(f float64, mode RoundingMode) {
+	if !math.IsNaN(f) {
+		p.current.SetValue(atFraction(f, 0, p.Max(), mode))
+	}
+}
+
+// String returns a string representation of the Pool.
+func (p Pool) String() string {
+	if p.temporary.IsEmpty() {
+		return fmt.Sprintf("%d/%d", p.current.Value(), p.Max())
+	}
+
+	return fmt.Sprintf("%d/%d+%d", p.current.Value(), p.Max(), p.temporary.Value())
+}
+
+func (p Pool) view() formatView {
+	val, max := p.current.Value(), p.Max()
+	return formatView{name: "Pool", val: val, min: BoundAt(0), max: BoundAt(max), str: p.String(),
+		fields: []formatField{
+			{"val", val}, {"max", max}, {"base", p.BaseMax()}, {"reduction", p.Reduction()},
+			{"temporary", p.Temporary()}, {"orig", p.Original()},
+		}}
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (p Pool) Format(f fmt.State, verb rune) { p.view().format(f, verb) }
+
+type poolJSON struct {
+	Version   int          `json:"version"`
+	Max       UIncrementer `json:"max"`
+	Reduction UIncrementer `json:"reduction"`
+	Current   UIncrementer `json:"current"`
+	Temporary UIncrementer `json:"temporary"`
+}
+
+// MarshalJSON returns a JSON representation of the Pool.
+func (p Pool) MarshalJSON() ([]byte, error) {
+	return json.Marshal(poolJSON{
+		Version:   poolVersion,
+		Max:       p.max,
+		Reduction: p.reduction,
+		Current:   p.current,
+		Temporary: p.temporary,
+	})
+}
+
+// UnmarshalJSON parses a JSON representation of the Pool. Older versions are migrated to the latest
+// version first.
+func (p *Pool) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("Pool.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" || string(data) == `""` {
+		return nil
+	}
+
+	var j poolJSON
+	if err := unmarshalVersioned(TypePool, "Pool", data, &j, "max", "current"); err != nil {
+		return err
+	}
+
+	n := Pool{max: j.Max, reduction: j.Reduction, current: j.Current, temporary: j.Temporary}
+	if n.current.Value() > n.Max() {
+		return fmt.Errorf("invalid Pool: current must be less than or equal to max - reduction")
+	}
+
+	*p = n
+	return nil
+}
+
+// clamp keeps the current value at or below the maximum.
+func (p *Pool) clamp() { p.current.SetValue(ClampMax(p.current.Value(), p.Max())) }
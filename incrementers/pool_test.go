@@ -0,0 +1,275 @@
+package incrementers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolNew(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("positive", func(t *testing.T) {
+		p := NewPool(45)
+		require.Equal(45, p.Value())
+		require.Equal(45, p.Original())
+		require.Equal(45, p.Max())
+		require.Equal(45, p.BaseMax())
+		require.Equal(0, p.Temporary())
+		require.Equal(0, p.Reduction())
+		require.True(p.IsFull())
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		p := NewPool(-5)
+		require.Equal(0, p.Value())
+		require.Equal(0, p.Max())
+	})
+}
+
+func TestPoolDamage(t *testing.T) {
+	require := require.New(t)
+	p := NewPool(20)
+
+	t.Run("no damage", func(t *testing.T) {
+		require.Equal(0, p.Damage(0))
+		require.Equal(0, p.Damage(-5))
+		require.Equal(20, p.Value())
+	})
+
+	t.Run("temporary first", func(t *testing.T) {
+		p.SetTemporary(5)
+		require.Equal(0, p.Damage(3))
+		require.Equal(2, p.Temporary())
+		require.Equal(20, p.Value())
+		require.Equal(22, p.Total())
+	})
+
+	t.Run("through temporary", func(t *testing.T) {
+		require.Equal(0, p.Damage(7))
+		require.Equal(0, p.Temporary())
+		require.Equal(15, p.Value())
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		require.Equal(5, p.Damage(20))
+		require.Equal(0, p.Value())
+		require.True(p.IsEmpty())
+	})
+}
+
+func TestPoolHeal(t *testing.T) {
+	require := require.New(t)
+	p := NewPool(20)
+	p.SetValue(10)
+
+	require.Equal(0, p.Heal(-5))
+	require.Equal(0, p.Heal(5))
+	require.Equal(15, p.Value())
+
+	require.Equal(3, p.Heal(8))
+	require.Equal(20, p.Value())
+}
+
+func TestPoolSetValue(t *testing.T) {
+	require := require.New(t)
+	p := NewPool(20)
+
+	p.SetValue(25)
+	require.Equal(20, p.Value())
+
+	p.SetValue(-5)
+	require.Equal(0, p.Value())
+}
+
+func TestPoolGrantTemporary(t *testing.T) {
+	require := require.New(t)
+	p := NewPool(20)
+
+	p.GrantTemporary(5)
+	require.Equal(5, p.Temporary())
+
+	p.GrantTemporary(3)
+	require.Equal(5, p.Temporary())
+
+	p.SetTemporary(3)
+	require.Equal(3, p.Temporary())
+}
+
+func TestPoolReduceMax(t *testing.T) {
+	require := require.New(t)
+	p := NewPool(20)
+
+	t.Run("reduce", func(t *testing.T) {
+		p.ReduceMax(8)
+		require.Equal(12, p.Max())
+		require.Equal(20, p.BaseMax())
+		require.Equal(8, p.Reduction())
+		require.Equal(12, p.Value())
+	})
+
+	t.Run("heal overflow uses reduced max", func(t *testing.T) {
+		p.SetValue(10)
+		require.Equal(3, p.Heal(5))
+	})
+
+	t.Run("below zero", func(t *testing.T) {
+		p.ReduceMax(30)
+		require.Equal(0, p.Max())
+		require.Equal(0, p.Value())
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		p.RestoreMax(100)
+		require.Equal(0, p.Reduction())
+		require.Equal(20, p.Max())
+		require.Equal(0, p.Value())
+	})
+}
+
+func TestPoolSetBaseMax(t *testing.T) {
+	require := require.New(t)
+	p := NewPool(20)
+
+	p.SetBaseMax(10)
+	require.Equal(10, p.Max())
+	require.Equal(10, p.Value())
+
+	p.SetBaseMax(30)
+	require.Equal(30, p.Max())
+	require.Equal(10, p.Value())
+	require.False(p.IsFull())
+
+	p.Fill()
+	require.Equal(30, p.Value())
+}
+
+func TestPoolReset(t *testing.T) {
+	require := require.New(t)
+	p := NewPool(20)
+	p.Damage(5)
+	p.SetTemporary(4)
+	p.ReduceMax(3)
+	p.SetBaseMax(40)
+
+	p.Reset()
+	require.Equal(20, p.Value())
+	require.Equal(20, p.Max())
+	require.Equal(0, p.Temporary())
+
+	p.SetTemporary(4)
+	p.Empty()
+	require.Equal(0, p.Value())
+	require.Equal(0, p.Temporary())
+}
+
+func TestPoolRatio(t *testing.T) {
+	require := require.New(t)
+	p := NewPool(40)
+	p.Damage(25)
+	p.GrantTemporary(10)
+	require.Equal(0.375, p.Ratio(), "Ratio() included temporary values")
+	require.Equal(37, p.Percent())
+	require.Equal(1, p.Segments(4))
+
+	p.ReduceMax(20)
+	require.Equal(75, p.Percent())
+
+	p.SetFraction(0.33, RoundUp)
+	require.Equal(7, p.Value())
+	p.SetFraction(math.NaN(), RoundUp)
+	require.Equal(7, p.Value())
+
+	t.Run("zero max", func(t *testing.T) {
+		p := NewPool(0)
+		require.Equal(0.0, p.Ratio())
+		require.Equal(0, p.Percent())
+		p.SetFraction(1, RoundUp)
+		require.Equal(0, p.Value())
+	})
+}
+
+func TestPoolString(t *testing.T) {
+	require := require.New(t)
+	p := NewPool(45)
+	p.Damage(8)
+	require.Equal("37/45", p.String())
+
+	p.SetTemporary(5)
+	require.Equal("37/45+5", p.String())
+}
+
+func TestPoolMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	p := NewPool(20)
+	p.Damage(5)
+	p.SetTemporary(3)
+	p.ReduceMax(2)
+
+	data, err := p.MarshalJSON()
+	require.NoError(err, "Pool.MarshalJSON() returned an error: %s", err)
+	require.Equal(
+		`{"version":2,"max":{"version":2,"inc":1,"val":20,"orig":20},"reduction":{"version":2,"inc":1,"val":2,"orig":0},`+
+			`"current":{"version":2,"inc":1,"val":15,"orig":20},"temporary":{"version":2,"inc":1,"val":3,"orig":0}}`,
+		string(data),
+	)
+}
+
+func TestPoolUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		p, err := NewPoolFromJSON([]byte(
+			`{"max":{"inc":1,"val":20,"orig":20},"reduction":{"inc":1,"val":2,"orig":0},` +
+				`"current":{"inc":1,"val":15,"orig":20},"temporary":{"inc":1,"val":3,"orig":0}}`,
+		))
+		require.NoError(err, "Pool.UnmarshalJSON() returned an error: %s", err)
+		require.Equal(15, p.Value())
+		require.Equal(18, p.Max())
+		require.Equal(3, p.Temporary())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var p Pool
+		err := p.UnmarshalJSON(nil)
+		require.Error(err, "Pool.UnmarshalJSON() did not return an error")
+		require.Equal("Pool.UnmarshalJSON(): data was nil", err.Error())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var p Pool
+		require.NoError(p.UnmarshalJSON([]byte(`null`)))
+		require.Equal(Pool{}, p)
+	})
+
+	t.Run("invalid layer", func(t *testing.T) {
+		_, err := NewPoolFromJSON([]byte(
+			`{"max":{"inc":1,"val":20,"orig":20},"reduction":{"inc":1,"val":0,"orig":0},` +
+				`"current":{"inc":1,"val":-1,"orig":20},"temporary":{"inc":1,"val":0,"orig":0}}`,
+		))
+		require.Error(err, "Pool.UnmarshalJSON() did not return an error")
+		require.Equal("invalid UIncrementer: Incrementer.val must be 0 or greater", err.Error())
+	})
+
+	t.Run("current above max", func(t *testing.T) {
+		_, err := NewPoolFromJSON([]byte(
+			`{"max":{"inc":1,"val":20,"orig":20},"reduction":{"inc":1,"val":5,"orig":0},` +
+				`"current":{"inc":1,"val":16,"orig":20},"temporary":{"inc":1,"val":0,"orig":0}}`,
+		))
+		require.Error(err, "Pool.UnmarshalJSON() did not return an error")
+		require.Equal("invalid Pool: current must be less than or equal to max - reduction", err.Error())
+	})
+}
+
+func TestPoolOverflow(t *testing.T) {
+	require := require.New(t)
+	p := NewPool(20)
+	p.SetValue(10)
+
+	require.Equal(math.MaxInt-10, p.Heal(math.MaxInt))
+	require.Equal(20, p.Value())
+
+	p.GrantTemporary(math.MaxInt)
+	require.Equal(math.MaxInt, p.Total())
+}
@@ -0,0 +1,113 @@
+package incrementers
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// RoundingMode is how a fraction of a range is rounded to a whole value.
+type RoundingMode int
+
+const (
+	RoundDown    RoundingMode = iota // Rounds toward the minimum.
+	RoundUp                          // Rounds toward the maximum.
+	RoundNearest                     // Rounds to the nearest value, with halves toward the maximum.
+)
+
+var roundingModes = []string{"down", "up", "nearest"}
+
+// String returns the name of the RoundingMode.
+func (m RoundingMode) String() string {
+	if m < 0 || int(m) >= len(roundingModes) {
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+
+	return roundingModes[m]
+}
+
+// MarshalText returns the name of the RoundingMode.
+func (m RoundingMode) MarshalText() ([]byte, error) {
+	if m < 0 || int(m) >= len(roundingModes) {
+		return nil, fmt.Errorf("invalid RoundingMode: %d", int(m))
+	}
+
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText parses the name of a RoundingMode.
+func (m *RoundingMode) UnmarshalText(data []byte) error {
+	for i, name := range roundingModes {
+		if name == string(data) {
+			*m = RoundingMode(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid RoundingMode: %s", data)
+}
+
+// fraction returns (val - min) / (max - min) clamped to 0 to 1, or nil if max is not above min.
+func fraction(val, min, max int) *big.Rat {
+	if max <= min {
+		return nil
+	}
+
+	val = Clamp(val, min, max)
+	num := new(big.Int).Sub(big.NewInt(int64(val)), big.NewInt(int64(min)))
+	den := new(big.Int).Sub(big.NewInt(int64(max)), big.NewInt(int64(min)))
+	return new(big.Rat).SetFrac(num, den)
+}
+
+func ratio(val, min, max int) float64 {
+	r := fraction(val, min, max)
+	if r == nil {
+		return 0
+	}
+
+	f, _ := r.Float64()
+	return f
+}
+
+func segments(val, min, max, n int) int {
+	r := fraction(val, min, max)
+	if r == nil || n < 1 {
+		return 0
+	}
+
+	r.Mul(r, new(big.Rat).SetInt64(int64(n)))
+	return int(round(r, RoundDown).Int64())
+}
+
+// atFraction returns the value f of the way from min to max rounded with mode.
+func atFraction(f float64, min, max int, mode RoundingMode) int {
+	if max <= min {
+		return min
+	}
+
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(math.Max(0, math.Min(1, f)), 'g', -1, 64))
+	span := new(big.Int).Sub(big.NewInt(int64(max)), big.NewInt(int64(min)))
+	r.Mul(r, new(big.Rat).SetInt(span))
+	off := round(r, mode)
+	return int(off.Add(off, big.NewInt(int64(min))).Int64())
+}
+
+// round rounds a non-negative r to a whole number with mode.
+func round(r *big.Rat, mode RoundingMode) *big.Int {
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() == 0 {
+		return q
+	}
+
+	switch mode {
+	case RoundUp:
+		q.Add(q, big.NewInt(1))
+	case RoundNearest:
+		if m.Lsh(m, 1).Cmp(r.Denom()) >= 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return q
+}
@@ -0,0 +1,89 @@
+package incrementers
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundingMode(t *testing.T) {
+	require := require.New(t)
+	require.Equal("down", RoundDown.String())
+	require.Equal("up", RoundUp.String())
+	require.Equal("nearest", RoundNearest.String())
+	require.Equal("RoundingMode(7)", RoundingMode(7).String())
+
+	text, err := RoundNearest.MarshalText()
+	require.NoError(err)
+	require.Equal("nearest", string(text))
+
+	var m RoundingMode
+	require.NoError(m.UnmarshalText([]byte("up")))
+	require.Equal(RoundUp, m)
+	require.Error(m.UnmarshalText([]byte("sideways")))
+
+	_, err = RoundingMode(7).MarshalText()
+	require.Error(err)
+}
+
+func TestRound(t *testing.T) {
+	require := require.New(t)
+
+	tests := []struct {
+		num, den          int64
+		down, up, nearest int64
+	}{
+		{6, 3, 2, 2, 2},
+		{7, 3, 2, 3, 2},
+		{5, 2, 2, 3, 3},
+		{8, 3, 2, 3, 3},
+		{0, 5, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		r := big.NewRat(tt.num, tt.den)
+		require.Equal(tt.down, round(r, RoundDown).Int64(), "%s down", r)
+		require.Equal(tt.up, round(r, RoundUp).Int64(), "%s up", r)
+		require.Equal(tt.nearest, round(r, RoundNearest).Int64(), "%s nearest", r)
+	}
+}
+
+func TestAtFraction(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("decimal", func(t *testing.T) {
+		require.Equal(29, atFraction(0.29, 0, 100, RoundDown))
+		require.Equal(-71, atFraction(0.29, -100, 0, RoundDown))
+		require.Equal(1, atFraction(0.00001, 0, 100000, RoundDown))
+	})
+
+	t.Run("clamped", func(t *testing.T) {
+		require.Equal(10, atFraction(-1, 10, 20, RoundUp))
+		require.Equal(20, atFraction(2, 10, 20, RoundDown))
+		require.Equal(20, atFraction(math.Inf(1), 10, 20, RoundDown))
+	})
+
+	t.Run("full range", func(t *testing.T) {
+		require.Equal(math.MinInt, atFraction(0, math.MinInt, math.MaxInt, RoundDown))
+		require.Equal(math.MaxInt, atFraction(1, math.MinInt, math.MaxInt, RoundDown))
+		require.Equal(-1, atFraction(0.5, math.MinInt, math.MaxInt, RoundDown))
+		require.Equal(0, atFraction(0.5, math.MinInt, math.MaxInt, RoundNearest))
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		require.Equal(5, atFraction(0.5, 5, 5, RoundUp))
+	})
+}
+
+func TestSegments(t *testing.T) {
+	require := require.New(t)
+	require.Equal(1, segments(3, 0, 8, 4))
+	require.Equal(4, segments(8, 0, 8, 4))
+	require.Equal(0, segments(8, 0, 8, 0))
+	require.Equal(0, segments(3, 3, 3, 4))
+	require.Equal(50, segments(0, math.MinInt, math.MaxInt, 100))
+	require.Equal(0.5, ratio(5, 0, 10))
+	require.Equal(0.0, ratio(5, 10, 10))
+}
@@ -0,0 +1,372 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type registryEntry struct {
+	typ   string
+	tags  []string
+	value json.Marshaler
+}
+
+// Registry stores named Counters, Clocks and incrementers for a game session. Entries can be tagged
+// to group them for lookups and bulk operations. Any type registered with RegisterType can be stored.
+type Registry struct {
+	entries map[string]*registryEntry
+}
+
+// NewRegistry creates a new empty Registry.
+func NewRegistry() *Registry { return &Registry{entries: make(map[string]*registryEntry)} }
+
+// NewRegistryFromJSON creates a new Registry from a JSON representation.
+func NewRegistryFromJSON(data []byte) (*Registry, error) {
+	r := NewRegistry()
+	err := r.UnmarshalJSON(data)
+	return r, err
+}
+
+// Add adds a value of a type registered with RegisterType to the Registry with the given tags.
+func (r *Registry) Add(name, typ string, v json.Marshaler, tags ...string) error {
+	if !IsRegisteredType(typ) {
+		return fmt.Errorf("invalid Registry: unknown type %s", typ)
+	}
+
+	return r.addChecked(name, typ, v, tags)
+}
+
+// AddCounter adds a Counter to the Registry with the given tags. c must reload as a Counter, such as
+// one made with NewCounter. Add other types with Add and their registered type.
+func (r *Registry) AddCounter(name string, c Counter, tags ...string) error {
+	return r.addChecked(name, TypeCounter, c, tags)
+}
+
+// AddClock adds a Clock to the Registry with the given tags. c must reload as a Clock, such as one
+// made with NewClock. Add other types, such as a WrappingIncrementer, with Add and their registered
+// type.
+func (r *Registry) AddClock(name string, c Clock, tags ...string) error {
+	return r.addChecked(name, TypeClock, c, tags)
+}
+
+// AddIncrementer adds an Incrementer to the Registry with the given tags.
+func (r *Registry) AddIncrementer(name string, i *Incrementer, tags ...string) error {
+	return r.addChecked(name, TypeIncrementer, i, tags)
+}
+
+// AddUIncrementer adds a UIncrementer to the Registry with the given tags.
+func (r *Registry) AddUIncrementer(name string, u *UIncrementer, tags ...string) error {
+	return r.addChecked(name, TypeUIncrementer, u, tags)
+}
+
+// AddClampedIncrementer adds a ClampedIncrementer to the Registry with the given tags.
+func (r *Registry) AddClampedIncrementer(name string, c *ClampedIncrementer, tags ...string) error {
+	return r.addChecked(name, TypeClampedIncrementer, c, tags)
+}
+
+// Remove removes the named entry from the Registry.
+func (r *Registry) Remove(name string) { delete(r.entries, name) }
+
+// Len returns the number of entries in the Registry.
+func (r *Registry) Len() int { return len(r.entries) }
+
+// Has returns true if the Registry has an entry with the given name.
+func (r *Registry) Has(name string) bool { _, ok := r.entries[name]; return ok }
+
+// Type returns the type of the named entry.
+func (r *Registry) Type(name string) (string, bool) {
+	e, ok := r.entries[name]
+	if !ok {
+		return "", false
+	}
+
+	return e.typ, true
+}
+
+// Get returns the named entry.
+func (r *Registry) Get(name string) (any, bool) {
+	e, ok := r.entries[name]
+	if !ok {
+		return nil, false
+	}
+
+	return e.value, true
+}
+
+// Counter returns the named entry if it was added as a Counter.
+func (r *Registry) Counter(name string) (Counter, bool) {
+	c, ok := r.typed(name, TypeCounter).(Counter)
+	return c, ok
+}
+
+// Clock returns the named entry if it was added as a Clock.
+func (r *Registry) Clock(name string) (Clock, bool) {
+	c, ok := r.typed(name, TypeClock).(Clock)
+	return c, ok
+}
+
+// Incrementer returns the named entry if it was added as an Incrementer.
+func (r *Registry) Incrementer(name string) (*Incrementer, bool) {
+	i, ok := r.typed(name, TypeIncrementer).(*Incrementer)
+	return i, ok
+}
+
+// UIncrementer returns the named entry if it was added as a UIncrementer.
+func (r *Registry) UIncrementer(name string) (*UIncrementer, bool) {
+	u, ok := r.typed(name, TypeUIncrementer).(*UIncrementer)
+	return u, ok
+}
+
+// ClampedIncrementer returns the named entry if it was added as a ClampedIncrementer.
+func (r *Registry) ClampedIncrementer(name string) (*ClampedIncrementer, bool) {
+	c, ok := r.typed(name, TypeClampedIncrementer).(*ClampedIncrementer)
+	return c, ok
+}
+
+// Names returns the sorted names of every entry in the Registry.
+func (r *Registry) Names() []string {
+	return r.filter(func(string, *registryEntry) bool { return true })
+}
+
+// WithPrefix returns the sorted names of every entry whose name starts with prefix.
+func (r *Registry) WithPrefix(prefix string) []string {
+	return r.filter(func(name string, _ *registryEntry) bool { return strings.HasPrefix(name, prefix) })
+}
+
+// WithTag returns the sorted names of every entry with the given tag.
+func (r *Registry) WithTag(tag string) []string {
+	return r.filter(func(_ string, e *registryEntry) bool { return slices.Contains(e.tags, tag) })
+}
+
+// Tags returns the sorted tags of the named entry.
+func (r *Registry) Tags(name string) []string {
+	e, ok := r.entries[name]
+	if !ok {
+		return nil
+	}
+
+	return append([]string(nil), e.tags...)
+}
+
+// Tag adds tags to the named entry.
+func (r *Registry) Tag(name string, tags ...string) error {
+	e, ok := r.entries[name]
+	if !ok {
+		return fmt.Errorf("invalid Registry: unknown entry %s", name)
+	}
+
+	e.tags = mergeTags(e.tags, tags)
+	return nil
+}
+
+// Untag removes tags from the named entry.
+func (r *Registry) Untag(name string, tags ...string) error {
+	e, ok := r.entries[name]
+	if !ok {
+		return fmt.Errorf("invalid Registry: unknown entry %s", name)
+	}
+
+	e.tags = slices.DeleteFunc(e.tags, func(t string) bool { return slices.Contains(tags, t) })
+	return nil
+}
+
+// ResetAll resets every entry in the Registry to its original value. Entries without a Reset method
+// are skipped.
+func (r *Registry) ResetAll() {
+	for _, e := range r.entries {
+		e.reset()
+	}
+}
+
+// ResetTag resets every entry with the given tag to its original value. Entries without a Reset method
+// are skipped.
+func (r *Registry) ResetTag(tag string) {
+	for _, name := range r.WithTag(tag) {
+		r.entries[name].reset()
+	}
+}
+
+// FillClocks fills every Clock with the given tag. An empty tag fills every Clock.
+func (r *Registry) FillClocks(tag string) {
+	for _, e := range r.entries {
+		if e.typ == TypeClock && (tag == "" || slices.Contains(e.tags, tag)) {
+			if c, ok := e.value.(Clock); ok {
+				c.Fill()
+			}
+		}
+	}
+}
+
+// EmptyClocks empties every Clock with the given tag. An empty tag empties every Clock.
+func (r *Registry) EmptyClocks(tag string) {
+	for _, e := range r.entries {
+		if e.typ == TypeClock && (tag == "" || slices.Contains(e.tags, tag)) {
+			if c, ok := e.value.(Clock); ok {
+				c.Empty()
+			}
+		}
+	}
+}
+
+// ApplyDelta applies d to the entry named by its Target.
+func (r *Registry) ApplyDelta(d Delta) error { return r.ApplyPatch(Patch{d}) }
+
+// ApplyPatch applies every Delta in p, in order, to the entries named by their Targets. Nothing is
+// changed if any Delta has an unknown target or an operation its entry doesn't support. Merge the Patch
+// first to apply the Deltas in the same order as other clients.
+func (r *Registry) ApplyPatch(p Patch) error {
+	for _, d := range p {
+		e, ok := r.entries[d.Target]
+		if !ok {
+			return fmt.Errorf("invalid Registry: unknown entry %s", d.Target)
+		}
+
+		if err := d.check(e.value); err != nil {
+			return fmt.Errorf("invalid Registry: entry %s: %w", d.Target, err)
+		}
+	}
+
+	for _, d := range p {
+		_ = d.Apply(r.entries[d.Target].value)
+	}
+
+	return nil
+}
+
+type registryEntryJSON struct {
+	Name string          `json:"name"`
+	Type string          `json:"type"`
+	Tags []string        `json:"tags,omitempty"`
+	Data json.RawMessage `json:"data"`
+}
+
+type registryJSON struct {
+	Entries []registryEntryJSON `json:"entries"`
+}
+
+// MarshalJSON returns a JSON representation of the Registry with entries sorted by name.
+func (r *Registry) MarshalJSON() ([]byte, error) {
+	j := registryJSON{Entries: []registryEntryJSON{}}
+	for _, name := range r.Names() {
+		e := r.entries[name]
+		data, err := e.value.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		j.Entries = append(j.Entries, registryEntryJSON{Name: name, Type: e.typ, Tags: e.tags, Data: data})
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON parses a JSON representation of the Registry, replacing every entry.
+func (r *Registry) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("Registry.UnmarshalJSON(): data was nil")
+	}
+
+	var j registryJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	n := NewRegistry()
+	for _, e := range j.Entries {
+		value, err := decodeTyped(e.Type, e.Data)
+		if err != nil {
+			return fmt.Errorf("invalid Registry: entry %s: %w", e.Name, err)
+		}
+
+		if err := n.add(e.Name, e.Type, value, e.Tags); err != nil {
+			return err
+		}
+	}
+
+	r.entries = n.entries
+	return nil
+}
+
+// addChecked adds value after checking that it reloads as typ into a value of the same Go type, so a
+// Registry that saves also loads and its entries implement the interface of their type.
+func (r *Registry) addChecked(name, typ string, value json.Marshaler, tags []string) error {
+	if value == nil {
+		return fmt.Errorf("invalid Registry: entry %s: value must not be nil", name)
+	}
+
+	data, err := value.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("invalid Registry: entry %s: %w", name, err)
+	}
+
+	got, err := checkTyped(typ, value, data)
+	if err != nil {
+		return fmt.Errorf("invalid Registry: entry %s: %w", name, err)
+	}
+
+	if reflect.TypeOf(got) != reflect.TypeOf(value) {
+		return fmt.Errorf("invalid Registry: entry %s: %T must be a %T", name, value, got)
+	}
+
+	return r.add(name, typ, value, tags)
+}
+
+func (r *Registry) add(name, typ string, value json.Marshaler, tags []string) error {
+	if name == "" {
+		return fmt.Errorf("invalid Registry: name must not be empty")
+	}
+
+	if _, ok := r.entries[name]; ok {
+		return fmt.Errorf("invalid Registry: %s is already registered", name)
+	}
+
+	if r.entries == nil {
+		r.entries = make(map[string]*registryEntry)
+	}
+
+	r.entries[name] = &registryEntry{typ: typ, tags: mergeTags(nil, tags), value: value}
+	return nil
+}
+
+func (e *registryEntry) reset() {
+	if v, ok := e.value.(interface{ Reset() }); ok {
+		v.Reset()
+	}
+}
+
+func (r *Registry) typed(name, typ string) any {
+	e, ok := r.entries[name]
+	if !ok || e.typ != typ {
+		return nil
+	}
+
+	return e.value
+}
+
+func (r *Registry) filter(keep func(string, *registryEntry) bool) []string {
+	names := []string{}
+	for name, e := range r.entries {
+		if keep(name, e) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// mergeTags adds the new tags to tags, removing duplicates and empty tags, and sorts the result.
+func mergeTags(tags, add []string) []string {
+	for _, t := range add {
+		if t != "" && !slices.Contains(tags, t) {
+			tags = append(tags, t)
+		}
+	}
+
+	sort.Strings(tags)
+	return tags
+}
@@ -0,0 +1,380 @@
+package incrementers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry(t *testing.T) *Registry {
+	require := require.New(t)
+	r := NewRegistry()
+	inc := NewIncrementerWithValue(-3)
+	u := NewUIncrementerWithValue(4)
+	c := NewClampedIncrementerWithValue(-5, 5, 1)
+
+	require.NoError(r.AddCounter("party.xp", NewCounterWithValue(100), "party"))
+	require.NoError(r.AddClock("party.doom", NewClockWithTicks(6, 2), "party", "threats"))
+	require.NoError(r.AddClock("villain.plan", NewClockWithTicks(8, 3), "threats"))
+	require.NoError(r.AddIncrementer("weather", &inc))
+	require.NoError(r.AddUIncrementer("party.gold", &u, "party"))
+	require.NoError(r.AddClampedIncrementer("reputation", &c))
+
+	return r
+}
+
+func TestRegistryAdd(t *testing.T) {
+	require := require.New(t)
+	r := newTestRegistry(t)
+	require.Equal(6, r.Len())
+
+	t.Run("duplicate", func(t *testing.T) {
+		err := r.AddCounter("party.xp", NewCounter())
+		require.Error(err, "Registry.AddCounter() did not return an error")
+		require.Equal("invalid Registry: party.xp is already registered", err.Error())
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		err := r.AddClock("", NewClock(4))
+		require.Error(err, "Registry.AddClock() did not return an error")
+		require.Equal("invalid Registry: name must not be empty", err.Error())
+	})
+
+	t.Run("registered type", func(t *testing.T) {
+		p := NewPool(20)
+		require.NoError(r.Add("party.hp", TypePool, &p, "party"))
+		typ, _ := r.Type("party.hp")
+		require.Equal(TypePool, typ)
+		r.Remove("party.hp")
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		p := NewPool(20)
+		err := r.Add("party.hp", "hp", &p)
+		require.Error(err, "Registry.Add() did not return an error")
+		require.Equal("invalid Registry: unknown type hp", err.Error())
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		w := NewWrappingIncrementerWithValue(1, 7, 3)
+		err := r.AddClock("day", &w)
+		require.Error(err, "Registry.AddClock() did not return an error")
+		require.Equal("invalid Registry: entry day: invalid Typed: *incrementers.WrappingIncrementer does not decode as clock: invalid Clock: min must be 0", err.Error())
+
+		w = NewWrappingIncrementerWithValue(0, 7, 3)
+		err = r.AddClock("day", &w)
+		require.Error(err, "Registry.AddClock() did not return an error")
+		require.Equal("invalid Registry: entry day: invalid Typed: *incrementers.WrappingIncrementer is not a clock", err.Error())
+		require.False(r.Has("day"))
+	})
+
+	t.Run("value type", func(t *testing.T) {
+		err := r.Add("doom", TypeClock, NewClampedIncrementerWithValue(0, 6, 1))
+		require.Error(err, "Registry.Add() did not return an error")
+		require.Equal(
+			"invalid Registry: entry doom: incrementers.ClampedIncrementer must be a *incrementers.ClampedIncrementer",
+			err.Error(),
+		)
+		require.False(r.Has("doom"))
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		err := r.Add("day", TypeWrappingIncrementer, nil)
+		require.Error(err, "Registry.Add() did not return an error")
+		require.Equal("invalid Registry: entry day: value must not be nil", err.Error())
+	})
+
+	t.Run("zero value registry", func(t *testing.T) {
+		var z Registry
+		require.NoError(z.AddCounter("xp", NewCounter()))
+		require.True(z.Has("xp"))
+	})
+}
+
+func TestRegistryGet(t *testing.T) {
+	require := require.New(t)
+	r := newTestRegistry(t)
+
+	t.Run("get", func(t *testing.T) {
+		v, ok := r.Get("weather")
+		require.True(ok)
+		require.Equal(-3, v.(*Incrementer).Value())
+
+		_, ok = r.Get("missing")
+		require.False(ok)
+	})
+
+	t.Run("type", func(t *testing.T) {
+		typ, ok := r.Type("party.doom")
+		require.True(ok)
+		require.Equal(TypeClock, typ)
+
+		_, ok = r.Type("missing")
+		require.False(ok)
+	})
+
+	t.Run("typed", func(t *testing.T) {
+		c, ok := r.Counter("party.xp")
+		require.True(ok)
+		require.Equal(100, c.Value())
+
+		clock, ok := r.Clock("party.doom")
+		require.True(ok)
+		require.Equal(2, clock.Value())
+
+		i, ok := r.Incrementer("weather")
+		require.True(ok)
+		require.Equal(-3, i.Value())
+
+		u, ok := r.UIncrementer("party.gold")
+		require.True(ok)
+		require.Equal(4, u.Value())
+
+		ci, ok := r.ClampedIncrementer("reputation")
+		require.True(ok)
+		require.Equal(1, ci.Value())
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		_, ok := r.Clock("party.xp")
+		require.False(ok)
+
+		_, ok = r.Counter("party.doom")
+		require.False(ok)
+
+		_, ok = r.Incrementer("missing")
+		require.False(ok)
+	})
+
+	t.Run("shared", func(t *testing.T) {
+		c, _ := r.Counter("party.xp")
+		c.Add(50)
+
+		again, _ := r.Counter("party.xp")
+		require.Equal(150, again.Value())
+	})
+}
+
+func TestRegistryRemove(t *testing.T) {
+	require := require.New(t)
+	r := newTestRegistry(t)
+
+	r.Remove("weather")
+	require.False(r.Has("weather"))
+	require.Equal(5, r.Len())
+
+	r.Remove("missing")
+	require.Equal(5, r.Len())
+}
+
+func TestRegistryLookup(t *testing.T) {
+	require := require.New(t)
+	r := newTestRegistry(t)
+
+	require.Equal(
+		[]string{"party.doom", "party.gold", "party.xp", "reputation", "villain.plan", "weather"},
+		r.Names(),
+	)
+	require.Equal([]string{"party.doom", "party.gold", "party.xp"}, r.WithPrefix("party."))
+	require.Equal([]string{"party.doom", "villain.plan"}, r.WithTag("threats"))
+	require.Empty(r.WithTag("missing"))
+	require.Empty(r.WithPrefix("npc."))
+}
+
+func TestRegistryTags(t *testing.T) {
+	require := require.New(t)
+	r := newTestRegistry(t)
+	require.Equal([]string{"party", "threats"}, r.Tags("party.doom"))
+	require.Nil(r.Tags("missing"))
+
+	t.Run("tag", func(t *testing.T) {
+		require.NoError(r.Tag("weather", "world", "", "world", "env"))
+		require.Equal([]string{"env", "world"}, r.Tags("weather"))
+	})
+
+	t.Run("untag", func(t *testing.T) {
+		require.NoError(r.Untag("weather", "env", "missing"))
+		require.Equal([]string{"world"}, r.Tags("weather"))
+	})
+
+	t.Run("unknown entry", func(t *testing.T) {
+		err := r.Tag("missing", "x")
+		require.Error(err, "Registry.Tag() did not return an error")
+		require.Equal("invalid Registry: unknown entry missing", err.Error())
+
+		err = r.Untag("missing", "x")
+		require.Error(err, "Registry.Untag() did not return an error")
+	})
+}
+
+func TestRegistryBulk(t *testing.T) {
+	require := require.New(t)
+	r := newTestRegistry(t)
+	doom, _ := r.Clock("party.doom")
+	plan, _ := r.Clock("villain.plan")
+	xp, _ := r.Counter("party.xp")
+	gold, _ := r.UIncrementer("party.gold")
+
+	t.Run("fill clocks", func(t *testing.T) {
+		r.FillClocks("party")
+		require.True(doom.IsFull())
+		require.Equal(3, plan.Value())
+
+		r.FillClocks("")
+		require.True(plan.IsFull())
+	})
+
+	t.Run("empty clocks", func(t *testing.T) {
+		r.EmptyClocks("threats")
+		require.Equal(0, doom.Value())
+		require.Equal(0, plan.Value())
+	})
+
+	t.Run("not a clock", func(t *testing.T) {
+		r := NewRegistry()
+		require.NoError(r.add("doom", TypeClock, NewClampedIncrementerWithValue(0, 6, 1), nil))
+		require.NotPanics(func() { r.FillClocks("") })
+		require.NotPanics(func() { r.EmptyClocks("") })
+	})
+
+	t.Run("reset tag", func(t *testing.T) {
+		xp.Add(10)
+		gold.Add(10)
+		r.ResetTag("party")
+		require.Equal(100, xp.Value())
+		require.Equal(4, gold.Value())
+		require.Equal(2, doom.Value())
+		require.Equal(0, plan.Value())
+	})
+
+	t.Run("reset all", func(t *testing.T) {
+		r.ResetAll()
+		require.Equal(3, plan.Value())
+	})
+}
+
+func TestRegistryApplyPatch(t *testing.T) {
+	require := require.New(t)
+	r := newTestRegistry(t)
+	alice := NewReplica("alice")
+	doom, _ := r.Clock("party.doom")
+	xp, _ := r.Counter("party.xp")
+
+	t.Run("apply", func(t *testing.T) {
+		require.NoError(r.ApplyDelta(alice.Add("party.doom", 1)))
+		require.Equal(3, doom.Value())
+
+		require.NoError(r.ApplyPatch(Patch{alice.Add("party.xp", 25), alice.SetMax("party.doom", 8)}))
+		require.Equal(125, xp.Value())
+		require.Equal(8, doom.Max())
+	})
+
+	t.Run("unknown entry", func(t *testing.T) {
+		err := r.ApplyPatch(Patch{alice.Add("party.doom", 1), alice.Add("missing", 1)})
+		require.Error(err, "Registry.ApplyPatch() did not return an error")
+		require.Equal("invalid Registry: unknown entry missing", err.Error())
+		require.Equal(3, doom.Value())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		err := r.ApplyDelta(alice.SetMax("weather", 4))
+		require.Error(err, "Registry.ApplyDelta() did not return an error")
+		require.Equal(
+			"invalid Registry: entry weather: invalid Delta: *incrementers.Incrementer does not support setMax",
+			err.Error(),
+		)
+	})
+}
+
+func TestRegistryMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	r := NewRegistry()
+	inc := NewIncrementerWithValue(-3)
+	require.NoError(r.AddClock("doom", NewClockWithTicks(6, 2), "threats"))
+	require.NoError(r.AddIncrementer("weather", &inc))
+
+	data, err := r.MarshalJSON()
+	require.NoError(err, "Registry.MarshalJSON() returned an error: %s", err)
+	require.Equal(
+		`{"entries":[`+
+			`{"name":"doom","type":"clock","tags":["threats"],"data":{"version":3,"min":0,"max":6,"incrementer":{"version":2,"inc":1,"val":2,"orig":2}}},`+
+			`{"name":"weather","type":"incrementer","data":{"version":2,"inc":1,"val":-3,"orig":-3}}]}`,
+		string(data),
+	)
+
+	data, err = NewRegistry().MarshalJSON()
+	require.NoError(err, "Registry.MarshalJSON() returned an error: %s", err)
+	require.Equal(`{"entries":[]}`, string(data))
+}
+
+func TestRegistryUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("round trip", func(t *testing.T) {
+		r := newTestRegistry(t)
+		data, err := r.MarshalJSON()
+		require.NoError(err, "Registry.MarshalJSON() returned an error: %s", err)
+
+		n, err := NewRegistryFromJSON(data)
+		require.NoError(err, "Registry.UnmarshalJSON() returned an error: %s", err)
+		require.Equal(r.Names(), n.Names())
+		require.Equal(r.Tags("party.doom"), n.Tags("party.doom"))
+
+		for _, name := range r.Names() {
+			a, _ := r.Type(name)
+			b, _ := n.Type(name)
+			require.Equal(a, b)
+		}
+
+		c, ok := n.ClampedIncrementer("reputation")
+		require.True(ok)
+		require.Equal(-5, c.Min())
+	})
+
+	t.Run("concrete type", func(t *testing.T) {
+		r := NewRegistry()
+		w := NewWrappingIncrementerWithValue(0, 7, 3)
+		require.NoError(r.Add("day", TypeWrappingIncrementer, &w))
+		data, err := r.MarshalJSON()
+		require.NoError(err, "Registry.MarshalJSON() returned an error: %s", err)
+
+		n, err := NewRegistryFromJSON(data)
+		require.NoError(err, "Registry.UnmarshalJSON() returned an error: %s", err)
+		v, ok := n.Get("day")
+		require.True(ok)
+		require.IsType(&WrappingIncrementer{}, v)
+
+		got := v.(*WrappingIncrementer)
+		got.Add(5)
+		require.Equal(0, got.Value())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		err := NewRegistry().UnmarshalJSON(nil)
+		require.Error(err, "Registry.UnmarshalJSON() did not return an error")
+		require.Equal("Registry.UnmarshalJSON(): data was nil", err.Error())
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := NewRegistryFromJSON([]byte(`{"entries":[{"name":"a","type":"dial","data":{}}]}`))
+		require.Error(err, "Registry.UnmarshalJSON() did not return an error")
+		require.Equal("invalid Registry: entry a: invalid Typed: unknown type dial", err.Error())
+	})
+
+	t.Run("invalid entry", func(t *testing.T) {
+		_, err := NewRegistryFromJSON([]byte(
+			`{"entries":[{"name":"a","type":"clock","data":{"min":0,"max":0,"incrementer":{"inc":1,"val":2,"orig":2}}}]}`,
+		))
+		require.Error(err, "Registry.UnmarshalJSON() did not return an error")
+		require.Equal("invalid Registry: entry a: invalid Clock: max must be greater than 0", err.Error())
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := NewRegistryFromJSON([]byte(
+			`{"entries":[{"name":"a","type":"incrementer","data":{"inc":1,"val":2,"orig":2}},` +
+				`{"name":"a","type":"incrementer","data":{"inc":1,"val":2,"orig":2}}]}`,
+		))
+		require.Error(err, "Registry.UnmarshalJSON() did not return an error")
+		require.Equal("invalid Registry: a is already registered", err.Error())
+	})
+}
@@ -0,0 +1,144 @@
+package incrementers
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeSource provides the current time to a Scheduler.
+type TimeSource interface {
+	Now() time.Time
+}
+
+// SystemTime is a TimeSource that reports the wall clock time.
+type SystemTime struct{}
+
+// Now returns the current wall clock time.
+func (SystemTime) Now() time.Time { return time.Now() }
+
+// ManualTime is a TimeSource that only moves when told to. Use it as an in-game calendar or as a
+// fake clock in tests.
+type ManualTime struct {
+	now time.Time
+}
+
+// NewManualTime creates a new ManualTime starting at start.
+func NewManualTime(start time.Time) *ManualTime { return &ManualTime{now: start} }
+
+// Now returns the current time of the ManualTime.
+func (m *ManualTime) Now() time.Time { return m.now }
+
+// Advance moves the ManualTime forward by d.
+func (m *ManualTime) Advance(d time.Duration) { m.now = m.now.Add(d) }
+
+// Set changes the ManualTime to t.
+func (m *ManualTime) Set(t time.Time) { m.now = t }
+
+// Advance records how many times a scheduled Clock was ticked by Scheduler.Update.
+type Advance struct {
+	Name  string
+	Ticks int
+}
+
+type schedule struct {
+	name  string
+	clock Clock
+	every time.Duration
+	last  time.Time // Time of the last tick, or registration time.
+}
+
+// Scheduler advances registered Clocks by 1 for every interval that passes on its TimeSource.
+type Scheduler struct {
+	source     TimeSource
+	schedules  []*schedule
+	maxCatchUp int
+}
+
+// NewScheduler creates a new Scheduler using source to tell the time. Catch-up is unlimited until
+// SetMaxCatchUp is called.
+func NewScheduler(source TimeSource) *Scheduler { return &Scheduler{source: source} }
+
+// MaxCatchUp returns the maximum number of ticks a single Update will apply to a Clock.
+func (s *Scheduler) MaxCatchUp() int { return s.maxCatchUp }
+
+// SetMaxCatchUp limits the number of ticks a single Update will apply to each Clock. Ticks beyond the
+// limit are dropped. 0 means no limit.
+func (s *Scheduler) SetMaxCatchUp(max int) { s.maxCatchUp = ClampMin(max, 0) }
+
+// Register adds a Clock to the Scheduler which will be ticked once every interval, starting from now.
+func (s *Scheduler) Register(name string, c Clock, every time.Duration) error {
+	if every <= 0 {
+		return fmt.Errorf("invalid schedule: interval must be greater than 0")
+	}
+
+	if s.find(name) != -1 {
+		return fmt.Errorf("invalid schedule: %s is already registered", name)
+	}
+
+	s.schedules = append(s.schedules, &schedule{name: name, clock: c, every: every, last: s.source.Now()})
+	return nil
+}
+
+// Unregister removes the named Clock from the Scheduler.
+func (s *Scheduler) Unregister(name string) {
+	if i := s.find(name); i != -1 {
+		s.schedules = append(s.schedules[:i], s.schedules[i+1:]...)
+	}
+}
+
+// Names returns the names of the registered Clocks in registration order.
+func (s *Scheduler) Names() []string {
+	names := make([]string, len(s.schedules))
+	for i, sc := range s.schedules {
+		names[i] = sc.name
+	}
+
+	return names
+}
+
+// Update ticks every registered Clock once for each full interval that has passed since its last tick.
+// Clocks are updated in registration order. Partial intervals carry over to the next Update so gaps
+// between calls never lose or double count ticks. If the time source moved backwards the Clock is
+// re-anchored to the current time without ticking. The ticks are applied to a Clock with a single Add
+// of the ticks times its increment, so a long gap costs no more than a short one.
+func (s *Scheduler) Update() []Advance {
+	var advances []Advance
+	now := s.source.Now()
+
+	for _, sc := range s.schedules {
+		if now.Before(sc.last) {
+			sc.last = now
+			continue
+		}
+
+		ticks := int(now.Sub(sc.last) / sc.every)
+		if ticks == 0 {
+			continue
+		}
+
+		sc.last = sc.last.Add(time.Duration(ticks) * sc.every)
+		if s.maxCatchUp > 0 && ticks > s.maxCatchUp {
+			ticks = s.maxCatchUp
+		}
+
+		inc := 1
+		if i, ok := sc.clock.(interface{ Inc() int }); ok {
+			inc = i.Inc()
+		}
+
+		sc.clock.Add(MulSaturating(ticks, inc))
+		advances = append(advances, Advance{Name: sc.name, Ticks: ticks})
+	}
+
+	return advances
+}
+
+func (s *Scheduler) find(name string) int {
+	for i, sc := range s.schedules {
+		if sc.name == name {
+			return i
+		}
+	}
+
+	return -1
+}
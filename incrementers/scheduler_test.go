@@ -0,0 +1,141 @@
+package incrementers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var schedulerStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestSchedulerManualTime(t *testing.T) {
+	require := require.New(t)
+	m := NewManualTime(schedulerStart)
+	require.Equal(schedulerStart, m.Now())
+
+	m.Advance(time.Hour)
+	require.Equal(schedulerStart.Add(time.Hour), m.Now())
+
+	m.Set(schedulerStart)
+	require.Equal(schedulerStart, m.Now())
+}
+
+func TestSchedulerRegister(t *testing.T) {
+	require := require.New(t)
+	s := NewScheduler(NewManualTime(schedulerStart))
+
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(s.Register("project", NewClock(4), time.Hour))
+		require.Equal([]string{"project"}, s.Names())
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		err := s.Register("project", NewClock(4), time.Hour)
+		require.Error(err, "Scheduler.Register() did not return an error")
+		require.Equal("invalid schedule: project is already registered", err.Error())
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		err := s.Register("downtime", NewClock(4), 0)
+		require.Error(err, "Scheduler.Register() did not return an error")
+		require.Equal("invalid schedule: interval must be greater than 0", err.Error())
+	})
+}
+
+func TestSchedulerUnregister(t *testing.T) {
+	require := require.New(t)
+	s := NewScheduler(NewManualTime(schedulerStart))
+	require.NoError(s.Register("a", NewClock(4), time.Hour))
+	require.NoError(s.Register("b", NewClock(4), time.Hour))
+
+	s.Unregister("a")
+	require.Equal([]string{"b"}, s.Names())
+
+	s.Unregister("missing")
+	require.Equal([]string{"b"}, s.Names())
+}
+
+func TestSchedulerUpdate(t *testing.T) {
+	require := require.New(t)
+	m := NewManualTime(schedulerStart)
+	s := NewScheduler(m)
+	daily := NewClock(8)
+	hourly := NewClock(8)
+	require.NoError(s.Register("daily", daily, 24*time.Hour))
+	require.NoError(s.Register("hourly", hourly, time.Hour))
+
+	t.Run("no time passed", func(t *testing.T) {
+		require.Empty(s.Update())
+		require.Equal(0, daily.Value())
+		require.Equal(0, hourly.Value())
+	})
+
+	t.Run("partial interval", func(t *testing.T) {
+		m.Advance(90 * time.Minute)
+		require.Equal([]Advance{{Name: "hourly", Ticks: 1}}, s.Update())
+		require.Equal(0, daily.Value())
+		require.Equal(1, hourly.Value())
+	})
+
+	t.Run("remainder carries over", func(t *testing.T) {
+		m.Advance(30 * time.Minute)
+		require.Equal([]Advance{{Name: "hourly", Ticks: 1}}, s.Update())
+		require.Equal(2, hourly.Value())
+	})
+
+	t.Run("catch up", func(t *testing.T) {
+		m.Advance(22 * time.Hour)
+		require.Equal([]Advance{{Name: "daily", Ticks: 1}, {Name: "hourly", Ticks: 22}}, s.Update())
+		require.Equal(1, daily.Value())
+		require.True(hourly.IsFull())
+	})
+
+	t.Run("backwards", func(t *testing.T) {
+		m.Set(schedulerStart)
+		require.Empty(s.Update())
+		m.Advance(time.Hour)
+		require.Equal([]Advance{{Name: "hourly", Ticks: 1}}, s.Update())
+		require.Equal(1, daily.Value())
+	})
+}
+
+func TestSchedulerMaxCatchUp(t *testing.T) {
+	require := require.New(t)
+	m := NewManualTime(schedulerStart)
+	s := NewScheduler(m)
+	c := NewClock(8)
+	require.NoError(s.Register("clock", c, time.Hour))
+
+	s.SetMaxCatchUp(-1)
+	require.Equal(0, s.MaxCatchUp())
+
+	s.SetMaxCatchUp(2)
+	require.Equal(2, s.MaxCatchUp())
+
+	m.Advance(5*time.Hour + 30*time.Minute)
+	require.Equal([]Advance{{Name: "clock", Ticks: 2}}, s.Update())
+	require.Equal(2, c.Value())
+
+	// Dropped ticks are not applied later, but the partial interval still carries over.
+	m.Advance(30 * time.Minute)
+	require.Equal([]Advance{{Name: "clock", Ticks: 1}}, s.Update())
+	require.Equal(3, c.Value())
+}
+
+func TestSchedulerLongGap(t *testing.T) {
+	require := require.New(t)
+	m := NewManualTime(schedulerStart)
+	s := NewScheduler(m)
+	c := NewClock(8)
+	w := NewWrappingIncrementer(0, 9)
+	w.SetIncrementer(3)
+	require.NoError(s.Register("clock", c, time.Nanosecond))
+	require.NoError(s.Register("wrap", &w, time.Nanosecond))
+
+	m.Advance(1e12)
+	require.Equal([]Advance{{Name: "clock", Ticks: 1e12}, {Name: "wrap", Ticks: 1e12}}, s.Update())
+	require.True(c.IsFull())
+	require.Equal(300_000_000_000, w.Wraps())
+	require.Equal(0, w.Value())
+}
@@ -0,0 +1,220 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// Every JSON representation written by this package includes a version field. Payloads written before
+// versioning was added have no version field and are treated as version 1. UIncrementer, Counter and
+// Clock share the schemas of Incrementer and ClampedIncrementer.
+const (
+	incrementerVersion        = 2
+	clampedIncrementerVersion = 3
+	wrappingVersion           = 2
+	cascadingVersion          = 2
+	poolVersion               = 2
+	tickingVersion            = 2
+	modifierStackVersion      = 2
+	gCounterVersion           = 1
+	pnCounterVersion          = 1
+	boundedCounterVersion     = 1
+	snappedVersion            = 1
+	sequenceVersion           = 1
+)
+
+// Migration upgrades the fields of a JSON object by one version. The version field is updated after
+// the Migration returns.
+type Migration func(fields map[string]json.RawMessage) error
+
+var (
+	schemasMu sync.RWMutex
+	schemas   = make(map[string][]Migration)
+)
+
+// nested names the fields of each kind which hold another kind, so Migrate upgrades them too.
+var nested = map[string]map[string]string{
+	TypeClampedIncrementer:  {"incrementer": TypeIncrementer},
+	TypeWrappingIncrementer: {"incrementer": TypeIncrementer},
+	TypePool: {
+		"max": TypeIncrementer, "reduction": TypeIncrementer, "current": TypeIncrementer,
+		"temporary": TypeIncrementer,
+	},
+	TypeTickingIncrementer: {"clamped": TypeClampedIncrementer},
+	TypeModifierStack:      {"base": TypeClampedIncrementer},
+	TypeSnappedIncrementer: {"clamped": TypeClampedIncrementer},
+}
+
+func init() {
+	// Version 2 added the version field.
+	for _, kind := range []string{
+		TypeIncrementer, TypeWrappingIncrementer, TypeCascadingCounter, TypePool, TypeTickingIncrementer,
+		TypeModifierStack,
+	} {
+		if err := RegisterSchema(kind, addVersion); err != nil {
+			panic(err)
+		}
+	}
+
+	// Version 3 made min and max optional.
+	if err := RegisterSchema(TypeClampedIncrementer, addVersion, unboundedZeroMax); err != nil {
+		panic(err)
+	}
+
+	for _, kind := range []string{TypeGCounter, TypePNCounter, TypeBoundedCounter, TypeSnappedIncrementer,
+		TypeSequenceIncrementer,
+	} {
+		if err := RegisterSchema(kind); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// addVersion is the version 1 to 2 migration for every kind in this package. Only the version field
+// changed.
+func addVersion(map[string]json.RawMessage) error { return nil }
+
+// unboundedZeroMax is the ClampedIncrementer version 2 to 3 migration. A max of 0 meant no maximum
+// and is now null.
+func unboundedZeroMax(fields map[string]json.RawMessage) error {
+	raw, ok := fields["max"]
+	if !ok {
+		return nil
+	}
+
+	var max int
+	if err := json.Unmarshal(raw, &max); err != nil {
+		return fmt.Errorf("max: %w", err)
+	}
+
+	if max == 0 {
+		fields["max"] = json.RawMessage("null")
+	}
+
+	return nil
+}
+
+// RegisterSchema adds the migrations for a kind of JSON representation. migrations[0] upgrades
+// version 1 to 2, migrations[1] upgrades version 2 to 3, and so on, so the latest version of the kind
+// is len(migrations) + 1. Kinds must be unique.
+func RegisterSchema(kind string, migrations ...Migration) error {
+	if kind == "" {
+		return fmt.Errorf("invalid schema: kind must not be empty")
+	}
+
+	for i, m := range migrations {
+		if m == nil {
+			return fmt.Errorf("invalid schema: %s migration from version %d must not be nil", kind, i+1)
+		}
+	}
+
+	schemasMu.Lock()
+	defer schemasMu.Unlock()
+
+	if _, ok := schemas[kind]; ok {
+		return fmt.Errorf("invalid schema: %s is already registered", kind)
+	}
+
+	schemas[kind] = append([]Migration(nil), migrations...)
+	return nil
+}
+
+// SchemaVersion returns the latest version of kind, or 0 if kind has not been registered.
+func SchemaVersion(kind string) int {
+	schemasMu.RLock()
+	defer schemasMu.RUnlock()
+
+	migrations, ok := schemas[kind]
+	if !ok {
+		return 0
+	}
+
+	return len(migrations) + 1
+}
+
+// Migrate upgrades a JSON representation of kind to its latest version, along with the incrementers
+// nested in the kinds of this package, such as the incrementer of a ClampedIncrementer. Typed values,
+// such as the entries of a Registry, are upgraded when they are loaded.
+func Migrate(kind string, data []byte) ([]byte, error) {
+	fields, err := migrate(kind, data)
+	if err != nil {
+		return nil, err
+	}
+
+	for field, k := range nested[kind] {
+		raw, ok := fields[field]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+
+		if fields[field], err = Migrate(k, raw); err != nil {
+			return nil, fmt.Errorf("invalid schema: %s %s: %w", kind, field, err)
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+func migrate(kind string, data []byte) (map[string]json.RawMessage, error) {
+	schemasMu.RLock()
+	migrations, ok := schemas[kind]
+	schemasMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("invalid schema: unknown kind %s", kind)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	if fields == nil {
+		return nil, fmt.Errorf("invalid schema: %s must be a JSON object", kind)
+	}
+
+	version := 1
+	if raw, ok := fields["version"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, fmt.Errorf("invalid schema: %s version: %w", kind, err)
+		}
+	}
+
+	latest := len(migrations) + 1
+	if version < 1 || version > latest {
+		return nil, fmt.Errorf("invalid schema: %s version %d is not supported", kind, version)
+	}
+
+	for ; version < latest; version++ {
+		if err := migrations[version-1](fields); err != nil {
+			return nil, fmt.Errorf("invalid schema: %s version %d: %w", kind, version, err)
+		}
+	}
+
+	fields["version"] = json.RawMessage(strconv.Itoa(latest))
+	return fields, nil
+}
+
+// unmarshalVersioned upgrades a JSON representation of kind to its latest version and decodes it into
+// v. typ names the decoded type in errors for missing required fields.
+func unmarshalVersioned(kind, typ string, data []byte, v any, required ...string) error {
+	fields, err := migrate(kind, data)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range required {
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("invalid %s: %s is required", typ, name)
+		}
+	}
+
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
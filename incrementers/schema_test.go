@@ -0,0 +1,215 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterSchema(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("builtin", func(t *testing.T) {
+		require.Equal(incrementerVersion, SchemaVersion(TypeIncrementer))
+		require.Equal(clampedIncrementerVersion, SchemaVersion(TypeClampedIncrementer))
+		require.Equal(wrappingVersion, SchemaVersion(TypeWrappingIncrementer))
+		require.Equal(cascadingVersion, SchemaVersion(TypeCascadingCounter))
+		require.Equal(poolVersion, SchemaVersion(TypePool))
+		require.Equal(tickingVersion, SchemaVersion(TypeTickingIncrementer))
+		require.Equal(modifierStackVersion, SchemaVersion(TypeModifierStack))
+		require.Equal(gCounterVersion, SchemaVersion(TypeGCounter))
+		require.Equal(pnCounterVersion, SchemaVersion(TypePNCounter))
+		require.Equal(boundedCounterVersion, SchemaVersion(TypeBoundedCounter))
+		require.Equal(snappedVersion, SchemaVersion(TypeSnappedIncrementer))
+		require.Equal(sequenceVersion, SchemaVersion(TypeSequenceIncrementer))
+		require.Equal(0, SchemaVersion("missing"))
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		err := RegisterSchema(TypeIncrementer)
+		require.Error(err, "RegisterSchema() did not return an error")
+		require.Equal("invalid schema: incrementer is already registered", err.Error())
+	})
+
+	t.Run("empty kind", func(t *testing.T) {
+		err := RegisterSchema("")
+		require.Error(err, "RegisterSchema() did not return an error")
+		require.Equal("invalid schema: kind must not be empty", err.Error())
+	})
+
+	t.Run("nil migration", func(t *testing.T) {
+		err := RegisterSchema("test.nil", addVersion, nil)
+		require.Error(err, "RegisterSchema() did not return an error")
+		require.Equal("invalid schema: test.nil migration from version 2 must not be nil", err.Error())
+		require.Equal(0, SchemaVersion("test.nil"))
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("unversioned incrementer", func(t *testing.T) {
+		data, err := Migrate(TypeIncrementer, []byte(`{"inc":1,"val":2,"orig":3}`))
+		require.NoError(err, "Migrate() returned an error: %s", err)
+		require.JSONEq(`{"version":2,"inc":1,"val":2,"orig":3}`, string(data))
+	})
+
+	t.Run("unversioned clamped", func(t *testing.T) {
+		data, err := Migrate(TypeClampedIncrementer, []byte(`{"min":0,"max":4,"incrementer":{"inc":1,"val":2,"orig":3}}`))
+		require.NoError(err, "Migrate() returned an error: %s", err)
+		require.JSONEq(`{"version":3,"min":0,"max":4,"incrementer":{"version":2,"inc":1,"val":2,"orig":3}}`, string(data))
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		data, err := Migrate(TypeTickingIncrementer, []byte(
+			`{"rate":1,"clamped":{"min":0,"max":4,"incrementer":{"inc":1,"val":2,"orig":3}}}`,
+		))
+		require.NoError(err, "Migrate() returned an error: %s", err)
+		require.JSONEq(
+			`{"version":2,"rate":1,"clamped":{"version":3,"min":0,"max":4,"incrementer":{"version":2,"inc":1,"val":2,"orig":3}}}`,
+			string(data),
+		)
+
+		data, err = Migrate(TypePool, []byte(`{"max":{"inc":1,"val":10,"orig":10},"temporary":null}`))
+		require.NoError(err, "Migrate() returned an error: %s", err)
+		require.JSONEq(`{"version":2,"max":{"version":2,"inc":1,"val":10,"orig":10},"temporary":null}`, string(data))
+
+		_, err = Migrate(TypeModifierStack, []byte(`{"base":{"version":3,"incrementer":{"version":3}}}`))
+		require.Error(err, "Migrate() did not return an error")
+		require.Equal(
+			"invalid schema: modifiers base: invalid schema: clamped incrementer: invalid schema: incrementer version 3 is not supported",
+			err.Error(),
+		)
+	})
+
+	t.Run("zero max", func(t *testing.T) {
+		data, err := Migrate(TypeClampedIncrementer, []byte(`{"version":2,"min":-4,"max":0,"incrementer":{}}`))
+		require.NoError(err, "Migrate() returned an error: %s", err)
+		require.JSONEq(`{"version":3,"min":-4,"max":null,"incrementer":{"version":2}}`, string(data))
+
+		_, err = Migrate(TypeClampedIncrementer, []byte(`{"version":2,"min":-4,"max":"a","incrementer":{}}`))
+		require.Error(err, "Migrate() did not return an error")
+		require.Equal(
+			"invalid schema: clamped version 2: max: json: cannot unmarshal string into Go value of type int",
+			err.Error(),
+		)
+	})
+
+	t.Run("zero max range", func(t *testing.T) {
+		c, err := NewClampedIncrementerFromJSON(
+			[]byte(`{"version":3,"min":-10,"max":0,"incrementer":{"version":2,"inc":1,"val":-2,"orig":-2}}`),
+		)
+		require.NoError(err, "NewClampedIncrementerFromJSON() returned an error: %s", err)
+		c.Add(5)
+		require.Equal(0, c.Value())
+		require.True(c.IsFull())
+	})
+
+	t.Run("latest", func(t *testing.T) {
+		data, err := Migrate(TypeIncrementer, []byte(`{"version":2,"inc":1,"val":2,"orig":3}`))
+		require.NoError(err, "Migrate() returned an error: %s", err)
+		require.JSONEq(`{"version":2,"inc":1,"val":2,"orig":3}`, string(data))
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		var seen []int
+		rename := func(fields map[string]json.RawMessage) error {
+			seen = append(seen, 1)
+			fields["val"] = fields["value"]
+			delete(fields, "value")
+			return nil
+		}
+		double := func(fields map[string]json.RawMessage) error {
+			seen = append(seen, 2)
+			var v int
+			if err := json.Unmarshal(fields["val"], &v); err != nil {
+				return err
+			}
+
+			fields["val"], _ = json.Marshal(v * 2)
+			return nil
+		}
+		require.NoError(RegisterSchema("test.chain", rename, double))
+		require.Equal(3, SchemaVersion("test.chain"))
+
+		data, err := Migrate("test.chain", []byte(`{"value":4}`))
+		require.NoError(err, "Migrate() returned an error: %s", err)
+		require.JSONEq(`{"version":3,"val":8}`, string(data))
+		require.Equal([]int{1, 2}, seen)
+
+		seen = nil
+		data, err = Migrate("test.chain", []byte(`{"version":2,"val":4}`))
+		require.NoError(err, "Migrate() returned an error: %s", err)
+		require.JSONEq(`{"version":3,"val":8}`, string(data))
+		require.Equal([]int{2}, seen)
+
+		_, err = Migrate("test.chain", []byte(`{"version":2,"val":"four"}`))
+		require.Error(err, "Migrate() did not return an error")
+		require.Contains(err.Error(), "invalid schema: test.chain version 2: ")
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		_, err := Migrate("dial", []byte(`{}`))
+		require.Error(err, "Migrate() did not return an error")
+		require.Equal("invalid schema: unknown kind dial", err.Error())
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := Migrate(TypeIncrementer, []byte(`{"version":3,"inc":1,"val":2,"orig":3}`))
+		require.Error(err, "Migrate() did not return an error")
+		require.Equal("invalid schema: incrementer version 3 is not supported", err.Error())
+
+		_, err = Migrate(TypeIncrementer, []byte(`{"version":0,"inc":1,"val":2,"orig":3}`))
+		require.Error(err, "Migrate() did not return an error")
+		require.Equal("invalid schema: incrementer version 0 is not supported", err.Error())
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		_, err := Migrate(TypeIncrementer, []byte(`{"version":"two"}`))
+		require.Error(err, "Migrate() did not return an error")
+		require.Contains(err.Error(), "invalid schema: incrementer version: ")
+	})
+
+	t.Run("not an object", func(t *testing.T) {
+		_, err := Migrate(TypeIncrementer, []byte(`null`))
+		require.Error(err, "Migrate() did not return an error")
+		require.Equal("invalid schema: incrementer must be a JSON object", err.Error())
+
+		_, err = Migrate(TypeIncrementer, []byte(`[1,2,3]`))
+		require.Error(err, "Migrate() did not return an error")
+	})
+}
+
+func TestUnmarshalVersioned(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("any key order", func(t *testing.T) {
+		c, err := NewClampedIncrementerFromJSON(
+			[]byte(`{"incrementer":{"orig":3,"val":2,"inc":1},"max":4,"min":0,"version":2}`),
+		)
+		require.NoError(err, "NewClampedIncrementerFromJSON() returned an error: %s", err)
+		require.Equal(ClampedIncrementer{min: BoundAt(0), max: BoundAt(4), Incrementer: Incrementer{inc: 1, val: 2, orig: 3}}, c)
+	})
+
+	t.Run("mixed versions", func(t *testing.T) {
+		p, err := NewPoolFromJSON([]byte(
+			`{"max":{"inc":1,"val":20,"orig":20},"reduction":{"version":2,"inc":1,"val":0,"orig":0},` +
+				`"current":{"inc":1,"val":15,"orig":20},"temporary":{"inc":1,"val":0,"orig":0}}`,
+		))
+		require.NoError(err, "NewPoolFromJSON() returned an error: %s", err)
+		require.Equal("15/20", p.String())
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		_, err := NewClampedIncrementerFromJSON([]byte(`{"version":2,"min":0,"max":4}`))
+		require.Error(err, "NewClampedIncrementerFromJSON() did not return an error")
+		require.Equal("invalid ClampedIncrementer: incrementer is required", err.Error())
+	})
+
+	t.Run("newer version", func(t *testing.T) {
+		_, err := NewCounterFromJSON([]byte(`{"version":9,"min":0,"max":0,"incrementer":{"inc":1,"val":2,"orig":3}}`))
+		require.Error(err, "NewCounterFromJSON() did not return an error")
+		require.Equal("invalid schema: clamped version 9 is not supported", err.Error())
+	})
+}
@@ -0,0 +1,377 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// SequenceKind is how a Sequence produces its steps.
+type SequenceKind int
+
+const (
+	Geometric SequenceKind = iota // Steps of Start times Ratio to the power of the step number.
+	Fibonacci                     // Steps of Start times the Fibonacci numbers 1, 1, 2, 3, 5...
+	Table                         // The Steps listed, ending after the last one.
+)
+
+var sequenceKinds = []string{"geometric", "fibonacci", "table"}
+
+// String returns the name of the SequenceKind.
+func (k SequenceKind) String() string {
+	if k < 0 || int(k) >= len(sequenceKinds) {
+		return fmt.Sprintf("SequenceKind(%d)", int(k))
+	}
+
+	return sequenceKinds[k]
+}
+
+// MarshalText returns the name of the SequenceKind.
+func (k SequenceKind) MarshalText() ([]byte, error) {
+	if k < 0 || int(k) >= len(sequenceKinds) {
+		return nil, fmt.Errorf("invalid SequenceKind: %d", int(k))
+	}
+
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText parses the name of a SequenceKind.
+func (k *SequenceKind) UnmarshalText(data []byte) error {
+	for i, name := range sequenceKinds {
+		if name == string(data) {
+			*k = SequenceKind(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid SequenceKind: %s", data)
+}
+
+// Sequence is the list of steps a SequenceIncrementer moves by. Steps saturate at math.MaxInt and
+// math.MinInt.
+type Sequence struct {
+	Kind  SequenceKind `json:"kind"`
+	Start int          `json:"start,omitempty"` // First step of a Geometric or Fibonacci sequence.
+	Ratio int          `json:"ratio,omitempty"` // Multiplier of a Geometric sequence.
+	Steps []int        `json:"steps,omitempty"` // Steps of a Table.
+}
+
+// NewGeometricSequence creates a Sequence of start, start*ratio, start*ratio*ratio...
+//
+//	tension := NewGeometricSequence(1, 2) // 1, 2, 4, 8...
+func NewGeometricSequence(start, ratio int) Sequence {
+	return Sequence{Kind: Geometric, Start: start, Ratio: ratio}
+}
+
+// NewFibonacciSequence creates a Sequence of start times the Fibonacci numbers 1, 1, 2, 3, 5...
+func NewFibonacciSequence(start int) Sequence { return Sequence{Kind: Fibonacci, Start: start} }
+
+// NewTableSequence creates a Sequence of the given steps which ends after the last step.
+//
+//	escalation := NewTableSequence(2, 2, 2, 2) // d4 to d12
+func NewTableSequence(steps ...int) Sequence {
+	return Sequence{Kind: Table, Steps: append([]int(nil), steps...)}
+}
+
+// Len returns the number of steps in a Table, or -1 if the Sequence never ends.
+func (s Sequence) Len() int {
+	if s.Kind == Table {
+		return len(s.Steps)
+	}
+
+	return -1
+}
+
+// Step returns step n of the Sequence counting from 0, or 0 if n is outside the Sequence.
+func (s Sequence) Step(n int) int {
+	if n < 0 {
+		return 0
+	}
+
+	switch s.Kind {
+	case Geometric:
+		// Start * Ratio^n by squaring, so a large n does not take n steps.
+		pow, base := 1, s.Ratio
+		var err error
+		for ; n > 0 && err == nil; n >>= 1 {
+			if n&1 == 1 {
+				pow, err = MulChecked(pow, base)
+			}
+
+			if err == nil && n > 1 {
+				base, err = MulChecked(base, base)
+			}
+		}
+
+		if err == nil {
+			return MulSaturating(s.Start, pow)
+		}
+
+		if s.Start < 0 {
+			return math.MinInt
+		}
+
+		return math.MaxInt
+	case Fibonacci:
+		a, b := s.Start, s.Start
+		for i := 0; i < n && !saturated(a); i++ {
+			a, b = b, AddSaturating(a, b)
+		}
+
+		return a
+	case Table:
+		if n < len(s.Steps) {
+			return s.Steps[n]
+		}
+	}
+
+	return 0
+}
+
+// Sum returns the total of the first n steps of the Sequence.
+func (s Sequence) Sum(n int) int {
+	if l := s.Len(); l >= 0 && n > l {
+		n = l
+	}
+
+	if s.Kind == Geometric && s.Ratio == 1 {
+		return MulSaturating(s.Start, ClampMin(n, 0))
+	}
+
+	total := 0
+	for i := 0; i < n && !saturated(total); i++ {
+		total = AddSaturating(total, s.Step(i))
+	}
+
+	return total
+}
+
+// saturated returns true if v is stuck at math.MaxInt or math.MinInt.
+func saturated(v int) bool { return v == math.MaxInt || v == math.MinInt }
+
+func (s Sequence) validate() error {
+	switch s.Kind {
+	case Geometric:
+		if s.Start == 0 || s.Ratio < 1 {
+			return fmt.Errorf("invalid Sequence: geometric start must not be 0 and ratio must be 1 or greater")
+		}
+	case Fibonacci:
+		if s.Start == 0 {
+			return fmt.Errorf("invalid Sequence: fibonacci start must not be 0")
+		}
+	case Table:
+		if len(s.Steps) == 0 {
+			return fmt.Errorf("invalid Sequence: table must have at least 1 step")
+		}
+	default:
+		return fmt.Errorf("invalid Sequence: %s is not a sequence kind", s.Kind)
+	}
+
+	return nil
+}
+
+// SequenceIncrementer is an incrementer which moves by the steps of a Sequence instead of a constant
+// increment, such as an XP track, a tension pool or an escalation die. Its value is the start value
+// plus the steps taken so far, so Decrement retraces the Sequence exactly. Increment does nothing at
+// the end of a Table and Decrement does nothing at the start.
+type SequenceIncrementer struct {
+	seq   Sequence
+	start int // Value before the first step.
+	pos   int // Number of steps taken.
+	orig  int // Original number of steps taken.
+}
+
+// NewSequenceIncrementer creates a new SequenceIncrementer which starts at start and moves by the
+// steps of seq.
+//
+//	die, err := NewSequenceIncrementer(NewTableSequence(2, 2, 2, 2), 4)
+func NewSequenceIncrementer(seq Sequence, start int) (SequenceIncrementer, error) {
+	var s SequenceIncrementer
+	err := s.set(seq, start, 0, 0)
+	return s, err
+}
+
+// NewSequenceIncrementerFromJSON creates a new SequenceIncrementer from a JSON representation.
+func NewSequenceIncrementerFromJSON(data []byte) (SequenceIncrementer, error) {
+	var s SequenceIncrementer
+	err := s.UnmarshalJSON(data)
+	return s, err
+}
+
+// Sequence returns the Sequence the incrementer moves by.
+func (s SequenceIncrementer) Sequence() Sequence {
+	seq := s.seq
+	seq.Steps = append([]int(nil), seq.Steps...)
+	return seq
+}
+
+// Start returns the value before the first step.
+func (s SequenceIncrementer) Start() int { return s.start }
+
+// Position returns the number of steps taken.
+func (s SequenceIncrementer) Position() int { return s.pos }
+
+// Value returns the start value plus the steps taken.
+func (s SequenceIncrementer) Value() int { return s.valueAt(s.pos) }
+
+// Original returns the value at the original position.
+func (s SequenceIncrementer) Original() int { return s.valueAt(s.orig) }
+
+// Next returns the amount the next Increment adds, or 0 at the end of a Table.
+func (s SequenceIncrementer) Next() int { return s.seq.Step(s.pos) }
+
+// IsFull returns true at the end of a Table. A Geometric or Fibonacci sequence is never full.
+func (s SequenceIncrementer) IsFull() bool { return s.pos == s.seq.Len() }
+
+// IsEmpty returns true if no steps have been taken.
+func (s SequenceIncrementer) IsEmpty() bool { return s.pos == 0 }
+
+// Increment moves to the next step of the Sequence.
+func (s *SequenceIncrementer) Increment() { s.SetPosition(AddSaturating(s.pos, 1)) }
+
+// Decrement moves back to the previous step of the Sequence.
+func (s *SequenceIncrementer) Decrement() { s.SetPosition(s.pos - 1) }
+
+// SetPosition sets the number of steps taken, clamped to the start and the end of a Table.
+func (s *SequenceIncrementer) SetPosition(pos int) { s.pos = s.clamp(pos) }
+
+// SetOriginalPosition sets the original number of steps taken, clamped like SetPosition.
+func (s *SequenceIncrementer) SetOriginalPosition(pos int) { s.orig = s.clamp(pos) }
+
+// Empty moves back to the start of the Sequence.
+func (s *SequenceIncrementer) Empty() { s.pos = 0 }
+
+// Reset moves to the original position.
+func (s *SequenceIncrementer) Reset() { s.pos = s.orig }
+
+// String returns the value of the SequenceIncrementer.
+func (s SequenceIncrementer) String() string { return fmt.Sprintf("%d", s.Value()) }
+
+func (s SequenceIncrementer) view() formatView {
+	val := s.Value()
+	v := formatView{name: "SequenceIncrementer", val: val, min: BoundAt(s.start), str: s.String(),
+		fields: []formatField{
+			{"val", val}, {"pos", s.pos}, {"next", s.Next()}, {"seq", s.seq.Kind}, {"orig", s.Original()},
+		}}
+	if s.seq.Len() >= 0 {
+		v.max = BoundAt(s.valueAt(s.seq.Len()))
+	}
+
+	return v
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (s SequenceIncrementer) Format(f fmt.State, verb rune) { s.view().format(f, verb) }
+
+type sequenceIncrementerJSON struct {
+	Version  int      `json:"version"`
+	Sequence Sequence `json:"sequence"`
+	Start    int      `json:"start"`
+	Pos      int      `json:"pos"`
+	Orig     int      `json:"orig"`
+}
+
+// MarshalJSON returns a JSON representation of the SequenceIncrementer including its Sequence.
+func (s SequenceIncrementer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sequenceIncrementerJSON{
+		Version:  sequenceVersion,
+		Sequence: s.seq,
+		Start:    s.start,
+		Pos:      s.pos,
+		Orig:     s.orig,
+	})
+}
+
+// UnmarshalJSON parses a JSON representation of the SequenceIncrementer.
+func (s *SequenceIncrementer) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("SequenceIncrementer.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" || string(data) == `""` {
+		return nil
+	}
+
+	var j sequenceIncrementerJSON
+	if err := unmarshalVersioned(TypeSequenceIncrementer, "SequenceIncrementer", data, &j, "sequence"); err != nil {
+		return err
+	}
+
+	return s.set(j.Sequence, j.Start, j.Pos, j.Orig)
+}
+
+// MarshalBinary returns a compact binary representation of the SequenceIncrementer.
+func (s SequenceIncrementer) MarshalBinary() ([]byte, error) {
+	w := newBinaryWriter()
+	w.int(int(s.seq.Kind), s.seq.Start, s.seq.Ratio)
+	w.uint(uint64(len(s.seq.Steps)))
+	w.int(s.seq.Steps...)
+	w.int(s.start, s.pos, s.orig)
+	return w.buf, nil
+}
+
+// UnmarshalBinary parses a binary representation of the SequenceIncrementer.
+func (s *SequenceIncrementer) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader("SequenceIncrementer", data)
+	if err != nil {
+		return err
+	}
+
+	seq := Sequence{Kind: SequenceKind(r.int()), Start: r.int(), Ratio: r.int()}
+	n := r.uint()
+	if n > uint64(len(data)) {
+		r.short()
+		n = 0
+	}
+
+	for i := uint64(0); i < n; i++ {
+		seq.Steps = append(seq.Steps, r.int())
+	}
+
+	start, pos, orig := r.int(), r.int(), r.int()
+	if err := r.close(); err != nil {
+		return err
+	}
+
+	return s.set(seq, start, pos, orig)
+}
+
+// MarshalText returns a compact text representation of the SequenceIncrementer.
+func (s SequenceIncrementer) MarshalText() ([]byte, error) { return marshalText(s) }
+
+// UnmarshalText parses a text representation of the SequenceIncrementer.
+func (s *SequenceIncrementer) UnmarshalText(text []byte) error { return unmarshalText(s, text) }
+
+// set replaces the state of the SequenceIncrementer after checking it.
+func (s *SequenceIncrementer) set(seq Sequence, start, pos, orig int) error {
+	if err := seq.validate(); err != nil {
+		return err
+	}
+
+	n := SequenceIncrementer{seq: seq, start: start}
+	n.seq.Steps = append([]int(nil), seq.Steps...)
+	if pos != n.clamp(pos) || orig != n.clamp(orig) {
+		if seq.Len() < 0 {
+			return fmt.Errorf("invalid SequenceIncrementer: pos and orig must be 0 or greater")
+		}
+
+		return fmt.Errorf("invalid SequenceIncrementer: pos and orig must be from 0 to %d", seq.Len())
+	}
+
+	n.pos = pos
+	n.orig = orig
+	*s = n
+	return nil
+}
+
+// clamp returns pos clamped to the start of the Sequence and the end of a Table.
+func (s SequenceIncrementer) clamp(pos int) int {
+	if l := s.seq.Len(); l >= 0 {
+		return Clamp(pos, 0, l)
+	}
+
+	return ClampMin(pos, 0)
+}
+
+// valueAt returns the value after pos steps.
+func (s SequenceIncrementer) valueAt(pos int) int { return AddSaturating(s.start, s.seq.Sum(pos)) }
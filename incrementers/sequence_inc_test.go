@@ -0,0 +1,265 @@
+package incrementers
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceKind(t *testing.T) {
+	require := require.New(t)
+	require.Equal("fibonacci", Fibonacci.String())
+	require.Equal("SequenceKind(5)", SequenceKind(5).String())
+
+	var k SequenceKind
+	require.NoError(k.UnmarshalText([]byte("table")))
+	require.Equal(Table, k)
+	require.Error(k.UnmarshalText([]byte("random")))
+}
+
+func TestSequenceStep(t *testing.T) {
+	require := require.New(t)
+
+	tests := []struct {
+		name string
+		seq  Sequence
+		want []int
+	}{
+		{"geometric", NewGeometricSequence(1, 2), []int{1, 2, 4, 8, 16}},
+		{"constant", NewGeometricSequence(3, 1), []int{3, 3, 3, 3, 3}},
+		{"negative", NewGeometricSequence(-2, 3), []int{-2, -6, -18, -54, -162}},
+		{"fibonacci", NewFibonacciSequence(100), []int{100, 100, 200, 300, 500}},
+		{"table", NewTableSequence(2, 2, 4), []int{2, 2, 4, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, want := range tt.want {
+				require.Equal(want, tt.seq.Step(i), "Step(%d)", i)
+			}
+
+			require.Equal(0, tt.seq.Step(-1))
+		})
+	}
+
+	t.Run("saturates", func(t *testing.T) {
+		require.Equal(math.MaxInt, NewGeometricSequence(1, 2).Step(100))
+		require.Equal(math.MinInt, NewFibonacciSequence(-1).Step(200))
+		require.Equal(math.MaxInt, NewGeometricSequence(1, 2).Sum(math.MaxInt))
+		require.Equal(math.MaxInt, NewGeometricSequence(7, 1).Sum(math.MaxInt))
+		require.Equal(math.MinInt, NewGeometricSequence(-1, 3).Step(math.MaxInt))
+	})
+
+	t.Run("huge step", func(t *testing.T) {
+		require.Equal(7, NewGeometricSequence(7, 1).Step(math.MaxInt))
+		require.Equal(math.MaxInt, NewGeometricSequence(1, 2).Step(math.MaxInt))
+	})
+}
+
+func TestSequenceSum(t *testing.T) {
+	require := require.New(t)
+	require.Equal(15, NewGeometricSequence(1, 2).Sum(4))
+	require.Equal(12, NewFibonacciSequence(1).Sum(5))
+	require.Equal(8, NewTableSequence(2, 2, 4).Sum(10))
+	require.Equal(0, NewTableSequence(2, 2, 4).Sum(-1))
+}
+
+func TestNewSequenceIncrementer(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		die, err := NewSequenceIncrementer(NewTableSequence(2, 2, 2, 2), 4)
+		require.NoError(err)
+		require.Equal(4, die.Value())
+		require.Equal(4, die.Start())
+		require.Equal(2, die.Next())
+		require.True(die.IsEmpty())
+	})
+
+	tests := []struct {
+		name string
+		seq  Sequence
+		err  string
+	}{
+		{"zero start", NewGeometricSequence(0, 2), "invalid Sequence: geometric start must not be 0 and ratio must be 1 or greater"},
+		{"zero ratio", NewGeometricSequence(1, 0), "invalid Sequence: geometric start must not be 0 and ratio must be 1 or greater"},
+		{"zero fibonacci", NewFibonacciSequence(0), "invalid Sequence: fibonacci start must not be 0"},
+		{"empty table", NewTableSequence(), "invalid Sequence: table must have at least 1 step"},
+		{"kind", Sequence{Kind: 9}, "invalid Sequence: SequenceKind(9) is not a sequence kind"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSequenceIncrementer(tt.seq, 0)
+			require.Error(err, "NewSequenceIncrementer() did not return an error")
+			require.Equal(tt.err, err.Error())
+		})
+	}
+}
+
+func TestSequenceIncrementerStep(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("geometric", func(t *testing.T) {
+		tension, err := NewSequenceIncrementer(NewGeometricSequence(1, 2), 0)
+		require.NoError(err)
+		for _, want := range []int{1, 3, 7, 15} {
+			tension.Increment()
+			require.Equal(want, tension.Value())
+		}
+
+		for _, want := range []int{7, 3, 1, 0, 0} {
+			tension.Decrement()
+			require.Equal(want, tension.Value())
+		}
+
+		require.Equal(0, tension.Position())
+	})
+
+	t.Run("table end", func(t *testing.T) {
+		die, err := NewSequenceIncrementer(NewTableSequence(2, 2, 2, 2), 4)
+		require.NoError(err)
+		die.SetPosition(10)
+		require.Equal(4, die.Position())
+		require.Equal(12, die.Value())
+		require.True(die.IsFull())
+		require.Equal(0, die.Next())
+
+		die.Increment()
+		require.Equal(12, die.Value())
+		die.Decrement()
+		require.Equal(10, die.Value())
+	})
+
+	t.Run("retrace saturated", func(t *testing.T) {
+		s, err := NewSequenceIncrementer(NewGeometricSequence(1, 2), 0)
+		require.NoError(err)
+		s.SetPosition(70)
+		require.Equal(math.MaxInt, s.Value())
+
+		s.SetPosition(3)
+		require.Equal(7, s.Value())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		xp, err := NewSequenceIncrementer(NewFibonacciSequence(100), 0)
+		require.NoError(err)
+		xp.SetOriginalPosition(2)
+		require.Equal(200, xp.Original())
+
+		xp.SetPosition(5)
+		require.Equal(1200, xp.Value())
+		xp.Reset()
+		require.Equal(200, xp.Value())
+		xp.Empty()
+		require.True(xp.IsEmpty())
+	})
+
+	t.Run("sequence copy", func(t *testing.T) {
+		s, err := NewSequenceIncrementer(NewTableSequence(1, 2), 0)
+		require.NoError(err)
+		s.Sequence().Steps[0] = 50
+		require.Equal(1, s.Next())
+	})
+}
+
+func TestSequenceIncrementerFormat(t *testing.T) {
+	require := require.New(t)
+	die, err := NewSequenceIncrementer(NewTableSequence(2, 2, 2, 2), 4)
+	require.NoError(err)
+	die.Increment()
+
+	require.Equal("6", die.String())
+	require.Equal("4..12", fmt.Sprintf("%r", die))
+	require.Equal("25%", fmt.Sprintf("%P", die))
+	require.Equal("SequenceIncrementer{val:6 pos:1 next:2 seq:table orig:4}", fmt.Sprintf("%+v", die))
+}
+
+func TestSequenceIncrementerMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	s, err := NewSequenceIncrementer(NewGeometricSequence(1, 2), 0)
+	require.NoError(err)
+	s.Increment()
+
+	data, err := s.MarshalJSON()
+	require.NoError(err, "SequenceIncrementer.MarshalJSON() returned an error: %s", err)
+	require.Equal(
+		`{"version":1,"sequence":{"kind":"geometric","start":1,"ratio":2},"start":0,"pos":1,"orig":0}`,
+		string(data),
+	)
+}
+
+func TestSequenceIncrementerUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		s, err := NewSequenceIncrementerFromJSON([]byte(
+			`{"version":1,"sequence":{"kind":"table","steps":[2,2,2,2]},"start":4,"pos":2,"orig":0}`,
+		))
+		require.NoError(err, "SequenceIncrementer.UnmarshalJSON() returned an error: %s", err)
+		require.Equal(8, s.Value())
+		require.Equal(NewTableSequence(2, 2, 2, 2), s.Sequence())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var s SequenceIncrementer
+		err := s.UnmarshalJSON(nil)
+		require.Error(err, "SequenceIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("SequenceIncrementer.UnmarshalJSON(): data was nil", err.Error())
+	})
+
+	t.Run("missing sequence", func(t *testing.T) {
+		_, err := NewSequenceIncrementerFromJSON([]byte(`{"version":1,"start":0,"pos":0,"orig":0}`))
+		require.Error(err, "SequenceIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("invalid SequenceIncrementer: sequence is required", err.Error())
+	})
+
+	t.Run("past table", func(t *testing.T) {
+		_, err := NewSequenceIncrementerFromJSON([]byte(
+			`{"version":1,"sequence":{"kind":"table","steps":[2,2]},"start":4,"pos":3,"orig":0}`,
+		))
+		require.Error(err, "SequenceIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("invalid SequenceIncrementer: pos and orig must be from 0 to 2", err.Error())
+	})
+
+	t.Run("huge pos", func(t *testing.T) {
+		s, err := NewSequenceIncrementerFromJSON([]byte(
+			`{"version":1,"sequence":{"kind":"geometric","start":1,"ratio":1},"start":0,"pos":9223372036854775806,"orig":0}`,
+		))
+		require.NoError(err, "SequenceIncrementer.UnmarshalJSON() returned an error: %s", err)
+		require.Equal(1, s.Next())
+		require.Equal(math.MaxInt-1, s.Value())
+		require.Equal(
+			"SequenceIncrementer{val:9223372036854775806 pos:9223372036854775806 next:1 seq:geometric orig:0}",
+			fmt.Sprintf("%+v", s),
+		)
+	})
+
+	t.Run("negative pos", func(t *testing.T) {
+		_, err := NewSequenceIncrementerFromJSON([]byte(
+			`{"version":1,"sequence":{"kind":"fibonacci","start":1},"start":0,"pos":-1,"orig":0}`,
+		))
+		require.Error(err, "SequenceIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("invalid SequenceIncrementer: pos and orig must be 0 or greater", err.Error())
+	})
+}
+
+func TestSequenceIncrementerBinary(t *testing.T) {
+	require := require.New(t)
+	s, err := NewSequenceIncrementer(NewTableSequence(5, -3, 10), 1)
+	require.NoError(err)
+	s.SetPosition(2)
+
+	data, err := s.MarshalText()
+	require.NoError(err)
+
+	var got SequenceIncrementer
+	require.NoError(got.UnmarshalText(data))
+	require.Equal(s, got)
+
+	err = got.UnmarshalBinary([]byte{binaryVersion, 4, 0, 0, 200})
+	require.Error(err, "SequenceIncrementer.UnmarshalBinary() did not return an error")
+	require.Equal("invalid SequenceIncrementer: binary data was too short", err.Error())
+}
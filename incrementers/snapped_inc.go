@@ -0,0 +1,353 @@
+package incrementers
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// SnappedIncrementer is a ClampedIncrementer whose value stays on a grid of multiples of its increment
+// from an origin, such as stamina in 5s or currency in 10s. Values set off the grid are rounded to it
+// with the RoundingMode, and Increment and Decrement move to the next or previous grid point. A grid
+// point past a bound is moved to the last grid point inside it. If there is no grid point between
+// min and max the value is only clamped. An increment of 0 turns snapping off.
+type SnappedIncrementer struct {
+	origin int
+	mode   RoundingMode
+	ClampedIncrementer
+}
+
+// NewSnappedIncrementer creates a new SnappedIncrementer which snaps c to multiples of c's increment
+// from origin, rounding with mode.
+func NewSnappedIncrementer(c ClampedIncrementer, origin int, mode RoundingMode) SnappedIncrementer {
+	s := SnappedIncrementer{origin: origin, mode: mode, ClampedIncrementer: c}
+	s.snapAll()
+	return s
+}
+
+// NewSnappedIncrementerFromJSON creates a new SnappedIncrementer from a JSON representation.
+func NewSnappedIncrementerFromJSON(data []byte) (SnappedIncrementer, error) {
+	var s SnappedIncrementer
+	err := s.UnmarshalJSON(data)
+	return s, err
+}
+
+// Origin returns the grid point the steps are counted from.
+func (s SnappedIncrementer) Origin() int { return s.origin }
+
+// Mode returns how values off the grid are rounded.
+func (s SnappedIncrementer) Mode() RoundingMode { return s.mode }
+
+// IsFull returns true if the value is the last grid point before the maximum.
+func (s SnappedIncrementer) IsFull() bool {
+	max, ok := s.max.Value()
+	return ok && s.val == s.fit(s.grid(max, RoundDown))
+}
+
+// Increment moves the value to the next grid point in the direction of the increment.
+func (s *SnappedIncrementer) Increment() { s.step(1) }
+
+// Decrement moves the value to the previous grid point in the direction of the increment.
+func (s *SnappedIncrementer) Decrement() { s.step(-1) }
+
+// Add increases the value by val and snaps it to the grid.
+func (s *SnappedIncrementer) Add(val int) { s.SetValue(AddSaturating(s.val, val)) }
+
+// Remove decreases the value by val and snaps it to the grid.
+func (s *SnappedIncrementer) Remove(val int) { s.SetValue(SubSaturating(s.val, val)) }
+
+// AddChecked increases the value by val and snaps it to the grid, or returns ErrOverflow and leaves
+// the value unchanged if the result does not fit in an int.
+func (s *SnappedIncrementer) AddChecked(val int) error {
+	v, err := AddChecked(s.val, val)
+	if err != nil {
+		return err
+	}
+
+	s.SetValue(v)
+	return nil
+}
+
+// RemoveChecked decreases the value by val and snaps it to the grid, or returns ErrOverflow and leaves
+// the value unchanged if the result does not fit in an int.
+func (s *SnappedIncrementer) RemoveChecked(val int) error {
+	v, err := SubChecked(s.val, val)
+	if err != nil {
+		return err
+	}
+
+	s.SetValue(v)
+	return nil
+}
+
+// SetIncrementer sets the grid step and snaps the value and original value to the new grid.
+func (s *SnappedIncrementer) SetIncrementer(inc int) { s.inc = inc; s.snapAll() }
+
+// SetOrigin moves the grid to origin and snaps the value and original value to it.
+func (s *SnappedIncrementer) SetOrigin(origin int) { s.origin = origin; s.snapAll() }
+
+// SetMode sets how values off the grid are rounded. The value is not changed.
+func (s *SnappedIncrementer) SetMode(mode RoundingMode) { s.mode = mode }
+
+// SetMin sets the minimum and snaps the value into it.
+func (s *SnappedIncrementer) SetMin(min int) { s.SetMinBound(BoundAt(min)) }
+
+// SetMax sets the maximum and snaps the value into it.
+func (s *SnappedIncrementer) SetMax(max int) { s.SetMaxBound(BoundAt(max)) }
+
+// SetMinBound sets the minimum and snaps the value into it. Use Unbounded to remove the minimum.
+func (s *SnappedIncrementer) SetMinBound(min Bound) { s.min = min; s.snapAll() }
+
+// SetMaxBound sets the maximum and snaps the value into it. Use Unbounded to remove the maximum.
+func (s *SnappedIncrementer) SetMaxBound(max Bound) { s.max = max; s.snapAll() }
+
+// SetValue sets the value to val snapped to the grid.
+func (s *SnappedIncrementer) SetValue(val int) { s.val = s.snap(val, s.mode) }
+
+// SetOriginalValue sets the original value to val snapped to the grid.
+func (s *SnappedIncrementer) SetOriginalValue(val int) { s.orig = s.snap(val, s.mode) }
+
+// Clamp snaps the value to the grid inside the min max range.
+func (s *SnappedIncrementer) Clamp() { s.SetValue(s.val) }
+
+// ClampOriginalValue snaps the original value to the grid inside the min max range.
+func (s *SnappedIncrementer) ClampOriginalValue() { s.SetOriginalValue(s.orig) }
+
+// Fill sets the value to the last grid point before the maximum. Does nothing if there is no maximum.
+func (s *SnappedIncrementer) Fill() {
+	if max, ok := s.max.Value(); ok {
+		s.val = s.fit(s.grid(max, RoundDown))
+	}
+}
+
+// Floor sets the value to the first grid point after the minimum. Does nothing if there is no minimum.
+func (s *SnappedIncrementer) Floor() {
+	if min, ok := s.min.Value(); ok {
+		s.val = s.fit(s.grid(min, RoundUp))
+	}
+}
+
+// Empty sets the value to 0 snapped to the grid.
+func (s *SnappedIncrementer) Empty() { s.SetValue(0) }
+
+// Reset sets the value to the original value.
+func (s *SnappedIncrementer) Reset() { s.SetValue(s.orig) }
+
+// Ratio returns how far the value is from the first to the last grid point in the bounds, from 0 to 1.
+// Returns 0 if there is no min or max.
+func (s SnappedIncrementer) Ratio() float64 {
+	lo, hi, ok := s.reach()
+	if !ok {
+		return 0
+	}
+
+	return ratio(s.val, lo, hi)
+}
+
+// Percent returns how far the value is from the first to the last grid point in the bounds, from 0 to
+// 100 rounded down. Returns 0 if there is no min or max.
+func (s SnappedIncrementer) Percent() int { return s.Segments(100) }
+
+// Segments returns how many of n equal segments from the first to the last grid point in the bounds
+// are completely filled. Returns 0 if there is no min or max or n is less than 1.
+func (s SnappedIncrementer) Segments(n int) int {
+	lo, hi, ok := s.reach()
+	if !ok {
+		return 0
+	}
+
+	return segments(s.val, lo, hi, n)
+}
+
+// SetFraction sets the value to the given fraction of the way from the first to the last grid point in
+// the bounds, snapped to the grid with mode. Does nothing if there is no min or max or f is NaN.
+func (s *SnappedIncrementer) SetFraction(f float64, mode RoundingMode) {
+	lo, hi, ok := s.reach()
+	if !ok || math.IsNaN(f) {
+		return
+	}
+
+	s.val = s.snap(atFraction(f, lo, hi, mode), mode)
+}
+
+// reach returns the first and last grid points in the bounds, and false if either bound is missing.
+func (s SnappedIncrementer) reach() (int, int, bool) {
+	min, max, ok := s.bounds()
+	if !ok {
+		return 0, 0, false
+	}
+
+	return s.fit(s.grid(min, RoundUp)), s.fit(s.grid(max, RoundDown)), true
+}
+
+// step moves the value to the next grid point in dir times the direction of the increment.
+func (s *SnappedIncrementer) step(dir int) {
+	if s.inc == 0 {
+		return
+	}
+
+	if s.inc < 0 {
+		dir = -dir
+	}
+
+	size := s.size()
+	if dir > 0 {
+		g := s.grid(s.val, RoundDown)
+		s.val = s.fit(g.Add(g, size))
+		return
+	}
+
+	g := s.grid(s.val, RoundUp)
+	s.val = s.fit(g.Sub(g, size))
+}
+
+// snapAll snaps the value and original value to the grid.
+func (s *SnappedIncrementer) snapAll() {
+	s.val = s.snap(s.val, s.mode)
+	s.orig = s.snap(s.orig, s.mode)
+}
+
+// snap returns val rounded to the grid with mode and moved inside the bounds.
+func (s SnappedIncrementer) snap(val int, mode RoundingMode) int { return s.fit(s.grid(val, mode)) }
+
+// size returns the distance between grid points.
+func (s SnappedIncrementer) size() *big.Int { return new(big.Int).Abs(big.NewInt(int64(s.inc))) }
+
+// grid returns val rounded to the grid with mode, or val if snapping is off.
+func (s SnappedIncrementer) grid(val int, mode RoundingMode) *big.Int {
+	v := big.NewInt(int64(val))
+	size := s.size()
+	if size.Sign() == 0 {
+		return v
+	}
+
+	origin := big.NewInt(int64(s.origin))
+	q, m := new(big.Int).DivMod(v.Sub(v, origin), size, new(big.Int))
+	if m.Sign() != 0 {
+		switch mode {
+		case RoundUp:
+			q.Add(q, big.NewInt(1))
+		case RoundNearest:
+			if m.Lsh(m, 1).Cmp(size) >= 0 {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	}
+
+	q.Mul(q, size)
+	return q.Add(q, origin)
+}
+
+// fit moves the grid point g to the last grid point inside the bounds and returns it as an int. The
+// result is clamped if there is no grid point inside the bounds.
+func (s SnappedIncrementer) fit(g *big.Int) int {
+	if max, ok := s.max.Value(); ok && g.Cmp(big.NewInt(int64(max))) > 0 {
+		g = s.grid(max, RoundDown)
+	}
+
+	if min, ok := s.min.Value(); ok && g.Cmp(big.NewInt(int64(min))) < 0 {
+		g = s.grid(min, RoundUp)
+	}
+
+	switch {
+	case g.Cmp(big.NewInt(int64(s.Min()))) < 0:
+		return s.Min()
+	case g.Cmp(big.NewInt(int64(s.Max()))) > 0:
+		return s.Max()
+	}
+
+	return int(g.Int64())
+}
+
+func (s SnappedIncrementer) view() formatView {
+	v := s.ClampedIncrementer.view()
+	v.name = "SnappedIncrementer"
+	v.fields = append(v.fields, formatField{"origin", s.origin}, formatField{"mode", s.mode})
+	return v
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (s SnappedIncrementer) Format(f fmt.State, verb rune) { s.view().format(f, verb) }
+
+// MarshalJSON returns a JSON representation of the SnappedIncrementer.
+func (s SnappedIncrementer) MarshalJSON() ([]byte, error) {
+	mode, err := s.mode.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	c, _ := s.ClampedIncrementer.MarshalJSON()
+	return []byte(fmt.Sprintf(
+		`{"version":%d,"origin":%d,"mode":"%s","clamped":%s}`, snappedVersion, s.origin, mode, c,
+	)), nil
+}
+
+type snappedIncrementerJSON struct {
+	Origin  int                `json:"origin"`
+	Mode    RoundingMode       `json:"mode"`
+	Clamped ClampedIncrementer `json:"clamped"`
+}
+
+// UnmarshalJSON parses a JSON representation of the SnappedIncrementer. The value and original value
+// are snapped to the grid.
+func (s *SnappedIncrementer) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("SnappedIncrementer.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" || string(data) == `""` {
+		return nil
+	}
+
+	var j snappedIncrementerJSON
+	err := unmarshalVersioned(TypeSnappedIncrementer, "SnappedIncrementer", data, &j, "mode", "clamped")
+	if err != nil {
+		return err
+	}
+
+	return s.set(j.Origin, j.Mode, j.Clamped)
+}
+
+// MarshalBinary returns a compact binary representation of the SnappedIncrementer.
+func (s SnappedIncrementer) MarshalBinary() ([]byte, error) {
+	w := newBinaryWriter()
+	w.int(s.origin, int(s.mode))
+	w.clamped(s.ClampedIncrementer)
+	return w.buf, nil
+}
+
+// UnmarshalBinary parses a binary representation of the SnappedIncrementer.
+func (s *SnappedIncrementer) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader("SnappedIncrementer", data)
+	if err != nil {
+		return err
+	}
+
+	origin, mode := r.int(), r.int()
+	c := r.clamped()
+	if err := r.close(); err != nil {
+		return err
+	}
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	return s.set(origin, RoundingMode(mode), c)
+}
+
+// MarshalText returns a compact text representation of the SnappedIncrementer.
+func (s SnappedIncrementer) MarshalText() ([]byte, error) { return marshalText(s) }
+
+// UnmarshalText parses a text representation of the SnappedIncrementer.
+func (s *SnappedIncrementer) UnmarshalText(text []byte) error { return unmarshalText(s, text) }
+
+// set replaces the state of the SnappedIncrementer after checking it.
+func (s *SnappedIncrementer) set(origin int, mode RoundingMode, c ClampedIncrementer) error {
+	if mode < 0 || int(mode) >= len(roundingModes) {
+		return fmt.Errorf("invalid SnappedIncrementer: %s is not a rounding mode", mode)
+	}
+
+	*s = NewSnappedIncrementer(c, origin, mode)
+	return nil
+}
@@ -0,0 +1,258 @@
+package incrementers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ Counter  = &SnappedIncrementer{}
+	_ Clock    = &SnappedIncrementer{}
+	_ Progress = &SnappedIncrementer{}
+)
+
+func stamina(min, max, val, step int) SnappedIncrementer {
+	c := NewClampedIncrementerWithValue(min, max, val)
+	c.SetIncrementer(step)
+	return NewSnappedIncrementer(c, 0, RoundNearest)
+}
+
+func TestSnappedIncrementerNew(t *testing.T) {
+	require := require.New(t)
+	s := stamina(0, 100, 7, 5)
+	require.Equal(5, s.Value())
+	require.Equal(5, s.Original())
+	require.Equal(0, s.Origin())
+	require.Equal(RoundNearest, s.Mode())
+}
+
+func TestSnappedIncrementerSetValue(t *testing.T) {
+	require := require.New(t)
+	s := stamina(0, 100, 0, 5)
+
+	tests := []struct {
+		mode      RoundingMode
+		val, want int
+	}{
+		{RoundDown, 7, 5},
+		{RoundUp, 7, 10},
+		{RoundNearest, 7, 5},
+		{RoundNearest, 8, 10},
+		{RoundNearest, 12, 10},
+		{RoundNearest, 13, 15},
+		{RoundNearest, 10, 10},
+		{RoundDown, -3, 0},
+		{RoundUp, 103, 100},
+	}
+
+	for _, tt := range tests {
+		s.SetMode(tt.mode)
+		s.SetValue(tt.val)
+		require.Equal(tt.want, s.Value(), "SetValue(%d) with %s", tt.val, tt.mode)
+	}
+}
+
+func TestSnappedIncrementerOrigin(t *testing.T) {
+	require := require.New(t)
+	c := NewClampedIncrementerWithBounds(Unbounded(), Unbounded(), 0)
+	c.SetIncrementer(10)
+	s := NewSnappedIncrementer(c, 3, RoundDown)
+	require.Equal(-7, s.Value())
+
+	s.SetValue(-8)
+	require.Equal(-17, s.Value(), "RoundDown did not round toward the minimum for negative values")
+
+	s.SetOrigin(0)
+	require.Equal(-20, s.Value())
+	s.Increment()
+	require.Equal(-10, s.Value())
+}
+
+func TestSnappedIncrementerStep(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("increment", func(t *testing.T) {
+		s := stamina(0, 12, 0, 5)
+		for _, want := range []int{5, 10, 10} {
+			s.Increment()
+			require.Equal(want, s.Value())
+		}
+
+		require.True(s.IsFull(), "the last grid point before the max was not full")
+		require.Equal(100, s.Percent())
+	})
+
+	t.Run("decrement", func(t *testing.T) {
+		s := stamina(0, 12, 10, 5)
+		for _, want := range []int{5, 0, 0} {
+			s.Decrement()
+			require.Equal(want, s.Value())
+		}
+	})
+
+	t.Run("negative increment", func(t *testing.T) {
+		s := stamina(0, 100, 50, -5)
+		s.Increment()
+		require.Equal(45, s.Value())
+		s.Decrement()
+		require.Equal(50, s.Value())
+	})
+
+	t.Run("set increment", func(t *testing.T) {
+		s := stamina(0, 100, 15, 5)
+		s.SetIncrementer(10)
+		require.Equal(20, s.Value(), "SetIncrementer() did not snap to the new grid")
+		require.Equal(20, s.Original())
+	})
+
+	t.Run("no grid", func(t *testing.T) {
+		s := stamina(0, 10, 7, 0)
+		s.Increment()
+		require.Equal(7, s.Value())
+		s.Add(2)
+		require.Equal(9, s.Value())
+	})
+}
+
+func TestSnappedIncrementerAdd(t *testing.T) {
+	require := require.New(t)
+	gold := stamina(0, 1000, 50, 10)
+	gold.SetMode(RoundDown)
+
+	gold.Add(27)
+	require.Equal(70, gold.Value())
+	gold.Remove(5)
+	require.Equal(60, gold.Value())
+
+	require.NoError(gold.AddChecked(15))
+	require.Equal(70, gold.Value())
+	require.ErrorIs(gold.RemoveChecked(math.MinInt), ErrOverflow)
+	require.Equal(70, gold.Value())
+}
+
+func TestSnappedIncrementerBounds(t *testing.T) {
+	require := require.New(t)
+	s := stamina(1, 23, 12, 5)
+	require.Equal(10, s.Value())
+
+	s.Floor()
+	require.Equal(5, s.Value())
+	s.Fill()
+	require.Equal(20, s.Value())
+
+	s.SetMax(17)
+	require.Equal(15, s.Value())
+
+	s.SetMinBound(Unbounded())
+	s.SetValue(-12)
+	require.Equal(-10, s.Value())
+
+	t.Run("no grid point", func(t *testing.T) {
+		s := stamina(1, 4, 2, 5)
+		require.Equal(4, s.Value(), "a value with no grid point in the bounds was not clamped")
+	})
+
+	t.Run("unbounded", func(t *testing.T) {
+		c := NewClampedIncrementerWithBounds(Unbounded(), Unbounded(), math.MaxInt)
+		c.SetIncrementer(10)
+		s := NewSnappedIncrementer(c, 0, RoundUp)
+		require.Equal(math.MaxInt, s.Value(), "a grid point past MaxInt did not saturate")
+	})
+}
+
+func TestSnappedIncrementerProgress(t *testing.T) {
+	require := require.New(t)
+	s := stamina(0, 22, 10, 5)
+	require.Equal(50, s.Percent(), "Percent() did not use the last grid point before the max")
+	require.Equal(0.5, s.Ratio())
+	require.Equal(2, s.Segments(4))
+
+	s.SetFraction(0.3, RoundUp)
+	require.Equal(10, s.Value())
+	s.SetFraction(0.3, RoundDown)
+	require.Equal(5, s.Value())
+}
+
+func TestSnappedIncrementerReset(t *testing.T) {
+	require := require.New(t)
+	s := stamina(0, 100, 40, 5)
+	s.Add(30)
+	s.SetOriginalValue(33)
+	require.Equal(35, s.Original())
+
+	s.Reset()
+	require.Equal(35, s.Value())
+	s.Empty()
+	require.Equal(0, s.Value())
+}
+
+func TestSnappedIncrementerMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	s := stamina(0, 100, 40, 5)
+
+	data, err := s.MarshalJSON()
+	require.NoError(err, "SnappedIncrementer.MarshalJSON() returned an error: %s", err)
+	require.Equal(
+		`{"version":1,"origin":0,"mode":"nearest",`+
+			`"clamped":{"version":3,"min":0,"max":100,"incrementer":{"version":2,"inc":5,"val":40,"orig":40}}}`,
+		string(data),
+	)
+}
+
+func TestSnappedIncrementerUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		s, err := NewSnappedIncrementerFromJSON([]byte(
+			`{"version":1,"origin":2,"mode":"up",` +
+				`"clamped":{"min":0,"max":100,"incrementer":{"inc":10,"val":40,"orig":40}}}`,
+		))
+		require.NoError(err, "SnappedIncrementer.UnmarshalJSON() returned an error: %s", err)
+		require.Equal(2, s.Origin())
+		require.Equal(RoundUp, s.Mode())
+		require.Equal(42, s.Value(), "UnmarshalJSON() did not snap the value")
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var s SnappedIncrementer
+		err := s.UnmarshalJSON(nil)
+		require.Error(err, "SnappedIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("SnappedIncrementer.UnmarshalJSON(): data was nil", err.Error())
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		_, err := NewSnappedIncrementerFromJSON([]byte(
+			`{"version":1,"origin":0,"mode":"sideways",` +
+				`"clamped":{"min":0,"max":100,"incrementer":{"inc":10,"val":40,"orig":40}}}`,
+		))
+		require.Error(err, "SnappedIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("invalid RoundingMode: sideways", err.Error())
+	})
+
+	t.Run("missing mode", func(t *testing.T) {
+		_, err := NewSnappedIncrementerFromJSON([]byte(
+			`{"version":1,"origin":0,"clamped":{"min":0,"max":100,"incrementer":{"inc":10,"val":40,"orig":40}}}`,
+		))
+		require.Error(err, "SnappedIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("invalid SnappedIncrementer: mode is required", err.Error())
+	})
+}
+
+func TestSnappedIncrementerBinary(t *testing.T) {
+	require := require.New(t)
+	s := stamina(-50, 50, 20, 10)
+
+	data, err := s.MarshalText()
+	require.NoError(err)
+
+	var got SnappedIncrementer
+	require.NoError(got.UnmarshalText(data))
+	require.Equal(s, got)
+
+	bad := []byte{binaryVersion, 0, 18, 1, 0, 1, 40, 10, 0, 0}
+	err = got.UnmarshalBinary(bad)
+	require.Error(err, "SnappedIncrementer.UnmarshalBinary() did not return an error")
+	require.Equal("invalid SnappedIncrementer: RoundingMode(9) is not a rounding mode", err.Error())
+}
@@ -0,0 +1,97 @@
+package incrementers
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+type jsonValuer struct {
+	v json.Marshaler
+}
+
+// JSONValue returns a driver.Valuer which writes v to a database column as JSON. Incrementers can't
+// implement driver.Valuer themselves because their Value method returns the current value. Scan reads
+// the column back.
+//
+//	db.Exec("UPDATE tokens SET hp = ? WHERE id = ?", incrementers.JSONValue(hp), id)
+//	db.QueryRow("SELECT hp FROM tokens WHERE id = ?", id).Scan(&hp)
+func JSONValue(v json.Marshaler) driver.Valuer { return jsonValuer{v: v} }
+
+func (j jsonValuer) Value() (driver.Value, error) {
+	data, err := j.v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+type textValuer struct {
+	v encoding.TextMarshaler
+}
+
+// TextValue returns a driver.Valuer which writes v to a database column in its compact text format.
+func TextValue(v encoding.TextMarshaler) driver.Valuer { return textValuer{v: v} }
+
+func (t textValuer) Value() (driver.Value, error) {
+	text, err := t.v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return string(text), nil
+}
+
+type columnUnmarshaler interface {
+	json.Unmarshaler
+	encoding.TextUnmarshaler
+}
+
+// scan reads a column written with JSONValue or TextValue into dst. NULL leaves dst unchanged.
+func scan(typ string, dst columnUnmarshaler, src any) error {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	default:
+		return fmt.Errorf("invalid %s: cannot scan %T", typ, src)
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		return dst.UnmarshalJSON(data)
+	}
+
+	return dst.UnmarshalText(data)
+}
+
+// Scan reads the Incrementer from a JSON or text database column.
+func (i *Incrementer) Scan(src any) error { return scan("Incrementer", i, src) }
+
+// Scan reads the counter from a JSON or text database column.
+func (u *UIncrementer) Scan(src any) error { return scan("UIncrementer", u, src) }
+
+// Scan reads the counter from a JSON or text database column.
+func (c *ClampedIncrementer) Scan(src any) error { return scan("ClampedIncrementer", c, src) }
+
+// Scan reads the incrementer from a JSON or text database column.
+func (w *WrappingIncrementer) Scan(src any) error { return scan("WrappingIncrementer", w, src) }
+
+// Scan reads the TickingIncrementer from a JSON or text database column.
+func (t *TickingIncrementer) Scan(src any) error { return scan("TickingIncrementer", t, src) }
+
+// Scan reads the SnappedIncrementer from a JSON or text database column.
+func (s *SnappedIncrementer) Scan(src any) error { return scan("SnappedIncrementer", s, src) }
+
+// Scan reads the SequenceIncrementer from a JSON or text database column.
+func (s *SequenceIncrementer) Scan(src any) error { return scan("SequenceIncrementer", s, src) }
+
+// Scan reads the ModifierStack from a JSON or text database column.
+func (s *ModifierStack) Scan(src any) error { return scan("ModifierStack", s, src) }
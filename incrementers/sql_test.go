@@ -0,0 +1,96 @@
+package incrementers
+
+import (
+	"testing"
+
+	"github.com/chadeldridge/rpgtools/internal/sqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLRoundTrip(t *testing.T) {
+	require := require.New(t)
+	db, err := sqltest.Open(t.Name())
+	require.NoError(err, "sqltest.Open() returned an error: %s", err)
+	defer db.Close()
+
+	inc := NewIncrementerWithValue(-3)
+	hp := NewClampedIncrementerWithValue(0, 45, 37)
+	gold := NewUIncrementerWithValue(120)
+	day := NewWrappingIncrementerWithValue(1, 7, 3)
+
+	_, err = db.Exec(
+		"INSERT INTO sheet VALUES (?, ?, ?, ?)",
+		JSONValue(inc), JSONValue(hp), TextValue(gold), TextValue(day),
+	)
+	require.NoError(err, "db.Exec() returned an error: %s", err)
+
+	var gotInc Incrementer
+	var gotHP ClampedIncrementer
+	var gotGold UIncrementer
+	var gotDay WrappingIncrementer
+	require.NoError(db.QueryRow("SELECT * FROM sheet").Scan(&gotInc, &gotHP, &gotGold, &gotDay))
+	require.Equal(inc, gotInc)
+	require.Equal(hp, gotHP)
+	require.Equal(gold, gotGold)
+	require.Equal(day, gotDay)
+}
+
+func TestSQLValue(t *testing.T) {
+	require := require.New(t)
+	c := NewClampedIncrementerWithValue(0, 4, 2)
+
+	v, err := JSONValue(c).Value()
+	require.NoError(err, "Value() returned an error: %s", err)
+	require.Equal(`{"version":3,"min":0,"max":4,"incrementer":{"version":2,"inc":1,"val":2,"orig":2}}`, v)
+
+	v, err = TextValue(c).Value()
+	require.NoError(err, "Value() returned an error: %s", err)
+	text, _ := c.MarshalText()
+	require.Equal(string(text), v)
+}
+
+func TestScan(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("legacy json", func(t *testing.T) {
+		var c ClampedIncrementer
+		require.NoError(c.Scan([]byte(` {"min":0,"max":4,"incrementer":{"inc":1,"val":3,"orig":0}}`)))
+		require.Equal(3, c.Value())
+		require.Equal(4, c.Max())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		i := NewIncrementerWithValue(5)
+		require.NoError(i.Scan(nil))
+		require.Equal(5, i.Value())
+	})
+
+	t.Run("validates", func(t *testing.T) {
+		var u UIncrementer
+		err := u.Scan(`{"inc":1,"val":-2,"orig":0}`)
+		require.Error(err, "UIncrementer.Scan() did not return an error")
+		require.Equal("invalid UIncrementer: Incrementer.val must be 0 or greater", err.Error())
+	})
+
+	t.Run("embedded types", func(t *testing.T) {
+		ti := NewTickingIncrementer(NewClampedIncrementerWithValue(0, 10, 4), -1)
+		text, _ := ti.MarshalText()
+		var got TickingIncrementer
+		require.NoError(got.Scan(text))
+		require.Equal(ti, got)
+
+		s := NewModifierStack(NewIncrementerWithValue(10))
+		s.AddModifier(NewAdditiveModifier("bless", "luck", 2, 3))
+		data, _ := s.MarshalJSON()
+		var gotStack ModifierStack
+		require.NoError(gotStack.Scan(data))
+		require.Equal(12, gotStack.Effective())
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		var i Incrementer
+		err := i.Scan(int64(4))
+		require.Error(err, "Incrementer.Scan() did not return an error")
+		require.Equal("invalid Incrementer: cannot scan int64", err.Error())
+	})
+}
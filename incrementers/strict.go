@@ -0,0 +1,548 @@
+package incrementers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Errors returned by the strict incrementers. Use errors.Is to check for them.
+var (
+	ErrBelowMin          = errors.New("below the minimum")
+	ErrAboveMax          = errors.New("above the maximum")
+	ErrInvalidBounds     = errors.New("invalid bounds")
+	ErrNegativeIncrement = errors.New("negative incrementer value")
+)
+
+// StrictCounter is a Counter whose mutators return an error instead of clamping or ignoring invalid
+// changes.
+type StrictCounter interface {
+	Inc() int
+	Value() int
+	Original() int
+
+	IsFull() bool
+	IsEmpty() bool
+
+	Increment() error
+	Decrement() error
+	Add(int) error
+	Remove(int) error
+
+	SetMax(int) error
+	SetIncrementer(int) error
+	SetValue(int) error
+	SetOriginalValue(int) error
+
+	Empty() error
+	Reset() error
+
+	String() string
+	MarshalJSON() ([]byte, error)
+	UnmarshalJSON([]byte) error
+}
+
+// StrictClock is a Clock whose mutators return an error instead of clamping or ignoring invalid
+// changes.
+type StrictClock interface {
+	Max() int
+	Value() int
+	Original() int
+
+	IsFull() bool
+	IsEmpty() bool
+
+	Increment() error
+	Decrement() error
+	Add(int) error
+	Remove(int) error
+
+	SetMax(int) error
+	SetValue(int) error
+	SetOriginalValue(int) error
+
+	Fill() error
+	Empty() error
+	Reset() error
+
+	String() string
+	MarshalJSON() ([]byte, error)
+	UnmarshalJSON([]byte) error
+}
+
+// StrictProgress is Progress whose SetFraction returns an error instead of clamping. The StrictClocks
+// made by NewStrictClock implement it.
+type StrictProgress interface {
+	Ratio() float64
+	Percent() int
+	Segments(int) int
+	SetFraction(float64, RoundingMode) error
+}
+
+// StrictClampedIncrementer changes a ClampedIncrementer but returns an error, and leaves it unchanged,
+// where the ClampedIncrementer would clamp or ignore the change.
+type StrictClampedIncrementer struct {
+	c *ClampedIncrementer
+}
+
+// NewStrictClampedIncrementer creates a new StrictClampedIncrementer from min to max with a starting
+// value of val.
+func NewStrictClampedIncrementer(min, max, val int) (*StrictClampedIncrementer, error) {
+	return NewStrictClampedIncrementerWithBounds(BoundAt(min), BoundAt(max), val)
+}
+
+// NewStrictClampedIncrementerWithBounds creates a new StrictClampedIncrementer from min to max, either
+// of which may be Unbounded, with a starting value of val.
+func NewStrictClampedIncrementerWithBounds(min, max Bound, val int) (*StrictClampedIncrementer, error) {
+	lo, hasMin := min.Value()
+	hi, hasMax := max.Value()
+	if hasMin && hasMax && lo >= hi {
+		return nil, fmt.Errorf("%w: min %d >= max %d", ErrInvalidBounds, lo, hi)
+	}
+
+	s := &StrictClampedIncrementer{c: &ClampedIncrementer{min: min, max: max, Incrementer: Incrementer{inc: 1}}}
+	if err := s.check(val); err != nil {
+		return nil, err
+	}
+
+	s.c.val, s.c.orig = val, val
+	return s, nil
+}
+
+// NewStrictCounter creates a new StrictCounter with a minimum value of 0, no maximum value and a
+// starting value of val.
+func NewStrictCounter(val int) (StrictCounter, error) {
+	return NewStrictClampedIncrementerWithBounds(BoundAt(0), Unbounded(), val)
+}
+
+// NewStrictClock creates a new StrictClock from 0 to steps with a starting value of ticks.
+func NewStrictClock(steps, ticks int) (StrictClock, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("%w: steps must be greater than 0", ErrInvalidBounds)
+	}
+
+	return NewStrictClampedIncrementer(0, steps, ticks)
+}
+
+// WrapStrict returns a StrictClampedIncrementer which changes c. c must be within its bounds.
+func WrapStrict(c *ClampedIncrementer) (*StrictClampedIncrementer, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	return &StrictClampedIncrementer{c: c}, nil
+}
+
+// Lenient returns the ClampedIncrementer the StrictClampedIncrementer changes.
+func (s *StrictClampedIncrementer) Lenient() *ClampedIncrementer { return s.c }
+
+// Inc returns the incrementer value.
+func (s *StrictClampedIncrementer) Inc() int { return s.c.inc }
+
+// Value returns the current value.
+func (s *StrictClampedIncrementer) Value() int { return s.c.val }
+
+// Original returns the original value.
+func (s *StrictClampedIncrementer) Original() int { return s.c.orig }
+
+// Min returns the minimum value, or math.MinInt if there is no minimum.
+func (s *StrictClampedIncrementer) Min() int { return s.c.Min() }
+
+// Max returns the maximum value, or math.MaxInt if there is no maximum.
+func (s *StrictClampedIncrementer) Max() int { return s.c.Max() }
+
+// MinBound returns the minimum.
+func (s *StrictClampedIncrementer) MinBound() Bound { return s.c.min }
+
+// MaxBound returns the maximum.
+func (s *StrictClampedIncrementer) MaxBound() Bound { return s.c.max }
+
+// IsFull returns true if the value is at the maximum.
+func (s *StrictClampedIncrementer) IsFull() bool { return s.c.IsFull() }
+
+// IsEmpty returns true if the value is 0.
+func (s *StrictClampedIncrementer) IsEmpty() bool { return s.c.IsEmpty() }
+
+// Increment increases the value by the incrementer value.
+func (s *StrictClampedIncrementer) Increment() error { return s.Add(s.c.inc) }
+
+// Decrement decreases the value by the incrementer value.
+func (s *StrictClampedIncrementer) Decrement() error { return s.Remove(s.c.inc) }
+
+// Add increases the value by val. Returns ErrAboveMax, ErrBelowMin or ErrOverflow if the result is
+// out of range.
+func (s *StrictClampedIncrementer) Add(val int) error {
+	v, err := AddChecked(s.c.val, val)
+	if err != nil {
+		return err
+	}
+
+	return s.SetValue(v)
+}
+
+// Remove decreases the value by val. Returns ErrAboveMax, ErrBelowMin or ErrOverflow if the result is
+// out of range.
+func (s *StrictClampedIncrementer) Remove(val int) error {
+	v, err := SubChecked(s.c.val, val)
+	if err != nil {
+		return err
+	}
+
+	return s.SetValue(v)
+}
+
+// SetMin sets the minimum value. Returns ErrInvalidBounds if min is not below the maximum and
+// ErrBelowMin if the value or original value is below min.
+func (s *StrictClampedIncrementer) SetMin(min int) error {
+	if max, ok := s.c.max.Value(); ok && min >= max {
+		return fmt.Errorf("%w: min %d >= max %d", ErrInvalidBounds, min, max)
+	}
+
+	for _, v := range []int{s.c.val, s.c.orig} {
+		if v < min {
+			return fmt.Errorf("%w: %d < %d", ErrBelowMin, v, min)
+		}
+	}
+
+	s.c.min = BoundAt(min)
+	return nil
+}
+
+// SetMax sets the maximum value. Returns ErrInvalidBounds if max is not above the minimum and
+// ErrAboveMax if the value or original value is above max.
+func (s *StrictClampedIncrementer) SetMax(max int) error {
+	if min, ok := s.c.min.Value(); ok && max <= min {
+		return fmt.Errorf("%w: min %d >= max %d", ErrInvalidBounds, min, max)
+	}
+
+	for _, v := range []int{s.c.val, s.c.orig} {
+		if v > max {
+			return fmt.Errorf("%w: %d > %d", ErrAboveMax, v, max)
+		}
+	}
+
+	s.c.max = BoundAt(max)
+	return nil
+}
+
+// SetMinBound sets the minimum like SetMin, or removes it if min is Unbounded.
+func (s *StrictClampedIncrementer) SetMinBound(min Bound) error {
+	if val, ok := min.Value(); ok {
+		return s.SetMin(val)
+	}
+
+	s.c.min = min
+	return nil
+}
+
+// SetMaxBound sets the maximum like SetMax. Returns ErrInvalidBounds if max is Unbounded and there is a
+// maximum, so a strict clock can not become an unbounded counter.
+func (s *StrictClampedIncrementer) SetMaxBound(max Bound) error {
+	if val, ok := max.Value(); ok {
+		return s.SetMax(val)
+	}
+
+	if !s.c.max.IsUnbounded() {
+		return fmt.Errorf("%w: max %s can not be removed", ErrInvalidBounds, s.c.max)
+	}
+
+	return nil
+}
+
+// SetIncrementer sets the incrementer value. Returns ErrNegativeIncrement if inc is negative.
+func (s *StrictClampedIncrementer) SetIncrementer(inc int) error {
+	if inc < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativeIncrement, inc)
+	}
+
+	s.c.inc = inc
+	return nil
+}
+
+// SetValue sets the value. Returns ErrBelowMin or ErrAboveMax if val is out of range.
+func (s *StrictClampedIncrementer) SetValue(val int) error {
+	if err := s.check(val); err != nil {
+		return err
+	}
+
+	s.c.val = val
+	return nil
+}
+
+// SetOriginalValue sets the original value. Returns ErrBelowMin or ErrAboveMax if val is out of range.
+func (s *StrictClampedIncrementer) SetOriginalValue(val int) error {
+	if err := s.check(val); err != nil {
+		return err
+	}
+
+	s.c.orig = val
+	return nil
+}
+
+// Fill sets the value to the maximum. Returns ErrInvalidBounds if there is no maximum.
+func (s *StrictClampedIncrementer) Fill() error {
+	if s.c.max.IsUnbounded() {
+		return fmt.Errorf("%w: there is no maximum to fill to", ErrInvalidBounds)
+	}
+
+	s.c.Fill()
+	return nil
+}
+
+// Floor sets the value to the minimum. Returns ErrInvalidBounds if there is no minimum.
+func (s *StrictClampedIncrementer) Floor() error {
+	if s.c.min.IsUnbounded() {
+		return fmt.Errorf("%w: there is no minimum to floor to", ErrInvalidBounds)
+	}
+
+	s.c.Floor()
+	return nil
+}
+
+// Ratio returns how far the value is from min to max, from 0 to 1. Returns 0 if there is no min or
+// max.
+func (s *StrictClampedIncrementer) Ratio() float64 { return s.c.Ratio() }
+
+// Percent returns how far the value is from min to max, from 0 to 100, rounded down. Returns 0 if
+// there is no min or max.
+func (s *StrictClampedIncrementer) Percent() int { return s.c.Percent() }
+
+// Segments returns how many of n equal segments from min to max the value has completely filled.
+func (s *StrictClampedIncrementer) Segments(n int) int { return s.c.Segments(n) }
+
+// SetFraction sets the value to the given fraction of the way from min to max, rounded with mode.
+// Returns ErrInvalidBounds if there is no min or max, and ErrBelowMin or ErrAboveMax if f is not
+// from 0 to 1.
+func (s *StrictClampedIncrementer) SetFraction(f float64, mode RoundingMode) error {
+	if _, _, ok := s.c.bounds(); !ok {
+		return fmt.Errorf("%w: a fraction needs a min and max", ErrInvalidBounds)
+	}
+
+	switch {
+	case math.IsNaN(f):
+		return fmt.Errorf("invalid fraction: %v", f)
+	case f < 0:
+		return fmt.Errorf("%w: fraction %v < 0", ErrBelowMin, f)
+	case f > 1:
+		return fmt.Errorf("%w: fraction %v > 1", ErrAboveMax, f)
+	}
+
+	s.c.SetFraction(f, mode)
+	return nil
+}
+
+// Empty sets the value to 0. Returns ErrBelowMin or ErrAboveMax if 0 is out of range.
+func (s *StrictClampedIncrementer) Empty() error { return s.SetValue(0) }
+
+// Reset sets the value to the original value.
+func (s *StrictClampedIncrementer) Reset() error { return s.SetValue(s.c.orig) }
+
+// String returns a string representation of the value.
+func (s *StrictClampedIncrementer) String() string { return s.c.String() }
+
+func (s *StrictClampedIncrementer) view() formatView {
+	v := s.c.view()
+	v.name = "StrictClampedIncrementer"
+	return v
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (s *StrictClampedIncrementer) Format(f fmt.State, verb rune) { s.view().format(f, verb) }
+
+// MarshalJSON returns the JSON representation of the ClampedIncrementer.
+func (s *StrictClampedIncrementer) MarshalJSON() ([]byte, error) { return s.c.MarshalJSON() }
+
+// UnmarshalJSON parses the JSON representation of a ClampedIncrementer. Returns ErrInvalidBounds if min
+// is not below max or if the data has no maximum and the StrictClampedIncrementer has one, and
+// ErrBelowMin or ErrAboveMax if the value or original value is out of range.
+func (s *StrictClampedIncrementer) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("StrictClampedIncrementer.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" || string(data) == `""` {
+		return nil
+	}
+
+	var j clampedIncrementerJSON
+	err := unmarshalVersioned(
+		TypeClampedIncrementer, "ClampedIncrementer", data, &j, "min", "max", "incrementer",
+	)
+	if err != nil {
+		return err
+	}
+
+	lo, hasMin := j.Min.Value()
+	hi, hasMax := j.Max.Value()
+	if hasMin && hasMax && lo >= hi {
+		return fmt.Errorf("%w: min %d >= max %d", ErrInvalidBounds, lo, hi)
+	}
+
+	if s.c != nil && !hasMax && !s.c.max.IsUnbounded() {
+		return fmt.Errorf("%w: max %s can not be removed", ErrInvalidBounds, s.c.max)
+	}
+
+	n := &StrictClampedIncrementer{c: &ClampedIncrementer{min: j.Min, max: j.Max, Incrementer: j.Incrementer}}
+	for _, v := range []int{n.c.val, n.c.orig} {
+		if err := n.check(v); err != nil {
+			return err
+		}
+	}
+
+	if s.c == nil {
+		s.c = n.c
+		return nil
+	}
+
+	*s.c = *n.c
+	return nil
+}
+
+// check returns an error if v is outside the bounds.
+func (s *StrictClampedIncrementer) check(v int) error {
+	if v < s.c.Min() {
+		return fmt.Errorf("%w: %d < %d", ErrBelowMin, v, s.c.Min())
+	}
+
+	if v > s.c.Max() {
+		return fmt.Errorf("%w: %d > %d", ErrAboveMax, v, s.c.Max())
+	}
+
+	return nil
+}
+
+// StrictUIncrementer changes a UIncrementer but returns an error, and leaves it unchanged, where the
+// UIncrementer would clamp the change.
+type StrictUIncrementer struct {
+	u *UIncrementer
+}
+
+// NewStrictUIncrementer creates a new StrictUIncrementer with a starting value of val.
+func NewStrictUIncrementer(val int) (*StrictUIncrementer, error) {
+	if val < 0 {
+		return nil, fmt.Errorf("%w: %d < 0", ErrBelowMin, val)
+	}
+
+	u := NewUIncrementerWithValue(val)
+	return &StrictUIncrementer{u: &u}, nil
+}
+
+// WrapStrictU returns a StrictUIncrementer which changes u. u must not be negative.
+func WrapStrictU(u *UIncrementer) (*StrictUIncrementer, error) {
+	if err := u.validate(); err != nil {
+		return nil, err
+	}
+
+	return &StrictUIncrementer{u: u}, nil
+}
+
+// Lenient returns the UIncrementer the StrictUIncrementer changes.
+func (s *StrictUIncrementer) Lenient() *UIncrementer { return s.u }
+
+// Inc returns the incrementer value.
+func (s *StrictUIncrementer) Inc() int { return s.u.inc }
+
+// Value returns the current value.
+func (s *StrictUIncrementer) Value() int { return s.u.val }
+
+// Original returns the original value.
+func (s *StrictUIncrementer) Original() int { return s.u.orig }
+
+// IsEmpty returns true if the value is 0.
+func (s *StrictUIncrementer) IsEmpty() bool { return s.u.IsEmpty() }
+
+// IsUnchanged returns true if the value is the same as the original value.
+func (s *StrictUIncrementer) IsUnchanged() bool { return s.u.IsUnchanged() }
+
+// Increment increases the value by the incrementer value.
+func (s *StrictUIncrementer) Increment() error { return s.Add(s.u.inc) }
+
+// Decrement decreases the value by the incrementer value.
+func (s *StrictUIncrementer) Decrement() error { return s.Remove(s.u.inc) }
+
+// Add increases the value by val. Returns ErrBelowMin or ErrOverflow if the result is out of range.
+func (s *StrictUIncrementer) Add(val int) error {
+	v, err := AddChecked(s.u.val, val)
+	if err != nil {
+		return err
+	}
+
+	return s.SetValue(v)
+}
+
+// Remove decreases the value by val. Returns ErrBelowMin or ErrOverflow if the result is out of range.
+func (s *StrictUIncrementer) Remove(val int) error {
+	v, err := SubChecked(s.u.val, val)
+	if err != nil {
+		return err
+	}
+
+	return s.SetValue(v)
+}
+
+// SetIncrementer sets the incrementer value. Returns ErrNegativeIncrement if inc is negative.
+func (s *StrictUIncrementer) SetIncrementer(inc int) error {
+	if inc < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativeIncrement, inc)
+	}
+
+	s.u.inc = inc
+	return nil
+}
+
+// SetValue sets the value. Returns ErrBelowMin if val is negative.
+func (s *StrictUIncrementer) SetValue(val int) error {
+	if val < 0 {
+		return fmt.Errorf("%w: %d < 0", ErrBelowMin, val)
+	}
+
+	s.u.val = val
+	return nil
+}
+
+// SetOriginalValue sets the original value. Returns ErrBelowMin if val is negative.
+func (s *StrictUIncrementer) SetOriginalValue(val int) error {
+	if val < 0 {
+		return fmt.Errorf("%w: %d < 0", ErrBelowMin, val)
+	}
+
+	s.u.orig = val
+	return nil
+}
+
+// Empty sets the value to 0.
+func (s *StrictUIncrementer) Empty() error { s.u.Empty(); return nil }
+
+// Reset sets the value to the original value.
+func (s *StrictUIncrementer) Reset() error { s.u.Reset(); return nil }
+
+// String returns a string representation of the value.
+func (s *StrictUIncrementer) String() string { return s.u.String() }
+
+func (s *StrictUIncrementer) view() formatView {
+	v := s.u.view()
+	v.name = "StrictUIncrementer"
+	return v
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (s *StrictUIncrementer) Format(f fmt.State, verb rune) { s.view().format(f, verb) }
+
+// MarshalJSON returns the JSON representation of the UIncrementer.
+func (s *StrictUIncrementer) MarshalJSON() ([]byte, error) { return s.u.MarshalJSON() }
+
+// UnmarshalJSON parses the JSON representation of a UIncrementer.
+func (s *StrictUIncrementer) UnmarshalJSON(data []byte) error {
+	var u UIncrementer
+	if err := u.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	if s.u == nil {
+		s.u = &u
+		return nil
+	}
+
+	*s.u = u
+	return nil
+}
@@ -0,0 +1,390 @@
+package incrementers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStrictClampedIncrementer(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		s, err := NewStrictClampedIncrementer(-5, 5, 2)
+		require.NoError(err)
+		require.Equal(-5, s.Min())
+		require.Equal(5, s.Max())
+		require.Equal(2, s.Value())
+		require.Equal(2, s.Original())
+		require.Equal(1, s.Inc())
+	})
+
+	t.Run("invalid bounds", func(t *testing.T) {
+		_, err := NewStrictClampedIncrementer(5, 5, 5)
+		require.ErrorIs(err, ErrInvalidBounds)
+		require.Equal("invalid bounds: min 5 >= max 5", err.Error())
+	})
+
+	t.Run("no min", func(t *testing.T) {
+		s, err := NewStrictClampedIncrementerWithBounds(Unbounded(), BoundAt(0), -20)
+		require.NoError(err)
+		require.Equal(math.MinInt, s.Min())
+		require.ErrorIs(s.Add(21), ErrAboveMax)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := NewStrictClampedIncrementer(0, 5, 6)
+		require.ErrorIs(err, ErrAboveMax)
+		require.Equal("above the maximum: 6 > 5", err.Error())
+
+		_, err = NewStrictClampedIncrementer(0, 5, -1)
+		require.ErrorIs(err, ErrBelowMin)
+		require.Equal("below the minimum: -1 < 0", err.Error())
+	})
+}
+
+func TestNewStrictCounter(t *testing.T) {
+	require := require.New(t)
+	c, err := NewStrictCounter(3)
+	require.NoError(err)
+	require.NoError(c.Add(100))
+	require.Equal(103, c.Value())
+
+	_, err = NewStrictCounter(-1)
+	require.ErrorIs(err, ErrBelowMin)
+}
+
+func TestNewStrictClock(t *testing.T) {
+	require := require.New(t)
+	c, err := NewStrictClock(4, 1)
+	require.NoError(err)
+	require.Equal(4, c.Max())
+	require.Equal(1, c.Value())
+
+	_, err = NewStrictClock(0, 0)
+	require.ErrorIs(err, ErrInvalidBounds)
+	require.Equal("invalid bounds: steps must be greater than 0", err.Error())
+
+	_, err = NewStrictClock(4, 5)
+	require.ErrorIs(err, ErrAboveMax)
+}
+
+func TestWrapStrict(t *testing.T) {
+	require := require.New(t)
+	c := NewClampedIncrementerWithValue(0, 10, 4)
+	s, err := WrapStrict(&c)
+	require.NoError(err)
+	require.Same(&c, s.Lenient())
+
+	require.NoError(s.Add(3))
+	require.Equal(7, c.Value(), "WrapStrict() did not change the wrapped value")
+
+	c.max = BoundAt(-1)
+	_, err = WrapStrict(&c)
+	require.Error(err, "WrapStrict() did not return an error")
+}
+
+func TestStrictClampedIncrementerAdd(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("in range", func(t *testing.T) {
+		s, _ := NewStrictClampedIncrementer(0, 10, 5)
+		require.NoError(s.Increment())
+		require.NoError(s.Add(4))
+		require.Equal(10, s.Value())
+		require.True(s.IsFull())
+	})
+
+	t.Run("above max", func(t *testing.T) {
+		s, _ := NewStrictClampedIncrementer(0, 10, 9)
+		err := s.Add(2)
+		require.ErrorIs(err, ErrAboveMax)
+		require.Equal("above the maximum: 11 > 10", err.Error())
+		require.Equal(9, s.Value(), "the value changed after an error")
+	})
+
+	t.Run("below min", func(t *testing.T) {
+		s, _ := NewStrictClampedIncrementer(0, 10, 1)
+		require.ErrorIs(s.Add(-2), ErrBelowMin)
+		require.ErrorIs(s.Remove(2), ErrBelowMin)
+		require.NoError(s.Decrement())
+		require.ErrorIs(s.Decrement(), ErrBelowMin)
+		require.Equal(0, s.Value())
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		s, _ := NewStrictClampedIncrementerWithBounds(BoundAt(0), Unbounded(), 1)
+		require.ErrorIs(s.Add(math.MaxInt), ErrOverflow)
+		require.ErrorIs(s.Remove(math.MinInt), ErrOverflow)
+		require.Equal(1, s.Value())
+	})
+}
+
+func TestStrictClampedIncrementerSetMin(t *testing.T) {
+	require := require.New(t)
+	s, _ := NewStrictClampedIncrementer(0, 10, 5)
+
+	err := s.SetMin(10)
+	require.ErrorIs(err, ErrInvalidBounds)
+	require.Equal("invalid bounds: min 10 >= max 10", err.Error())
+
+	err = s.SetMin(6)
+	require.ErrorIs(err, ErrBelowMin)
+	require.Equal("below the minimum: 5 < 6", err.Error())
+	require.Equal(0, s.Min())
+
+	require.NoError(s.SetMin(-3))
+	require.Equal(-3, s.Min())
+}
+
+func TestStrictClampedIncrementerSetMax(t *testing.T) {
+	require := require.New(t)
+	s, _ := NewStrictClampedIncrementer(2, 10, 5)
+
+	t.Run("below min", func(t *testing.T) {
+		err := s.SetMax(0)
+		require.ErrorIs(err, ErrInvalidBounds)
+		require.Equal("invalid bounds: min 2 >= max 0", err.Error())
+		require.ErrorIs(s.SetMax(2), ErrInvalidBounds)
+		require.Equal(10, s.Max())
+	})
+
+	t.Run("below value", func(t *testing.T) {
+		err := s.SetMax(4)
+		require.ErrorIs(err, ErrAboveMax)
+		require.Equal("above the maximum: 5 > 4", err.Error())
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(s.SetMax(5))
+		require.Equal(5, s.Max())
+		require.True(s.IsFull())
+	})
+
+	t.Run("clock", func(t *testing.T) {
+		c, _ := NewStrictClock(6, 0)
+		require.ErrorIs(c.SetMax(0), ErrInvalidBounds)
+		require.Equal(6, c.Max(), "SetMax(0) turned the clock into a counter")
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		s, _ := NewStrictClampedIncrementer(-10, -1, -5)
+		require.NoError(s.SetMax(0))
+		require.NoError(s.Add(5))
+		require.True(s.IsFull())
+	})
+
+	t.Run("bounds", func(t *testing.T) {
+		s, _ := NewStrictClampedIncrementer(0, 10, 5)
+		err := s.SetMaxBound(Unbounded())
+		require.ErrorIs(err, ErrInvalidBounds)
+		require.Equal("invalid bounds: max 10 can not be removed", err.Error())
+		require.Equal(BoundAt(10), s.MaxBound())
+
+		s, _ = NewStrictClampedIncrementerWithBounds(BoundAt(0), Unbounded(), 5)
+		require.NoError(s.SetMaxBound(Unbounded()))
+		require.NoError(s.Add(100))
+		require.ErrorIs(s.SetMaxBound(BoundAt(50)), ErrAboveMax)
+		require.NoError(s.SetMinBound(Unbounded()))
+		require.ErrorIs(s.Floor(), ErrInvalidBounds)
+		require.ErrorIs(s.SetMinBound(BoundAt(200)), ErrBelowMin)
+		require.NoError(s.SetMinBound(BoundAt(-5)))
+		require.Equal(BoundAt(-5), s.MinBound())
+		require.True(s.MaxBound().IsUnbounded())
+	})
+}
+
+func TestStrictClampedIncrementerSet(t *testing.T) {
+	require := require.New(t)
+	s, _ := NewStrictClampedIncrementer(1, 10, 5)
+
+	t.Run("incrementer", func(t *testing.T) {
+		err := s.SetIncrementer(-2)
+		require.ErrorIs(err, ErrNegativeIncrement)
+		require.Equal("negative incrementer value: -2", err.Error())
+		require.Equal(1, s.Inc())
+
+		require.NoError(s.SetIncrementer(3))
+		require.Equal(3, s.Inc())
+	})
+
+	t.Run("value", func(t *testing.T) {
+		require.ErrorIs(s.SetValue(11), ErrAboveMax)
+		require.ErrorIs(s.SetValue(0), ErrBelowMin)
+		require.NoError(s.SetValue(8))
+		require.Equal(8, s.Value())
+	})
+
+	t.Run("original", func(t *testing.T) {
+		require.ErrorIs(s.SetOriginalValue(11), ErrAboveMax)
+		require.ErrorIs(s.SetOriginalValue(0), ErrBelowMin)
+		require.NoError(s.SetOriginalValue(2))
+		require.Equal(2, s.Original())
+	})
+}
+
+func TestStrictClampedIncrementerFill(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("bounded", func(t *testing.T) {
+		s, _ := NewStrictClampedIncrementer(1, 10, 5)
+		require.NoError(s.Fill())
+		require.Equal(10, s.Value())
+		require.NoError(s.Floor())
+		require.Equal(1, s.Value())
+		require.NoError(s.Reset())
+		require.Equal(5, s.Value())
+
+		err := s.Empty()
+		require.ErrorIs(err, ErrBelowMin)
+		require.Equal(5, s.Value())
+	})
+
+	t.Run("unbounded", func(t *testing.T) {
+		s, _ := NewStrictClampedIncrementerWithBounds(BoundAt(0), Unbounded(), 5)
+		err := s.Fill()
+		require.ErrorIs(err, ErrInvalidBounds)
+		require.Equal("invalid bounds: there is no maximum to fill to", err.Error())
+		require.NoError(s.Empty())
+		require.True(s.IsEmpty())
+	})
+}
+
+func TestStrictClampedIncrementerSetFraction(t *testing.T) {
+	require := require.New(t)
+	clock, _ := NewStrictClock(8, 0)
+	c, ok := clock.(StrictProgress)
+	require.True(ok, "StrictClock is not a StrictProgress")
+	require.NoError(c.SetFraction(0.3, RoundNearest))
+	require.Equal(2, clock.Value())
+	require.Equal(1, c.Segments(4))
+	require.Equal(25, c.Percent())
+	require.Equal(0.25, c.Ratio())
+
+	err := c.SetFraction(1.1, RoundDown)
+	require.ErrorIs(err, ErrAboveMax)
+	require.Equal("above the maximum: fraction 1.1 > 1", err.Error())
+	require.ErrorIs(c.SetFraction(-0.1, RoundDown), ErrBelowMin)
+	require.EqualError(c.SetFraction(math.NaN(), RoundDown), "invalid fraction: NaN")
+	require.Equal(2, clock.Value())
+
+	s, _ := NewStrictCounter(3)
+	err = s.(*StrictClampedIncrementer).SetFraction(0.5, RoundDown)
+	require.ErrorIs(err, ErrInvalidBounds)
+	require.Equal("invalid bounds: a fraction needs a min and max", err.Error())
+}
+
+func TestStrictClampedIncrementerJSON(t *testing.T) {
+	require := require.New(t)
+	s, _ := NewStrictClampedIncrementer(0, 10, 5)
+	data, err := s.MarshalJSON()
+	require.NoError(err)
+
+	var got StrictClampedIncrementer
+	require.NoError(got.UnmarshalJSON(data))
+	require.Equal(s.Lenient(), got.Lenient())
+	require.Equal(s.String(), got.String())
+
+	tests := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{"min above max", `{"version":3,"min":5,"max":1,"incrementer":{"inc":1,"val":2,"orig":2}}`, ErrInvalidBounds},
+		{"max removed", `{"version":3,"min":0,"max":null,"incrementer":{"inc":1,"val":2,"orig":2}}`, ErrInvalidBounds},
+		{"migrated max removed", `{"version":2,"min":0,"max":0,"incrementer":{"inc":1,"val":2,"orig":2}}`, ErrInvalidBounds},
+		{"below min", `{"version":3,"min":0,"max":10,"incrementer":{"inc":1,"val":-1,"orig":2}}`, ErrBelowMin},
+		{"above max", `{"version":3,"min":0,"max":10,"incrementer":{"inc":1,"val":2,"orig":11}}`, ErrAboveMax},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := got.UnmarshalJSON([]byte(tt.data))
+			require.ErrorIs(err, tt.err)
+			require.Equal(s.Lenient(), got.Lenient(), "Strict.UnmarshalJSON() changed the value on error")
+		})
+	}
+
+	t.Run("counter", func(t *testing.T) {
+		c, _ := NewStrictCounter(5)
+		data, err := c.MarshalJSON()
+		require.NoError(err)
+
+		var got StrictClampedIncrementer
+		require.NoError(got.UnmarshalJSON(data))
+		require.True(got.MaxBound().IsUnbounded())
+	})
+}
+
+func TestNewStrictUIncrementer(t *testing.T) {
+	require := require.New(t)
+	u, err := NewStrictUIncrementer(3)
+	require.NoError(err)
+	require.Equal(3, u.Value())
+	require.Equal(3, u.Original())
+	require.True(u.IsUnchanged())
+
+	_, err = NewStrictUIncrementer(-1)
+	require.ErrorIs(err, ErrBelowMin)
+	require.Equal("below the minimum: -1 < 0", err.Error())
+
+	l := NewUIncrementerWithValue(2)
+	w, err := WrapStrictU(&l)
+	require.NoError(err)
+	require.NoError(w.Increment())
+	require.Equal(3, l.Value(), "WrapStrictU() did not change the wrapped value")
+	require.Same(&l, w.Lenient())
+
+	l.val = -1
+	_, err = WrapStrictU(&l)
+	require.Error(err, "WrapStrictU() did not return an error")
+}
+
+func TestStrictUIncrementer(t *testing.T) {
+	require := require.New(t)
+	u, _ := NewStrictUIncrementer(2)
+
+	t.Run("below zero", func(t *testing.T) {
+		require.ErrorIs(u.Remove(3), ErrBelowMin)
+		require.ErrorIs(u.Add(-3), ErrBelowMin)
+		require.ErrorIs(u.SetValue(-1), ErrBelowMin)
+		require.ErrorIs(u.SetOriginalValue(-1), ErrBelowMin)
+		require.Equal(2, u.Value())
+		require.Equal(2, u.Original())
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		require.ErrorIs(u.Add(math.MaxInt), ErrOverflow)
+		require.Equal(2, u.Value())
+	})
+
+	t.Run("incrementer", func(t *testing.T) {
+		err := u.SetIncrementer(-1)
+		require.ErrorIs(err, ErrNegativeIncrement)
+		require.Equal(1, u.Inc())
+
+		require.NoError(u.SetIncrementer(2))
+		require.NoError(u.Decrement())
+		require.True(u.IsEmpty())
+		require.ErrorIs(u.Decrement(), ErrBelowMin)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		require.NoError(u.SetOriginalValue(4))
+		require.NoError(u.Reset())
+		require.Equal(4, u.Value())
+		require.NoError(u.Empty())
+		require.Equal(0, u.Value())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := u.MarshalJSON()
+		require.NoError(err)
+
+		var got StrictUIncrementer
+		require.NoError(got.UnmarshalJSON(data))
+		require.Equal(u.Lenient(), got.Lenient())
+		require.Equal(u.String(), got.String())
+	})
+}
@@ -0,0 +1,225 @@
+package incrementers
+
+import (
+	"fmt"
+)
+
+// StopCondition reports whether a TickingIncrementer should stop ticking. It is checked after every
+// tick that changes the value.
+type StopCondition func(c ClampedIncrementer) bool
+
+// StopAtMin stops a TickingIncrementer once it reaches its minimum value.
+func StopAtMin(c ClampedIncrementer) bool { return c.Value() == c.Min() }
+
+// StopAtMax stops a TickingIncrementer once it reaches its maximum value.
+func StopAtMax(c ClampedIncrementer) bool { return c.IsFull() }
+
+// TickingIncrementer is a ClampedIncrementer that changes by itself every tick. A positive rate
+// regenerates and a negative rate decays. Ticking can start after a delay, stop after a number of
+// ticks, or stop when a StopCondition is met.
+type TickingIncrementer struct {
+	ClampedIncrementer
+	rate     int
+	delay    int // Ticks left before the rate is applied.
+	duration int // Number of ticks to apply the rate for. 0 means no limit.
+	ticks    int // Number of ticks the rate has been applied.
+	stopped  bool
+	stop     StopCondition
+}
+
+// NewTickingIncrementer creates a new TickingIncrementer which changes c by rate every tick.
+func NewTickingIncrementer(c ClampedIncrementer, rate int) TickingIncrementer {
+	return TickingIncrementer{ClampedIncrementer: c, rate: rate}
+}
+
+// NewTickingIncrementerFromJSON creates a new TickingIncrementer from a JSON representation. Stop
+// conditions are not part of the JSON representation and must be set again.
+func NewTickingIncrementerFromJSON(data []byte) (TickingIncrementer, error) {
+	var t TickingIncrementer
+	err := t.UnmarshalJSON(data)
+	return t, err
+}
+
+// Rate returns the amount the value changes each tick.
+func (t TickingIncrementer) Rate() int { return t.rate }
+
+// Delay returns the number of ticks left before the rate is applied.
+func (t TickingIncrementer) Delay() int { return t.delay }
+
+// Duration returns the number of ticks the rate is applied for. 0 means no limit.
+func (t TickingIncrementer) Duration() int { return t.duration }
+
+// Ticks returns the number of ticks the rate has been applied.
+func (t TickingIncrementer) Ticks() int { return t.ticks }
+
+func (t TickingIncrementer) view() formatView {
+	v := t.ClampedIncrementer.view()
+	v.name = "TickingIncrementer"
+	v.fields = append(v.fields, formatField{"rate", t.rate}, formatField{"delay", t.delay},
+		formatField{"duration", t.duration}, formatField{"ticks", t.ticks}, formatField{"stopped", t.stopped})
+	return v
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (t TickingIncrementer) Format(f fmt.State, verb rune) { t.view().format(f, verb) }
+
+// Remaining returns the number of ticks left before the duration runs out. Returns -1 if there is no
+// duration.
+func (t TickingIncrementer) Remaining() int {
+	if t.duration == 0 {
+		return -1
+	}
+
+	return ClampMin(t.duration-t.ticks, 0)
+}
+
+// IsStopped returns true if the TickingIncrementer will no longer change on Tick.
+func (t TickingIncrementer) IsStopped() bool { return t.stopped }
+
+// SetRate sets the amount the value changes each tick.
+func (t *TickingIncrementer) SetRate(rate int) { t.rate = rate }
+
+// SetDelay sets the number of ticks to wait before applying the rate.
+func (t *TickingIncrementer) SetDelay(delay int) { t.delay = ClampMin(delay, 0) }
+
+// SetDuration sets the number of ticks to apply the rate for, counting from now. 0 means no limit.
+func (t *TickingIncrementer) SetDuration(duration int) {
+	t.duration = ClampMin(duration, 0)
+	t.ticks = 0
+}
+
+// SetStopCondition sets the condition which stops the TickingIncrementer. nil removes the condition.
+func (t *TickingIncrementer) SetStopCondition(stop StopCondition) { t.stop = stop }
+
+// Stop stops the TickingIncrementer from changing on Tick.
+func (t *TickingIncrementer) Stop() { t.stopped = true }
+
+// Start lets a stopped TickingIncrementer change on Tick again. The duration starts over.
+func (t *TickingIncrementer) Start() { t.stopped = false; t.ticks = 0 }
+
+// Tick advances the TickingIncrementer by 1 tick and returns the amount the value changed.
+func (t *TickingIncrementer) Tick() int {
+	if t.stopped {
+		return 0
+	}
+
+	if t.delay > 0 {
+		t.delay--
+		return 0
+	}
+
+	before := t.val
+	t.Add(t.rate)
+	t.ticks++
+
+	if t.duration > 0 && t.ticks >= t.duration {
+		t.stopped = true
+	}
+
+	if t.stop != nil && t.stop(t.ClampedIncrementer) {
+		t.stopped = true
+	}
+
+	return SubSaturating(t.val, before)
+}
+
+// TickN advances the TickingIncrementer by n ticks and returns the total amount the value changed.
+func (t *TickingIncrementer) TickN(n int) int {
+	var total int
+	for range n {
+		total = AddSaturating(total, t.Tick())
+	}
+
+	return total
+}
+
+// MarshalJSON returns a JSON representation of the TickingIncrementer.
+func (t TickingIncrementer) MarshalJSON() ([]byte, error) {
+	c, _ := t.ClampedIncrementer.MarshalJSON()
+	return []byte(fmt.Sprintf(
+		`{"version":%d,"rate":%d,"delay":%d,"duration":%d,"ticks":%d,"stopped":%t,"clamped":%s}`,
+		tickingVersion, t.rate, t.delay, t.duration, t.ticks, t.stopped, c,
+	)), nil
+}
+
+type tickingIncrementerJSON struct {
+	Rate     int                `json:"rate"`
+	Delay    int                `json:"delay"`
+	Duration int                `json:"duration"`
+	Ticks    int                `json:"ticks"`
+	Stopped  bool               `json:"stopped"`
+	Clamped  ClampedIncrementer `json:"clamped"`
+}
+
+// UnmarshalJSON parses a JSON representation of the TickingIncrementer. Older versions are migrated to
+// the latest version first.
+func (t *TickingIncrementer) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("TickingIncrementer.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" || string(data) == `""` {
+		return nil
+	}
+
+	var j tickingIncrementerJSON
+	err := unmarshalVersioned(TypeTickingIncrementer, "TickingIncrementer", data, &j, "rate", "clamped")
+	if err != nil {
+		return err
+	}
+
+	return t.set(j.Rate, j.Delay, j.Duration, j.Ticks, j.Stopped, j.Clamped)
+}
+
+// MarshalBinary returns a compact binary representation of the TickingIncrementer. Stop conditions are
+// not included.
+func (t TickingIncrementer) MarshalBinary() ([]byte, error) {
+	w := newBinaryWriter()
+	w.int(t.rate, t.delay, t.duration, t.ticks)
+	w.bool(t.stopped)
+	w.clamped(t.ClampedIncrementer)
+	return w.buf, nil
+}
+
+// UnmarshalBinary parses a binary representation of the TickingIncrementer. The stop condition is
+// kept.
+func (t *TickingIncrementer) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader("TickingIncrementer", data)
+	if err != nil {
+		return err
+	}
+
+	rate, delay, duration, ticks := r.int(), r.int(), r.int(), r.int()
+	stopped := r.bool()
+	c := r.clamped()
+	if err := r.close(); err != nil {
+		return err
+	}
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	return t.set(rate, delay, duration, ticks, stopped, c)
+}
+
+// MarshalText returns a compact text representation of the TickingIncrementer.
+func (t TickingIncrementer) MarshalText() ([]byte, error) { return marshalText(t) }
+
+// UnmarshalText parses a text representation of the TickingIncrementer.
+func (t *TickingIncrementer) UnmarshalText(text []byte) error { return unmarshalText(t, text) }
+
+// set replaces the state of the TickingIncrementer after checking it.
+func (t *TickingIncrementer) set(rate, delay, duration, ticks int, stopped bool, c ClampedIncrementer) error {
+	if delay < 0 || duration < 0 || ticks < 0 {
+		return fmt.Errorf("invalid TickingIncrementer: delay, duration and ticks must be 0 or greater")
+	}
+
+	t.rate = rate
+	t.delay = delay
+	t.duration = duration
+	t.ticks = ticks
+	t.stopped = stopped
+	t.ClampedIncrementer = c
+	return nil
+}
@@ -0,0 +1,180 @@
+package incrementers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTickingIncrementerNew(t *testing.T) {
+	require := require.New(t)
+	ti := NewTickingIncrementer(NewClampedIncrementerWithValue(0, 10, 4), 2)
+	require.Equal(2, ti.Rate())
+	require.Equal(4, ti.Value())
+	require.Equal(0, ti.Delay())
+	require.Equal(0, ti.Duration())
+	require.Equal(-1, ti.Remaining())
+	require.False(ti.IsStopped())
+}
+
+func TestTickingIncrementerTick(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("regenerate", func(t *testing.T) {
+		mana := NewTickingIncrementer(NewClampedIncrementerWithValue(0, 10, 5), 2)
+		for _, want := range []struct{ change, val int }{{2, 7}, {2, 9}, {1, 10}, {0, 10}} {
+			require.Equal(want.change, mana.Tick())
+			require.Equal(want.val, mana.Value())
+		}
+
+		require.Equal(4, mana.Ticks())
+		require.False(mana.IsStopped())
+	})
+
+	t.Run("decay", func(t *testing.T) {
+		poison := NewTickingIncrementer(NewClampedIncrementerWithValue(0, 10, 3), -1)
+		for _, want := range []int{2, 1, 0, 0} {
+			poison.Tick()
+			require.Equal(want, poison.Value())
+		}
+	})
+
+	t.Run("delay", func(t *testing.T) {
+		fatigue := NewTickingIncrementer(NewClampedIncrementerWithValue(0, 6, 6), -1)
+		fatigue.SetDelay(2)
+		for _, want := range []struct{ delay, val int }{{1, 6}, {0, 6}, {0, 5}, {0, 4}} {
+			fatigue.Tick()
+			require.Equal(want.delay, fatigue.Delay())
+			require.Equal(want.val, fatigue.Value())
+		}
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		regen := NewTickingIncrementer(NewClampedIncrementerWithValue(0, 10, 0), 1)
+		regen.SetDuration(3)
+		require.Equal(3, regen.Remaining())
+
+		require.Equal(3, regen.TickN(5))
+		require.Equal(3, regen.Value())
+		require.Equal(0, regen.Remaining())
+		require.True(regen.IsStopped())
+	})
+
+	t.Run("stop condition", func(t *testing.T) {
+		poison := NewTickingIncrementer(NewClampedIncrementerWithValue(0, 10, 2), -1)
+		poison.SetStopCondition(StopAtMin)
+		poison.Tick()
+		require.False(poison.IsStopped())
+
+		poison.Tick()
+		require.True(poison.IsStopped())
+
+		poison.Add(5)
+		require.Equal(0, poison.Tick())
+		require.Equal(5, poison.Value())
+	})
+
+	t.Run("stop at max", func(t *testing.T) {
+		mana := NewTickingIncrementer(NewClampedIncrementerWithValue(0, 3, 0), 2)
+		mana.SetStopCondition(StopAtMax)
+		mana.TickN(2)
+		require.True(mana.IsStopped())
+		require.Equal(3, mana.Value())
+	})
+}
+
+func TestTickingIncrementerStartStop(t *testing.T) {
+	require := require.New(t)
+	ti := NewTickingIncrementer(NewClampedIncrementerWithValue(0, 10, 0), 1)
+	ti.SetDuration(2)
+
+	ti.Stop()
+	require.Equal(0, ti.Tick())
+	require.Equal(0, ti.Value())
+
+	ti.Start()
+	require.Equal(2, ti.TickN(3))
+	require.True(ti.IsStopped())
+
+	ti.Start()
+	require.Equal(2, ti.Remaining())
+	require.Equal(1, ti.Tick())
+	require.Equal(3, ti.Value())
+}
+
+func TestTickingIncrementerSetters(t *testing.T) {
+	require := require.New(t)
+	ti := NewTickingIncrementer(NewClampedIncrementer(0, 10), 1)
+
+	ti.SetRate(-3)
+	require.Equal(-3, ti.Rate())
+
+	ti.SetDelay(-1)
+	require.Equal(0, ti.Delay())
+
+	ti.SetDuration(-1)
+	require.Equal(0, ti.Duration())
+}
+
+func TestTickingIncrementerMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	ti := NewTickingIncrementer(NewClampedIncrementerWithValue(0, 10, 5), -1)
+	ti.SetDelay(1)
+	ti.SetDuration(4)
+	ti.TickN(2)
+
+	data, err := ti.MarshalJSON()
+	require.NoError(err, "TickingIncrementer.MarshalJSON() returned an error: %s", err)
+	require.Equal(
+		`{"version":2,"rate":-1,"delay":0,"duration":4,"ticks":1,"stopped":false,`+
+			`"clamped":{"version":3,"min":0,"max":10,"incrementer":{"version":2,"inc":1,"val":4,"orig":5}}}`,
+		string(data),
+	)
+}
+
+func TestTickingIncrementerUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		ti, err := NewTickingIncrementerFromJSON([]byte(
+			`{"rate":-1,"delay":0,"duration":4,"ticks":1,"stopped":false,` +
+				`"clamped":{"min":0,"max":10,"incrementer":{"inc":1,"val":4,"orig":5}}}`,
+		))
+		require.NoError(err, "TickingIncrementer.UnmarshalJSON() returned an error: %s", err)
+		require.Equal(-1, ti.Rate())
+		require.Equal(3, ti.Remaining())
+		require.Equal(4, ti.Value())
+		require.Equal(10, ti.Max())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var ti TickingIncrementer
+		err := ti.UnmarshalJSON(nil)
+		require.Error(err, "TickingIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("TickingIncrementer.UnmarshalJSON(): data was nil", err.Error())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var ti TickingIncrementer
+		require.NoError(ti.UnmarshalJSON([]byte(`null`)))
+		require.Equal(0, ti.Rate())
+	})
+
+	t.Run("invalid delay", func(t *testing.T) {
+		_, err := NewTickingIncrementerFromJSON([]byte(
+			`{"rate":-1,"delay":-1,"duration":4,"ticks":1,"stopped":false,` +
+				`"clamped":{"min":0,"max":10,"incrementer":{"inc":1,"val":4,"orig":5}}}`,
+		))
+		require.Error(err, "TickingIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("invalid TickingIncrementer: delay, duration and ticks must be 0 or greater", err.Error())
+	})
+
+	t.Run("invalid clamped", func(t *testing.T) {
+		_, err := NewTickingIncrementerFromJSON([]byte(
+			`{"rate":-1,"delay":0,"duration":4,"ticks":1,"stopped":false,` +
+				`"clamped":{"min":0,"max":10,"incrementer":{"inc":1,"val":11,"orig":5}}}`,
+		))
+		require.Error(err, "TickingIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("invalid ClampedIncrementer: Incrementer.val must min <= val <= max", err.Error())
+	})
+}
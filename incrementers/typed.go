@@ -0,0 +1,288 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Type names of the incrementers in this package used by Typed JSON.
+const (
+	TypeIncrementer         = "incrementer"
+	TypeUIncrementer        = "uincrementer"
+	TypeClampedIncrementer  = "clamped"
+	TypeCounter             = "counter"
+	TypeClock               = "clock"
+	TypeWrappingIncrementer = "wrapping"
+	TypeCascadingCounter    = "cascading"
+	TypePool                = "pool"
+	TypeTickingIncrementer  = "ticking"
+	TypeModifierStack       = "modifiers"
+	TypeGCounter            = "gcounter"
+	TypePNCounter           = "pncounter"
+	TypeBoundedCounter      = "bounded"
+	TypeSnappedIncrementer  = "snapped"
+	TypeSequenceIncrementer = "sequence"
+)
+
+// Decoder decodes the JSON representation of a registered type. Decoders should return a pointer so
+// the decoded value can be changed.
+type Decoder func(data []byte) (json.Marshaler, error)
+
+var (
+	typesMu sync.RWMutex
+	types   = make(map[string]Decoder)
+	names   = make(map[reflect.Type]string) // Name of each Go type registered with RegisterTypeOf.
+)
+
+func init() {
+	// ClampedIncrementer is registered before Counter and Clock so it is the name of its Go type.
+	for _, t := range []struct {
+		name   string
+		v      json.Marshaler
+		decode Decoder
+	}{
+		{TypeIncrementer, &Incrementer{}, pointerDecoder(NewIncrementerFromJSON)},
+		{TypeUIncrementer, &UIncrementer{}, pointerDecoder(NewUIncrementerFromJSON)},
+		{TypeClampedIncrementer, &ClampedIncrementer{}, pointerDecoder(NewClampedIncrementerFromJSON)},
+		{TypeCounter, &ClampedIncrementer{}, func(data []byte) (json.Marshaler, error) { return NewCounterFromJSON(data) }},
+		{TypeClock, &ClampedIncrementer{}, func(data []byte) (json.Marshaler, error) { return NewClockFromJSON(data) }},
+		{TypeWrappingIncrementer, &WrappingIncrementer{}, pointerDecoder(NewWrappingIncrementerFromJSON)},
+		{TypeCascadingCounter, &CascadingCounter{}, pointerDecoder(NewCascadingCounterFromJSON)},
+		{TypePool, &Pool{}, pointerDecoder(NewPoolFromJSON)},
+		{TypeTickingIncrementer, &TickingIncrementer{}, pointerDecoder(NewTickingIncrementerFromJSON)},
+		{TypeModifierStack, &ModifierStack{}, pointerDecoder(NewModifierStackFromJSON)},
+		{TypeGCounter, &GCounter{}, func(data []byte) (json.Marshaler, error) { return NewGCounterFromJSON(data) }},
+		{TypePNCounter, &PNCounter{}, func(data []byte) (json.Marshaler, error) { return NewPNCounterFromJSON(data) }},
+		{TypeBoundedCounter, &BoundedCounter{}, func(data []byte) (json.Marshaler, error) {
+			return NewBoundedCounterFromJSON(data)
+		}},
+		{TypeSnappedIncrementer, &SnappedIncrementer{}, pointerDecoder(NewSnappedIncrementerFromJSON)},
+		{TypeSequenceIncrementer, &SequenceIncrementer{}, pointerDecoder(NewSequenceIncrementerFromJSON)},
+	} {
+		if err := RegisterTypeOf(t.name, t.v, t.decode); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// pointerDecoder turns a NewXFromJSON function into a Decoder returning *X.
+func pointerDecoder[T any, PT interface {
+	*T
+	json.Marshaler
+}](fn func([]byte) (T, error)) Decoder {
+	return func(data []byte) (json.Marshaler, error) {
+		v, err := fn(data)
+		if err != nil {
+			return nil, err
+		}
+
+		return PT(&v), nil
+	}
+}
+
+// RegisterType adds a type to Typed JSON decoding so it can be stored alongside the incrementers in
+// this package. Names must be unique.
+func RegisterType(name string, decode Decoder) error {
+	if name == "" {
+		return fmt.Errorf("invalid type: name must not be empty")
+	}
+
+	if decode == nil {
+		return fmt.Errorf("invalid type: %s decoder must not be nil", name)
+	}
+
+	typesMu.Lock()
+	defer typesMu.Unlock()
+
+	if _, ok := types[name]; ok {
+		return fmt.Errorf("invalid type: %s is already registered", name)
+	}
+
+	types[name] = decode
+	return nil
+}
+
+// RegisterTypeOf is like RegisterType and also records the Go type of v, so MarshalTyped and
+// NewTypedOf can find name from a value of that type. v and *v are the same type. If a Go type is
+// registered under more than one name, the first name is used.
+func RegisterTypeOf(name string, v json.Marshaler, decode Decoder) error {
+	if v == nil {
+		return fmt.Errorf("invalid type: %s value must not be nil", name)
+	}
+
+	if err := RegisterType(name, decode); err != nil {
+		return err
+	}
+
+	typesMu.Lock()
+	defer typesMu.Unlock()
+
+	if _, ok := names[goType(v)]; !ok {
+		names[goType(v)] = name
+	}
+
+	return nil
+}
+
+// TypeOf returns the name registered with RegisterTypeOf for the Go type of v.
+func TypeOf(v json.Marshaler) (string, bool) {
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+
+	name, ok := names[goType(v)]
+	return name, ok
+}
+
+// goType returns the type of v, or the type it points to if v is a pointer.
+func goType(v any) reflect.Type {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+
+	return t
+}
+
+// IsRegisteredType returns true if name has been registered with RegisterType.
+func IsRegisteredType(name string) bool {
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+
+	_, ok := types[name]
+	return ok
+}
+
+// RegisteredTypes returns the sorted names of every registered type.
+func RegisteredTypes() []string {
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Typed is an incrementer paired with its type name so it can be encoded to and decoded from JSON
+// without knowing its type ahead of time.
+//
+//	{"type":"clock","data":{"version":3,"min":0,"max":4,"incrementer":{"version":2,"inc":1,"val":2,"orig":0}}}
+type Typed struct {
+	Type  string
+	Value json.Marshaler
+}
+
+// NewTyped creates a new Typed from a registered type name and a value of that type.
+func NewTyped(typ string, v json.Marshaler) Typed { return Typed{Type: typ, Value: v} }
+
+// NewTypedOf creates a new Typed from v and the name registered for its Go type with RegisterTypeOf.
+func NewTypedOf(v json.Marshaler) (Typed, error) {
+	name, ok := TypeOf(v)
+	if !ok {
+		return Typed{}, fmt.Errorf("invalid Typed: %T is not a registered type", v)
+	}
+
+	return NewTyped(name, v), nil
+}
+
+// MarshalTyped returns the Typed JSON representation of v under the name registered for its Go type.
+// Use NewTyped to pick another name for the same Go type, such as TypeClock.
+func MarshalTyped(v json.Marshaler) ([]byte, error) {
+	t, err := NewTypedOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.MarshalJSON()
+}
+
+// UnmarshalTyped decodes a Typed JSON representation into the registered type it names.
+func UnmarshalTyped(data []byte) (Typed, error) {
+	var t Typed
+	err := t.UnmarshalJSON(data)
+	return t, err
+}
+
+type typedJSON struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// MarshalJSON returns a JSON representation of the Typed value. The value must decode as its type
+// name, so a mislabeled value fails when it is saved instead of when it is loaded.
+func (t Typed) MarshalJSON() ([]byte, error) {
+	if !IsRegisteredType(t.Type) {
+		return nil, fmt.Errorf("invalid Typed: unknown type %s", t.Type)
+	}
+
+	if t.Value == nil {
+		return nil, fmt.Errorf("invalid Typed: value must not be nil")
+	}
+
+	data, err := t.Value.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := checkTyped(t.Type, t.Value, data); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(typedJSON{Type: t.Type, Data: data})
+}
+
+// UnmarshalJSON parses a JSON representation of a Typed value using the decoder registered for its
+// type.
+func (t *Typed) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("Typed.UnmarshalJSON(): data was nil")
+	}
+
+	var j typedJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	v, err := decodeTyped(j.Type, j.Data)
+	if err != nil {
+		return err
+	}
+
+	t.Type = j.Type
+	t.Value = v
+
+	return nil
+}
+
+func decodeTyped(typ string, data []byte) (json.Marshaler, error) {
+	typesMu.RLock()
+	decode, ok := types[typ]
+	typesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("invalid Typed: unknown type %s", typ)
+	}
+
+	return decode(data)
+}
+
+// checkTyped returns the value decoded from data, the JSON of v, or an error if data does not decode
+// as typ into a value of the same Go type as v.
+func checkTyped(typ string, v json.Marshaler, data []byte) (json.Marshaler, error) {
+	got, err := decodeTyped(typ, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Typed: %T does not decode as %s: %w", v, typ, err)
+	}
+
+	if goType(got) != goType(v) {
+		return nil, fmt.Errorf("invalid Typed: %T is not a %s", v, typ)
+	}
+
+	return got, nil
+}
@@ -0,0 +1,197 @@
+package incrementers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterType(t *testing.T) {
+	require := require.New(t)
+	decode := func(data []byte) (json.Marshaler, error) { return NewCounterFromJSON(data) }
+
+	t.Run("builtin", func(t *testing.T) {
+		for _, name := range []string{
+			TypeIncrementer, TypeUIncrementer, TypeClampedIncrementer, TypeCounter, TypeClock,
+			TypeWrappingIncrementer, TypeCascadingCounter, TypePool, TypeTickingIncrementer, TypeModifierStack,
+			TypeGCounter, TypePNCounter, TypeBoundedCounter, TypeSnappedIncrementer, TypeSequenceIncrementer,
+		} {
+			require.True(IsRegisteredType(name), "%s was not registered", name)
+		}
+
+		require.Subset(RegisteredTypes(), []string{TypeClock, TypePool})
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		require.NoError(RegisterType("test.gauge", decode))
+		require.True(IsRegisteredType("test.gauge"))
+
+		typed, err := UnmarshalTyped([]byte(
+			`{"type":"test.gauge","data":{"min":0,"max":0,"incrementer":{"inc":1,"val":7,"orig":7}}}`,
+		))
+		require.NoError(err, "UnmarshalTyped() returned an error: %s", err)
+		require.Equal(7, typed.Value.(Counter).Value())
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		err := RegisterType(TypeClock, decode)
+		require.Error(err, "RegisterType() did not return an error")
+		require.Equal("invalid type: clock is already registered", err.Error())
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		err := RegisterType("", decode)
+		require.Error(err, "RegisterType() did not return an error")
+		require.Equal("invalid type: name must not be empty", err.Error())
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		err := RegisterTypeOf("test.nilvalue", nil, decode)
+		require.Error(err, "RegisterTypeOf() did not return an error")
+		require.Equal("invalid type: test.nilvalue value must not be nil", err.Error())
+		require.False(IsRegisteredType("test.nilvalue"))
+	})
+
+	t.Run("nil decoder", func(t *testing.T) {
+		err := RegisterType("test.nil", nil)
+		require.Error(err, "RegisterType() did not return an error")
+		require.Equal("invalid type: test.nil decoder must not be nil", err.Error())
+		require.False(IsRegisteredType("test.nil"))
+	})
+}
+
+func TestTypedMarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("clock", func(t *testing.T) {
+		data, err := NewTyped(TypeClock, NewClockWithTicks(4, 2)).MarshalJSON()
+		require.NoError(err, "Typed.MarshalJSON() returned an error: %s", err)
+		require.Equal(
+			`{"type":"clock","data":{"version":3,"min":0,"max":4,"incrementer":{"version":2,"inc":1,"val":2,"orig":2}}}`,
+			string(data),
+		)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := NewTyped("dial", NewClock(4)).MarshalJSON()
+		require.Error(err, "Typed.MarshalJSON() did not return an error")
+		require.Equal("invalid Typed: unknown type dial", err.Error())
+	})
+
+	t.Run("derived type", func(t *testing.T) {
+		w := NewWrappingIncrementerWithValue(1, 7, 3)
+		data, err := MarshalTyped(&w)
+		require.NoError(err, "MarshalTyped() returned an error: %s", err)
+		require.Contains(string(data), `{"type":"wrapping","data":`)
+
+		data, err = MarshalTyped(NewClock(4))
+		require.NoError(err, "MarshalTyped() returned an error: %s", err)
+		require.Contains(string(data), `{"type":"clamped","data":`)
+
+		typed, err := NewTypedOf(w)
+		require.NoError(err, "NewTypedOf() returned an error: %s", err)
+		require.Equal(TypeWrappingIncrementer, typed.Type)
+	})
+
+	t.Run("unregistered type", func(t *testing.T) {
+		type gauge struct{ Incrementer }
+		_, err := MarshalTyped(&gauge{})
+		require.Error(err, "MarshalTyped() did not return an error")
+		require.Equal("invalid Typed: *incrementers.gauge is not a registered type", err.Error())
+	})
+
+	t.Run("mislabeled", func(t *testing.T) {
+		w := NewWrappingIncrementerWithValue(0, 7, 3)
+		_, err := NewTyped(TypeClock, &w).MarshalJSON()
+		require.Error(err, "Typed.MarshalJSON() did not return an error")
+		require.Equal("invalid Typed: *incrementers.WrappingIncrementer is not a clock", err.Error())
+
+		_, err = NewTyped(TypeClock, NewCounter()).MarshalJSON()
+		require.Error(err, "Typed.MarshalJSON() did not return an error")
+		require.Equal(
+			"invalid Typed: *incrementers.ClampedIncrementer does not decode as clock: invalid Clock: max must be greater than 0",
+			err.Error(),
+		)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		_, err := NewTyped(TypeClock, nil).MarshalJSON()
+		require.Error(err, "Typed.MarshalJSON() did not return an error")
+		require.Equal("invalid Typed: value must not be nil", err.Error())
+	})
+}
+
+func TestTypedUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("round trip", func(t *testing.T) {
+		inc := NewIncrementerWithValue(-3)
+		u := NewUIncrementerWithValue(4)
+		c := NewClampedIncrementerWithValue(-5, 5, 1)
+		w := NewWrappingIncrementerWithValue(0, 23, 22)
+		coins, err := NewCascadingCounter(Stage{"cp", 10}, Stage{"sp", 10}, Stage{"gp", 0})
+		require.NoError(err, "NewCascadingCounter() returned an error: %s", err)
+		coins.SetValue(137)
+		p := NewPool(20)
+		p.Damage(5)
+		ti := NewTickingIncrementer(NewClampedIncrementerWithValue(0, 10, 3), 2)
+		s := NewModifierStack(NewIncrementerWithValue(10))
+		s.AddModifier(NewAdditiveModifier("bless", "luck", 2, 3))
+
+		values := []Typed{
+			NewTyped(TypeIncrementer, &inc),
+			NewTyped(TypeUIncrementer, &u),
+			NewTyped(TypeClampedIncrementer, &c),
+			NewTyped(TypeCounter, NewCounterWithValue(100)),
+			NewTyped(TypeClock, NewClockWithTicks(6, 2)),
+			NewTyped(TypeWrappingIncrementer, &w),
+			NewTyped(TypeCascadingCounter, &coins),
+			NewTyped(TypePool, &p),
+			NewTyped(TypeTickingIncrementer, &ti),
+			NewTyped(TypeModifierStack, &s),
+		}
+
+		data, err := json.Marshal(values)
+		require.NoError(err, "json.Marshal() returned an error: %s", err)
+
+		var got []Typed
+		require.NoError(json.Unmarshal(data, &got))
+		require.Len(got, len(values))
+
+		for i, v := range values {
+			require.Equal(v.Type, got[i].Type)
+			want, err := v.Value.MarshalJSON()
+			require.NoError(err)
+			have, err := got[i].Value.MarshalJSON()
+			require.NoError(err)
+			require.Equal(string(want), string(have), "%s did not round trip", v.Type)
+		}
+
+		require.Equal(-3, got[0].Value.(*Incrementer).Value())
+		require.Equal("1gp 3sp 7cp", got[6].Value.(*CascadingCounter).String())
+		require.Equal(15, got[7].Value.(*Pool).Value())
+		require.Equal(12, got[9].Value.(*ModifierStack).Effective())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var typed Typed
+		err := typed.UnmarshalJSON(nil)
+		require.Error(err, "Typed.UnmarshalJSON() did not return an error")
+		require.Equal("Typed.UnmarshalJSON(): data was nil", err.Error())
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := UnmarshalTyped([]byte(`{"type":"dial","data":{}}`))
+		require.Error(err, "UnmarshalTyped() did not return an error")
+		require.Equal("invalid Typed: unknown type dial", err.Error())
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		_, err := UnmarshalTyped([]byte(
+			`{"type":"clock","data":{"min":0,"max":0,"incrementer":{"inc":1,"val":2,"orig":2}}}`,
+		))
+		require.Error(err, "UnmarshalTyped() did not return an error")
+		require.Equal("invalid Clock: max must be greater than 0", err.Error())
+	})
+}
@@ -30,16 +30,40 @@ func NewUIncrementerFromJSON(data []byte) (UIncrementer, error) {
 }
 
 // Increment increases the counter by the incrementer value.
-func (u *UIncrementer) Increment() { u.val = ClampMin(u.val+u.inc, 0) }
+func (u *UIncrementer) Increment() { u.val = ClampMin(AddSaturating(u.val, u.inc), 0) }
 
 // Decrement decreases the counter by the incrementer value.
-func (u *UIncrementer) Decrement() { u.val = ClampMin(u.val-u.inc, 0) }
+func (u *UIncrementer) Decrement() { u.val = ClampMin(SubSaturating(u.val, u.inc), 0) }
 
 // Add increases the counter by the given number of val.
-func (u *UIncrementer) Add(val int) { u.val = ClampMin(u.val+val, 0) }
+func (u *UIncrementer) Add(val int) { u.val = ClampMin(AddSaturating(u.val, val), 0) }
 
 // Remove decreases the counter by the given number of val.
-func (u *UIncrementer) Remove(val int) { u.val = ClampMin(u.val-val, 0) }
+func (u *UIncrementer) Remove(val int) { u.val = ClampMin(SubSaturating(u.val, val), 0) }
+
+// AddChecked increases the counter by val with a minimum of 0, or returns ErrOverflow and leaves the
+// counter unchanged if the result does not fit in an int.
+func (u *UIncrementer) AddChecked(val int) error {
+	v, err := AddChecked(u.val, val)
+	if err != nil {
+		return err
+	}
+
+	u.val = ClampMin(v, 0)
+	return nil
+}
+
+// RemoveChecked decreases the counter by val with a minimum of 0, or returns ErrOverflow and leaves
+// the counter unchanged if the result does not fit in an int.
+func (u *UIncrementer) RemoveChecked(val int) error {
+	v, err := SubChecked(u.val, val)
+	if err != nil {
+		return err
+	}
+
+	u.val = ClampMin(v, 0)
+	return nil
+}
 
 // SetValue sets the counter value to the given number of val with a minimum of 0.
 func (u *UIncrementer) SetValue(val int) { u.val = ClampMin(val, 0) }
@@ -54,6 +78,38 @@ func (u *UIncrementer) UnmarshalJSON(data []byte) error {
 	}
 
 	u.Incrementer = i
+	return u.validate()
+}
+
+// UnmarshalBinary parses a binary representation of the counter.
+func (u *UIncrementer) UnmarshalBinary(data []byte) error {
+	var n UIncrementer
+	if err := n.Incrementer.UnmarshalBinary(data); err != nil {
+		return err
+	}
+
+	if err := n.validate(); err != nil {
+		return err
+	}
+
+	*u = n
+	return nil
+}
+
+// UnmarshalText parses a text representation of the counter.
+func (u *UIncrementer) UnmarshalText(text []byte) error { return unmarshalText(u, text) }
+
+func (u UIncrementer) view() formatView {
+	v := u.Incrementer.view()
+	v.name = "UIncrementer"
+	v.min = BoundAt(0)
+	return v
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (u UIncrementer) Format(f fmt.State, verb rune) { u.view().format(f, verb) }
+
+func (u UIncrementer) validate() error {
 	if u.val < 0 {
 		return fmt.Errorf("invalid UIncrementer: Incrementer.val must be 0 or greater")
 	}
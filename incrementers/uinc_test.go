@@ -1,6 +1,7 @@
 package incrementers
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -254,14 +255,14 @@ func TestUIncrementerMarshalJSON(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		data, err := u.MarshalJSON()
 		require.NoError(err, "Incrementer.MarshalJSON() returned an error: %s", err)
-		require.Equal(`{"inc":0,"val":0,"orig":0}`, string(data))
+		require.Equal(`{"version":2,"inc":0,"val":0,"orig":0}`, string(data))
 	})
 
 	t.Run("set", func(t *testing.T) {
 		u = UIncrementer{Incrementer{inc: 1, val: 2, orig: 3}}
 		data, err := u.MarshalJSON()
 		require.NoError(err, "Incrementer.MarshalJSON() returned an error: %s", err)
-		require.Equal(`{"inc":1,"val":2,"orig":3}`, string(data))
+		require.Equal(`{"version":2,"inc":1,"val":2,"orig":3}`, string(data))
 	})
 }
 
@@ -282,7 +283,7 @@ func TestUIncrementerUnmarshalJSON(t *testing.T) {
 		// Make "inc" a string to force error.
 		err := u.UnmarshalJSON([]byte(`{"inc":b,"val":2,"orig":3}`))
 		require.Error(err, "UIncrementer.UnmarshalJSON() did not return error")
-		require.Equal("expected integer", err.Error())
+		require.Equal("invalid character 'b' looking for beginning of value", err.Error())
 	})
 
 	t.Run("invalid val", func(t *testing.T) {
@@ -324,3 +325,23 @@ func TestUIncrementer(t *testing.T) {
 	require.Equal(0, u.Value())
 }
 */
+
+func TestUIncrementerOverflow(t *testing.T) {
+	require := require.New(t)
+	u := NewUIncrementerWithValue(10)
+
+	u.Add(math.MaxInt)
+	require.Equal(math.MaxInt, u.Value())
+
+	u.Remove(math.MinInt)
+	require.Equal(math.MaxInt, u.Value())
+
+	require.ErrorIs(u.AddChecked(1), ErrOverflow)
+	require.Equal(math.MaxInt, u.Value())
+
+	require.NoError(u.RemoveChecked(math.MaxInt))
+	require.Equal(0, u.Value())
+
+	require.NoError(u.AddChecked(math.MinInt))
+	require.Equal(0, u.Value())
+}
@@ -0,0 +1,304 @@
+package incrementers
+
+import (
+	"fmt"
+	"math"
+)
+
+// WrappingIncrementer is an incrementer that wraps from max back to min, and from min back to max,
+// instead of clamping. It counts how many times it has wrapped: forward wraps add 1 and backward
+// wraps subtract 1.
+type WrappingIncrementer struct {
+	min   int
+	max   int
+	wraps int
+	Incrementer
+}
+
+// NewWrappingIncrementer creates a new incrementer which wraps between min and max inclusive, starting
+// at min.
+func NewWrappingIncrementer(min, max int) WrappingIncrementer {
+	return WrappingIncrementer{min: min, max: max, Incrementer: Incrementer{val: min, inc: 1, orig: min}}
+}
+
+// NewWrappingIncrementerWithValue creates a new incrementer which wraps between min and max inclusive
+// with a starting value of val. A val outside the range is wrapped into it without counting a wrap.
+func NewWrappingIncrementerWithValue(min, max, val int) WrappingIncrementer {
+	w := WrappingIncrementer{min: min, max: max, Incrementer: Incrementer{val: val, inc: 1}}
+	w.move(0, false)
+	w.orig = w.val
+
+	return w
+}
+
+// NewWrappingIncrementerFromJSON creates a new incrementer from a JSON representation.
+func NewWrappingIncrementerFromJSON(data []byte) (WrappingIncrementer, error) {
+	var w WrappingIncrementer
+	err := w.UnmarshalJSON(data)
+	return w, err
+}
+
+// IsFull returns true if the incrementer is at the maximum value.
+func (w WrappingIncrementer) IsFull() bool { return w.val == w.max }
+
+// IsEmpty returns true if the incrementer is at the minimum value.
+func (w WrappingIncrementer) IsEmpty() bool { return w.val == w.min }
+
+// Min returns the minimum value of the incrementer.
+func (w WrappingIncrementer) Min() int { return w.min }
+
+// Max returns the maximum value of the incrementer.
+func (w WrappingIncrementer) Max() int { return w.max }
+
+// Wraps returns the number of times the incrementer has wrapped. Backward wraps are subtracted.
+func (w WrappingIncrementer) Wraps() int { return w.wraps }
+
+// Increment increases the value by the incrementer value, wrapping past max.
+func (w *WrappingIncrementer) Increment() { w.add(w.inc, false) }
+
+// Decrement decreases the value by the incrementer value, wrapping past min.
+func (w *WrappingIncrementer) Decrement() { w.add(w.inc, true) }
+
+// Add increases the value by the given number of val, wrapping as many times as needed. The wrap
+// count stops at math.MaxInt or math.MinInt instead of overflowing.
+func (w *WrappingIncrementer) Add(val int) { w.add(val, false) }
+
+// Remove decreases the value by the given number of val, wrapping as many times as needed.
+func (w *WrappingIncrementer) Remove(val int) { w.add(val, true) }
+
+// AddChecked increases the value by val, wrapping as many times as needed, or returns ErrOverflow and
+// leaves the incrementer unchanged if the wrap count does not fit in an int.
+func (w *WrappingIncrementer) AddChecked(val int) error { return w.addChecked(val, false) }
+
+// RemoveChecked decreases the value by val, wrapping as many times as needed, or returns ErrOverflow
+// and leaves the incrementer unchanged if the wrap count does not fit in an int.
+func (w *WrappingIncrementer) RemoveChecked(val int) error { return w.addChecked(val, true) }
+
+// SetMin sets the minimum value of the incrementer. The value is wrapped into the new range.
+func (w *WrappingIncrementer) SetMin(min int) { w.min = min; w.move(0, false) }
+
+// SetMax sets the maximum value of the incrementer. The value is wrapped into the new range.
+func (w *WrappingIncrementer) SetMax(max int) { w.max = max; w.move(0, false) }
+
+// SetValue sets the value to the given number of val. A val outside the range is wrapped into it
+// without counting a wrap.
+func (w *WrappingIncrementer) SetValue(val int) { w.val = val; w.move(0, false) }
+
+// SetOriginalValue sets the original value to the given number of val wrapped into the range.
+func (w *WrappingIncrementer) SetOriginalValue(val int) {
+	w.orig = w.min + w.offset(val)
+}
+
+// ResetWraps sets the wrap count back to 0.
+func (w *WrappingIncrementer) ResetWraps() { w.wraps = 0 }
+
+// Fill sets the incrementer to the maximum value.
+func (w *WrappingIncrementer) Fill() { w.val = w.max }
+
+// Floor sets the incrementer to the minimum value.
+func (w *WrappingIncrementer) Floor() { w.val = w.min }
+
+// Empty sets the incrementer to the minimum value.
+func (w *WrappingIncrementer) Empty() { w.val = w.min }
+
+// Ratio returns how far the value is from min to max, from 0 to 1.
+func (w WrappingIncrementer) Ratio() float64 { return ratio(w.val, w.min, w.max) }
+
+// Percent returns how far the value is from min to max, from 0 to 100, rounded down.
+func (w WrappingIncrementer) Percent() int { return segments(w.val, w.min, w.max, 100) }
+
+// Segments returns how many of n equal segments from min to max the value has completely filled.
+// Returns 0 if n is less than 1.
+func (w WrappingIncrementer) Segments(n int) int { return segments(w.val, w.min, w.max, n) }
+
+// SetFraction sets the value to the given fraction of the way from min to max, rounded with mode. f is
+// clamped to 0 to 1. Does nothing if f is NaN.
+func (w *WrappingIncrementer) SetFraction(f float64, mode RoundingMode) {
+	if !math.IsNaN(f) {
+		w.val = atFraction(f, w.min, w.max, mode)
+	}
+}
+
+// String returns a string representation of the incrementer.
+func (w WrappingIncrementer) String() string { return fmt.Sprintf("%d/%d", w.val, w.max) }
+
+func (w WrappingIncrementer) view() formatView {
+	return formatView{name: "WrappingIncrementer", val: w.val, min: BoundAt(w.min), max: BoundAt(w.max),
+		str: w.String(), fields: []formatField{
+			{"val", w.val}, {"min", w.min}, {"max", w.max}, {"wraps", w.wraps}, {"inc", w.inc}, {"orig", w.orig},
+		}}
+}
+
+// Format implements fmt.Formatter. See the package's format verbs.
+func (w WrappingIncrementer) Format(f fmt.State, verb rune) { w.view().format(f, verb) }
+
+// MarshalJSON returns a JSON representation of the incrementer.
+func (w WrappingIncrementer) MarshalJSON() ([]byte, error) {
+	j, _ := w.Incrementer.MarshalJSON()
+	return []byte(fmt.Sprintf(
+		`{"version":%d,"min":%d,"max":%d,"wraps":%d,"incrementer":%s}`,
+		wrappingVersion, w.min, w.max, w.wraps, j,
+	)), nil
+}
+
+type wrappingIncrementerJSON struct {
+	Min         int         `json:"min"`
+	Max         int         `json:"max"`
+	Wraps       int         `json:"wraps"`
+	Incrementer Incrementer `json:"incrementer"`
+}
+
+// UnmarshalJSON parses a JSON representation of the incrementer. Older versions are migrated to the
+// latest version first.
+func (w *WrappingIncrementer) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("WrappingIncrementer.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" || string(data) == `""` {
+		return nil
+	}
+
+	var j wrappingIncrementerJSON
+	err := unmarshalVersioned(
+		TypeWrappingIncrementer, "WrappingIncrementer", data, &j, "min", "max", "incrementer",
+	)
+	if err != nil {
+		return err
+	}
+
+	w.min = j.Min
+	w.max = j.Max
+	w.wraps = j.Wraps
+	w.Incrementer = j.Incrementer
+
+	return w.validate()
+}
+
+// MarshalBinary returns a compact binary representation of the incrementer.
+func (w WrappingIncrementer) MarshalBinary() ([]byte, error) {
+	b := newBinaryWriter()
+	b.int(w.min, w.max, w.wraps, w.inc, w.val, w.orig)
+	return b.buf, nil
+}
+
+// UnmarshalBinary parses a binary representation of the incrementer.
+func (w *WrappingIncrementer) UnmarshalBinary(data []byte) error {
+	r, err := newBinaryReader("WrappingIncrementer", data)
+	if err != nil {
+		return err
+	}
+
+	n := WrappingIncrementer{
+		min:         r.int(),
+		max:         r.int(),
+		wraps:       r.int(),
+		Incrementer: Incrementer{inc: r.int(), val: r.int(), orig: r.int()},
+	}
+	if err := r.close(); err != nil {
+		return err
+	}
+
+	if err := n.validate(); err != nil {
+		return err
+	}
+
+	*w = n
+	return nil
+}
+
+// MarshalText returns a compact text representation of the incrementer.
+func (w WrappingIncrementer) MarshalText() ([]byte, error) { return marshalText(w) }
+
+// UnmarshalText parses a text representation of the incrementer.
+func (w *WrappingIncrementer) UnmarshalText(text []byte) error { return unmarshalText(w, text) }
+
+func (w WrappingIncrementer) validate() error {
+	if w.min >= w.max {
+		return fmt.Errorf("invalid WrappingIncrementer: min must be less than max")
+	}
+
+	if !IsClamped(w.val, w.min, w.max) {
+		return fmt.Errorf("invalid WrappingIncrementer: Incrementer.val must min <= val <= max")
+	}
+
+	if !IsClamped(w.orig, w.min, w.max) {
+		return fmt.Errorf("invalid WrappingIncrementer: Incrementer.orig must min <= orig <= max")
+	}
+
+	return nil
+}
+
+// size returns the number of values in the range. A range where max is below min is treated as a
+// single value, and a range with more than math.MaxInt values as math.MaxInt values.
+func (w WrappingIncrementer) size() int {
+	if w.max < w.min {
+		return 1
+	}
+
+	return AddSaturating(SubSaturating(w.max, w.min), 1)
+}
+
+// offset returns how far past min val is once wrapped into the range.
+func (w WrappingIncrementer) offset(val int) int {
+	size := w.size()
+	return mod(mod(val, size)-mod(w.min, size), size)
+}
+
+// add adds val to the value, or removes it if back is true, and adds the wraps to the wrap count.
+func (w *WrappingIncrementer) add(val int, back bool) {
+	wraps, _ := w.move(val, back)
+	w.wraps = AddSaturating(w.wraps, wraps)
+}
+
+func (w *WrappingIncrementer) addChecked(val int, back bool) error {
+	n := *w
+	wraps, err := n.move(val, back)
+	if err != nil {
+		return err
+	}
+
+	if n.wraps, err = AddChecked(n.wraps, wraps); err != nil {
+		return err
+	}
+
+	*w = n
+	return nil
+}
+
+// move adds val to the value, or removes it if back is true, wraps it into the range and returns the
+// number of wraps. The value is split into whole ranges and a remainder first so nothing overflows. If
+// the number of wraps does not fit in an int it is saturated and ErrOverflow is returned.
+func (w *WrappingIncrementer) move(val int, back bool) (int, error) {
+	if w.max < w.min {
+		w.val = w.min
+		return 0, nil
+	}
+
+	size := w.size()
+	wraps, rem := floorDiv(val, size), mod(val, size)
+
+	var err error
+	if back {
+		// -val is -wraps whole ranges minus rem, or -wraps-1 whole ranges plus size-rem.
+		if wraps, err = SubChecked(0, wraps); err != nil {
+			wraps = math.MaxInt
+		}
+
+		if rem > 0 {
+			wraps--
+			rem = size - rem
+		}
+	}
+
+	off := w.offset(w.val)
+	if off >= size-rem {
+		off -= size - rem
+		wraps++
+	} else {
+		off += rem
+	}
+
+	w.val = w.min + off
+	return wraps, err
+}
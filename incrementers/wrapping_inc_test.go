@@ -0,0 +1,336 @@
+package incrementers
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ Counter  = &WrappingIncrementer{}
+	_ Clock    = &WrappingIncrementer{}
+	_ Progress = &WrappingIncrementer{}
+)
+
+func TestWrappingIncrementerNew(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementer(1, 7)
+	require.Equal(1, w.Min())
+	require.Equal(7, w.Max())
+	require.Equal(1, w.Inc())
+	require.Equal(1, w.Value())
+	require.Equal(1, w.Original())
+	require.Equal(0, w.Wraps())
+}
+
+func TestWrappingIncrementerNewWithValue(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("in range", func(t *testing.T) {
+		w := NewWrappingIncrementerWithValue(1, 7, 3)
+		require.Equal(3, w.Value())
+		require.Equal(3, w.Original())
+	})
+
+	t.Run("above max", func(t *testing.T) {
+		w := NewWrappingIncrementerWithValue(1, 7, 9)
+		require.Equal(2, w.Value())
+		require.Equal(2, w.Original())
+		require.Equal(0, w.Wraps())
+	})
+
+	t.Run("below min", func(t *testing.T) {
+		w := NewWrappingIncrementerWithValue(1, 7, 0)
+		require.Equal(7, w.Value())
+		require.Equal(0, w.Wraps())
+	})
+}
+
+func TestWrappingIncrementerIsFull(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementer(0, 3)
+	require.False(w.IsFull())
+	require.True(w.IsEmpty())
+
+	w.Fill()
+	require.True(w.IsFull())
+	require.False(w.IsEmpty())
+
+	w.Floor()
+	require.True(w.IsEmpty())
+}
+
+func TestWrappingIncrementerIncrement(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementerWithValue(1, 7, 6)
+
+	w.Increment()
+	require.Equal(7, w.Value())
+	require.Equal(0, w.Wraps())
+
+	w.Increment()
+	require.Equal(1, w.Value())
+	require.Equal(1, w.Wraps())
+
+	w.SetIncrementer(-1)
+	w.Increment()
+	require.Equal(7, w.Value())
+	require.Equal(0, w.Wraps())
+}
+
+func TestWrappingIncrementerDecrement(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementerWithValue(1, 7, 1)
+
+	w.Decrement()
+	require.Equal(7, w.Value())
+	require.Equal(-1, w.Wraps())
+}
+
+func TestWrappingIncrementerAdd(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementer(0, 5)
+
+	t.Run("positive", func(t *testing.T) {
+		w.Add(14)
+		require.Equal(2, w.Value())
+		require.Equal(2, w.Wraps())
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		w.Add(-15)
+		require.Equal(5, w.Value())
+		require.Equal(-1, w.Wraps())
+	})
+
+	t.Run("exact", func(t *testing.T) {
+		w.ResetWraps()
+		w.Add(6)
+		require.Equal(5, w.Value())
+		require.Equal(1, w.Wraps())
+	})
+}
+
+func TestWrappingIncrementerRemove(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementer(0, 5)
+
+	w.Remove(13)
+	require.Equal(5, w.Value())
+	require.Equal(-3, w.Wraps())
+
+	w.Remove(-1)
+	require.Equal(0, w.Value())
+	require.Equal(-2, w.Wraps())
+}
+
+func TestWrappingIncrementerSetMinMax(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementerWithValue(0, 9, 8)
+
+	w.SetMax(5)
+	require.Equal(5, w.Max())
+	require.Equal(2, w.Value())
+
+	w.SetMin(3)
+	require.Equal(3, w.Min())
+	require.Equal(5, w.Value())
+	require.Equal(0, w.Wraps())
+
+	w.SetMin(6)
+	require.Equal(6, w.Value())
+}
+
+func TestWrappingIncrementerSetValue(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementer(0, 3)
+
+	w.SetValue(2)
+	require.Equal(2, w.Value())
+
+	w.SetValue(-1)
+	require.Equal(3, w.Value())
+	require.Equal(0, w.Wraps())
+}
+
+func TestWrappingIncrementerSetOriginalValue(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementer(0, 3)
+
+	w.SetOriginalValue(6)
+	require.Equal(2, w.Original())
+
+	w.Reset()
+	require.Equal(2, w.Value())
+}
+
+func TestWrappingIncrementerEmpty(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementerWithValue(1, 7, 4)
+	w.Empty()
+	require.Equal(1, w.Value())
+}
+
+func TestWrappingIncrementerRatio(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementerWithValue(0, 23, 6)
+	require.InDelta(6.0/23, w.Ratio(), 1e-9)
+	require.Equal(26, w.Percent())
+	require.Equal(1, w.Segments(4))
+
+	w.SetFraction(0.5, RoundNearest)
+	require.Equal(12, w.Value())
+	require.Equal(0, w.Wraps())
+	w.SetFraction(math.NaN(), RoundNearest)
+	require.Equal(12, w.Value())
+}
+
+func TestWrappingIncrementerString(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementerWithValue(1, 7, 4)
+	require.Equal("4/7", w.String())
+}
+
+func TestWrappingIncrementerMarshalJSON(t *testing.T) {
+	require := require.New(t)
+	w := NewWrappingIncrementerWithValue(1, 7, 4)
+	w.Add(5)
+
+	data, err := w.MarshalJSON()
+	require.NoError(err, "WrappingIncrementer.MarshalJSON() returned an error: %s", err)
+	require.Equal(`{"version":2,"min":1,"max":7,"wraps":1,"incrementer":{"version":2,"inc":1,"val":2,"orig":4}}`, string(data))
+}
+
+func TestWrappingIncrementerUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		w, err := NewWrappingIncrementerFromJSON([]byte(`{"min":1,"max":7,"wraps":-2,"incrementer":{"inc":1,"val":2,"orig":4}}`))
+		require.NoError(err, "WrappingIncrementer.UnmarshalJSON() returned an error: %s", err)
+		require.Equal(1, w.Min())
+		require.Equal(7, w.Max())
+		require.Equal(-2, w.Wraps())
+		require.Equal(2, w.Value())
+		require.Equal(4, w.Original())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		w := WrappingIncrementer{}
+		err := w.UnmarshalJSON(nil)
+		require.Error(err, "WrappingIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("WrappingIncrementer.UnmarshalJSON(): data was nil", err.Error())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		w := WrappingIncrementer{}
+		require.NoError(w.UnmarshalJSON([]byte(`null`)))
+		require.Equal(WrappingIncrementer{}, w)
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		_, err := NewWrappingIncrementerFromJSON([]byte(`{"min":1,"max":7,"wraps":b,"incrementer":{"inc":1,"val":2,"orig":4}}`))
+		require.Error(err, "WrappingIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("invalid character 'b' looking for beginning of value", err.Error())
+	})
+
+	t.Run("invalid min", func(t *testing.T) {
+		_, err := NewWrappingIncrementerFromJSON([]byte(`{"min":7,"max":7,"wraps":0,"incrementer":{"inc":1,"val":7,"orig":7}}`))
+		require.Error(err, "WrappingIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("invalid WrappingIncrementer: min must be less than max", err.Error())
+	})
+
+	t.Run("invalid val", func(t *testing.T) {
+		_, err := NewWrappingIncrementerFromJSON([]byte(`{"min":1,"max":7,"wraps":0,"incrementer":{"inc":1,"val":8,"orig":4}}`))
+		require.Error(err, "WrappingIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("invalid WrappingIncrementer: Incrementer.val must min <= val <= max", err.Error())
+	})
+
+	t.Run("invalid orig", func(t *testing.T) {
+		_, err := NewWrappingIncrementerFromJSON([]byte(`{"min":1,"max":7,"wraps":0,"incrementer":{"inc":1,"val":2,"orig":0}}`))
+		require.Error(err, "WrappingIncrementer.UnmarshalJSON() did not return an error")
+		require.Equal("invalid WrappingIncrementer: Incrementer.orig must min <= orig <= max", err.Error())
+	})
+}
+
+func TestWrappingIncrementerOverflow(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("extreme values", func(t *testing.T) {
+		w := NewWrappingIncrementerWithValue(-3, 3, math.MaxInt)
+		require.Equal(0, w.Value())
+
+		w.Add(math.MaxInt)
+		require.Equal(0, w.Value())
+		require.Equal(1317624576693539401, w.Wraps())
+
+		w.Remove(math.MinInt)
+		require.Equal(1, w.Value())
+
+		w.SetOriginalValue(math.MinInt)
+		require.Equal(-1, w.Original())
+	})
+
+	t.Run("saturate", func(t *testing.T) {
+		w := NewWrappingIncrementer(0, 0)
+		w.Add(math.MaxInt)
+		w.Add(1)
+		require.Equal(math.MaxInt, w.Wraps())
+
+		w.ResetWraps()
+		w.Remove(math.MinInt)
+		require.Equal(math.MaxInt, w.Wraps())
+	})
+
+	t.Run("checked", func(t *testing.T) {
+		w := NewWrappingIncrementer(0, 0)
+		require.NoError(w.AddChecked(math.MaxInt))
+		require.ErrorIs(w.AddChecked(1), ErrOverflow)
+		require.Equal(math.MaxInt, w.Wraps())
+
+		w.ResetWraps()
+		require.ErrorIs(w.RemoveChecked(math.MinInt), ErrOverflow)
+		require.Equal(0, w.Wraps())
+	})
+}
+
+func FuzzWrappingIncrementerAdd(f *testing.F) {
+	f.Add(-3, 3, 0, math.MaxInt, false)
+	f.Add(-3, 3, 0, math.MinInt, true)
+	f.Add(0, 5, 5, 6, false)
+	f.Add(math.MinInt, math.MaxInt/2, 0, math.MaxInt, false)
+
+	f.Fuzz(func(t *testing.T, min, max, val, n int, back bool) {
+		if min >= max || max-min < 0 || max-min == math.MaxInt {
+			return
+		}
+
+		w := NewWrappingIncrementerWithValue(min, max, val)
+		start := w.Value()
+		if back {
+			w.Remove(n)
+		} else {
+			w.Add(n)
+		}
+
+		size := big.NewInt(int64(max - min + 1))
+		off := new(big.Int).Sub(big.NewInt(int64(start)), big.NewInt(int64(min)))
+		if back {
+			off.Sub(off, big.NewInt(int64(n)))
+		} else {
+			off.Add(off, big.NewInt(int64(n)))
+		}
+
+		wraps, rem := new(big.Int).DivMod(off, size, new(big.Int))
+		want := min + int(rem.Int64())
+		if w.Value() != want {
+			t.Fatalf("%d in %d..%d moved %d (back %t) = %d, want %d", start, min, max, n, back, w.Value(), want)
+		}
+
+		if wantWraps, _ := bigResult(wraps); w.Wraps() != wantWraps {
+			t.Fatalf("%d in %d..%d moved %d (back %t) wrapped %d times, want %d", start, min, max, n, back,
+				w.Wraps(), wantWraps)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+// Package sqltest provides a fake database/sql driver for tests. Every Exec stores its arguments as a
+// row and every Query returns the stored rows, so values can be written and read back without a real
+// database.
+package sqltest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sync"
+)
+
+var (
+	register sync.Once
+	fake     = &fakeDriver{tables: make(map[string]*table)}
+)
+
+// Open opens a connection to the named in-memory table. Connections with the same name share rows.
+func Open(name string) (*sql.DB, error) {
+	register.Do(func() { sql.Register("sqltest", fake) })
+	return sql.Open("sqltest", name)
+}
+
+type table struct {
+	mu   sync.Mutex
+	rows [][]driver.Value
+}
+
+type fakeDriver struct {
+	mu     sync.Mutex
+	tables map[string]*table
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	t, ok := d.tables[name]
+	if !ok {
+		t = &table{}
+		d.tables[name] = t
+	}
+
+	return &conn{table: t}, nil
+}
+
+type conn struct {
+	table *table
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) { return &stmt{table: c.table}, nil }
+func (c *conn) Close() error                              { return nil }
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("sqltest: transactions are not supported")
+}
+
+type stmt struct {
+	table *table
+}
+
+func (s *stmt) Close() error  { return nil }
+func (s *stmt) NumInput() int { return -1 }
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.table.mu.Lock()
+	defer s.table.mu.Unlock()
+
+	s.table.rows = append(s.table.rows, append([]driver.Value(nil), args...))
+	return driver.RowsAffected(1), nil
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.table.mu.Lock()
+	defer s.table.mu.Unlock()
+
+	return &rows{rows: append([][]driver.Value(nil), s.table.rows...)}, nil
+}
+
+type rows struct {
+	rows [][]driver.Value
+}
+
+func (r *rows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+
+	cols := make([]string, len(r.rows[0]))
+	for i := range cols {
+		cols[i] = fmt.Sprintf("c%d", i)
+	}
+
+	return cols
+}
+
+func (r *rows) Close() error { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
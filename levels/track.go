@@ -0,0 +1,336 @@
+// Package levels tracks experience and levels on top of an incrementers.Counter. A Track turns total
+// experience into a level with a table of thresholds or a formula, reports progress to the next level,
+// and returns a LevelUp for every threshold crossed.
+package levels
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chadeldridge/rpgtools/formulas"
+	"github.com/chadeldridge/rpgtools/incrementers"
+)
+
+// TypeTrack is the Typed JSON and schema name of a Track.
+const TypeTrack = "levels.track"
+
+const trackVersion = 1
+
+// MaxLevelLimit is the highest max level of a formula Track.
+const MaxLevelLimit = 1000
+
+func init() {
+	if err := incrementers.RegisterSchema(TypeTrack); err != nil {
+		panic(err)
+	}
+
+	err := incrementers.RegisterTypeOf(TypeTrack, &Track{}, func(data []byte) (json.Marshaler, error) {
+		return NewTrackFromJSON(data)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// LevelUp is a level reached by adding experience.
+type LevelUp struct {
+	Level     int `json:"level"`
+	Threshold int `json:"threshold"` // Experience needed for Level.
+}
+
+// Track is an experience Counter with the thresholds of each level. Level 1 starts at 0 experience and
+// level n + 1 starts at threshold n. Thresholds come from a table or from a formula of the variable
+// level, such as "500 * level * (level - 1)", evaluated once for every level up to a maximum.
+type Track struct {
+	xp         incrementers.Counter
+	thresholds []int // thresholds[i] is the experience needed for level i + 2.
+	formula    *formulas.Formula
+}
+
+// NewTrack creates a new Track over xp from a table of thresholds for level 2, 3 and so on. Thresholds
+// must be greater than 0 and increasing. xp must be a Counter registered with
+// incrementers.RegisterTypeOf, such as a ClampedIncrementer or a GCounter, so the Track can be saved.
+//
+//	track, err := NewTrack(incrementers.NewCounter(), 300, 900, 2700, 6500)
+func NewTrack(xp incrementers.Counter, thresholds ...int) (*Track, error) {
+	t := &Track{xp: xp, thresholds: append([]int(nil), thresholds...)}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// NewFormulaTrack creates a new Track over xp whose threshold for each level from 2 to maxLevel is f
+// evaluated with the variable level. f must not roll dice and must increase with level, and maxLevel
+// must be from 1 to MaxLevelLimit.
+func NewFormulaTrack(xp incrementers.Counter, f formulas.Formula, maxLevel int) (*Track, error) {
+	thresholds, err := evalThresholds(f, maxLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Track{xp: xp, thresholds: thresholds, formula: &f}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// NewTrackFromJSON creates a new Track from a JSON representation.
+func NewTrackFromJSON(data []byte) (*Track, error) {
+	t := &Track{}
+	if err := t.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// evalThresholds returns f evaluated for every level from 2 to maxLevel.
+func evalThresholds(f formulas.Formula, maxLevel int) ([]int, error) {
+	if maxLevel < 1 || maxLevel > MaxLevelLimit {
+		return nil, fmt.Errorf("invalid Track: max level must be from 1 to %d", MaxLevelLimit)
+	}
+
+	if f.HasDice() {
+		return nil, fmt.Errorf("invalid Track: formula %s must not roll dice", f)
+	}
+
+	level := incrementers.NewIncrementer()
+	vars := formulas.Vars{"level": &level}
+	thresholds := make([]int, 0, maxLevel-1)
+	for l := 2; l <= maxLevel; l++ {
+		level.SetValue(l)
+		v, err := f.Eval(vars)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Track: level %d: %w", l, err)
+		}
+
+		thresholds = append(thresholds, v)
+	}
+
+	return thresholds, nil
+}
+
+// XP returns the total experience.
+func (t *Track) XP() int { return t.xp.Value() }
+
+// Level returns the current level, from 1 to MaxLevel.
+func (t *Track) Level() int { return levelAt(t.thresholds, t.xp.Value()) }
+
+// MaxLevel returns the highest level of the Track.
+func (t *Track) MaxLevel() int { return len(t.thresholds) + 1 }
+
+// IsMaxLevel returns true if the Track is at its highest level.
+func (t *Track) IsMaxLevel() bool { return t.Level() == t.MaxLevel() }
+
+// Thresholds returns the experience needed for level 2, 3 and so on.
+func (t *Track) Thresholds() []int { return append([]int(nil), t.thresholds...) }
+
+// Formula returns the formula the thresholds were made from, and false if they came from a table.
+func (t *Track) Formula() (formulas.Formula, bool) {
+	if t.formula == nil {
+		return formulas.Formula{}, false
+	}
+
+	return *t.formula, true
+}
+
+// Threshold returns the experience needed for level, and false if level is above MaxLevel. Levels of 1
+// or less need 0.
+func (t *Track) Threshold(level int) (int, bool) {
+	switch {
+	case level <= 1:
+		return 0, true
+	case level > t.MaxLevel():
+		return 0, false
+	}
+
+	return t.thresholds[level-2], true
+}
+
+// ToNext returns the experience left to reach the next level, or 0 at MaxLevel.
+func (t *Track) ToNext() int {
+	next, ok := t.Threshold(t.Level() + 1)
+	if !ok {
+		return 0
+	}
+
+	return next - t.xp.Value()
+}
+
+// Progress returns how far the experience is from the current level to the next, from 0 to 1. Returns
+// 1 at MaxLevel.
+func (t *Track) Progress() float64 {
+	level := t.Level()
+	next, ok := t.Threshold(level + 1)
+	if !ok {
+		return 1
+	}
+
+	cur, _ := t.Threshold(level)
+	return float64(t.xp.Value()-cur) / float64(next-cur)
+}
+
+// Add adds experience and returns a LevelUp for every level reached, lowest first.
+func (t *Track) Add(xp int) []LevelUp { return t.change(func() { t.xp.Add(xp) }) }
+
+// Remove removes experience. The level drops if the experience falls below its threshold.
+func (t *Track) Remove(xp int) { t.xp.Remove(xp) }
+
+// SetXP sets the total experience and returns a LevelUp for every level reached, lowest first.
+func (t *Track) SetXP(xp int) []LevelUp { return t.change(func() { t.xp.SetValue(xp) }) }
+
+// SetLevel sets the experience to the threshold of level clamped to 1 to MaxLevel and returns a LevelUp
+// for every level reached.
+func (t *Track) SetLevel(level int) []LevelUp {
+	xp, _ := t.Threshold(incrementers.Clamp(level, 1, t.MaxLevel()))
+	return t.SetXP(xp)
+}
+
+// Reset sets the experience to its original value.
+func (t *Track) Reset() { t.xp.Reset() }
+
+// change applies fn to the experience and returns the levels reached.
+func (t *Track) change(fn func()) []LevelUp {
+	from := t.Level()
+	fn()
+
+	var ups []LevelUp
+	for l := from + 1; l <= t.Level(); l++ {
+		ups = append(ups, LevelUp{Level: l, Threshold: t.thresholds[l-2]})
+	}
+
+	return ups
+}
+
+// String returns a string representation of the Track such as "level 3 (1200/2700 XP)", or
+// "level 20 (400000 XP)" at MaxLevel.
+func (t *Track) String() string {
+	next, ok := t.Threshold(t.Level() + 1)
+	if !ok {
+		return fmt.Sprintf("level %d (%d XP)", t.Level(), t.xp.Value())
+	}
+
+	return fmt.Sprintf("level %d (%d/%d XP)", t.Level(), t.xp.Value(), next)
+}
+
+type trackJSON struct {
+	Version    int               `json:"version"`
+	Thresholds []int             `json:"thresholds,omitempty"`
+	Formula    *formulas.Formula `json:"formula,omitempty"`
+	MaxLevel   int               `json:"maxLevel,omitempty"`
+	XP         json.RawMessage   `json:"xp"`
+}
+
+// MarshalJSON returns a JSON representation of the Track. The experience is saved as Typed JSON, and a
+// formula Track saves its formula and max level instead of its thresholds.
+func (t *Track) MarshalJSON() ([]byte, error) {
+	xp, err := incrementers.MarshalTyped(t.xp)
+	if err != nil {
+		return nil, err
+	}
+
+	j := trackJSON{Version: trackVersion, XP: xp}
+	if t.formula != nil {
+		j.Formula = t.formula
+		j.MaxLevel = t.MaxLevel()
+	} else {
+		j.Thresholds = t.thresholds
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON parses a JSON representation of the Track. The experience is read back as the Counter
+// type it was saved as.
+func (t *Track) UnmarshalJSON(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("Track.UnmarshalJSON(): data was nil")
+	}
+
+	if string(data) == "null" || string(data) == `""` {
+		return nil
+	}
+
+	data, err := incrementers.Migrate(TypeTrack, data)
+	if err != nil {
+		return err
+	}
+
+	var j trackJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	if j.XP == nil {
+		return fmt.Errorf("invalid Track: xp is required")
+	}
+
+	typed, err := incrementers.UnmarshalTyped(j.XP)
+	if err != nil {
+		return err
+	}
+
+	xp, ok := typed.Value.(incrementers.Counter)
+	if !ok {
+		return fmt.Errorf("invalid Track: xp type %s is not a Counter", typed.Type)
+	}
+
+	n := &Track{xp: xp, thresholds: j.Thresholds}
+	if j.Formula != nil {
+		if len(j.Thresholds) > 0 {
+			return fmt.Errorf("invalid Track: thresholds and formula can not both be set")
+		}
+
+		if n.thresholds, err = evalThresholds(*j.Formula, j.MaxLevel); err != nil {
+			return err
+		}
+
+		n.formula = j.Formula
+	}
+
+	if err := n.validate(); err != nil {
+		return err
+	}
+
+	*t = *n
+	return nil
+}
+
+func (t *Track) validate() error {
+	if t.xp == nil {
+		return fmt.Errorf("invalid Track: xp must not be nil")
+	}
+
+	if _, ok := incrementers.TypeOf(t.xp); !ok {
+		return fmt.Errorf("invalid Track: xp type %T is not registered with incrementers.RegisterTypeOf", t.xp)
+	}
+
+	prev := 0
+	for i, v := range t.thresholds {
+		if v <= prev {
+			return fmt.Errorf("invalid Track: level %d threshold %d must be greater than %d", i+2, v, prev)
+		}
+
+		prev = v
+	}
+
+	return nil
+}
+
+// levelAt returns the level for xp.
+func levelAt(thresholds []int, xp int) int {
+	level := 1
+	for _, v := range thresholds {
+		if xp < v {
+			break
+		}
+
+		level++
+	}
+
+	return level
+}
@@ -0,0 +1,300 @@
+package levels
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/chadeldridge/rpgtools/formulas"
+	"github.com/chadeldridge/rpgtools/incrementers"
+	"github.com/stretchr/testify/require"
+)
+
+func newTrack(t *testing.T, xp int) *Track {
+	track, err := NewTrack(incrementers.NewCounterWithValue(xp), 300, 900, 2700, 6500)
+	require.NoError(t, err, "NewTrack() returned an error: %s", err)
+	return track
+}
+
+func TestNewTrack(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		track := newTrack(t, 1000)
+		require.Equal(1000, track.XP())
+		require.Equal(3, track.Level())
+		require.Equal(5, track.MaxLevel())
+		require.Equal([]int{300, 900, 2700, 6500}, track.Thresholds())
+		_, ok := track.Formula()
+		require.False(ok)
+	})
+
+	tests := []struct {
+		name       string
+		xp         incrementers.Counter
+		thresholds []int
+		err        string
+	}{
+		{"nil xp", nil, []int{300}, "invalid Track: xp must not be nil"},
+		{"unregistered xp", plainCounter{incrementers.NewCounter()}, []int{300},
+			"invalid Track: xp type levels.plainCounter is not registered with incrementers.RegisterTypeOf"},
+		{"zero", incrementers.NewCounter(), []int{0, 300}, "invalid Track: level 2 threshold 0 must be greater than 0"},
+		{"decreasing", incrementers.NewCounter(), []int{300, 900, 600}, "invalid Track: level 4 threshold 600 must be greater than 900"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTrack(tt.xp, tt.thresholds...)
+			require.Error(err, "NewTrack() did not return an error")
+			require.Equal(tt.err, err.Error())
+		})
+	}
+}
+
+// plainCounter is a Counter whose type is not registered with incrementers.RegisterTypeOf.
+type plainCounter struct{ incrementers.Counter }
+
+func TestNewFormulaTrack(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("valid", func(t *testing.T) {
+		track, err := NewFormulaTrack(incrementers.NewCounter(), formulas.MustParse("500 * level * (level - 1)"), 5)
+		require.NoError(err, "NewFormulaTrack() returned an error: %s", err)
+		require.Equal([]int{1000, 3000, 6000, 10000}, track.Thresholds())
+		f, ok := track.Formula()
+		require.True(ok)
+		require.Equal("500 * level * (level - 1)", f.String())
+	})
+
+	t.Run("max level 1", func(t *testing.T) {
+		track, err := NewFormulaTrack(incrementers.NewCounter(), formulas.MustParse("level"), 1)
+		require.NoError(err, "NewFormulaTrack() returned an error: %s", err)
+		require.True(track.IsMaxLevel())
+	})
+
+	tests := []struct {
+		name    string
+		formula string
+		max     int
+		err     string
+	}{
+		{"max level", "level * 100", 0, "invalid Track: max level must be from 1 to 1000"},
+		{"max level limit", "level * 100", MaxLevelLimit + 1, "invalid Track: max level must be from 1 to 1000"},
+		{"dice", "level * 1d6", 5, "invalid Track: formula level * 1d6 must not roll dice"},
+		{"variable", "level * str", 5, "invalid Track: level 2: unknown variable str"},
+		{"not increasing", "1000 - level", 5, "invalid Track: level 3 threshold 997 must be greater than 998"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFormulaTrack(incrementers.NewCounter(), formulas.MustParse(tt.formula), tt.max)
+			require.Error(err, "NewFormulaTrack() did not return an error")
+			require.Equal(tt.err, err.Error())
+		})
+	}
+}
+
+func TestTrackAdd(t *testing.T) {
+	require := require.New(t)
+	track := newTrack(t, 0)
+
+	t.Run("no level", func(t *testing.T) {
+		require.Nil(track.Add(299))
+		require.Equal(1, track.Level())
+		require.Equal(1, track.ToNext())
+	})
+
+	t.Run("one level", func(t *testing.T) {
+		require.Equal([]LevelUp{{Level: 2, Threshold: 300}}, track.Add(1))
+		require.Equal(600, track.ToNext())
+	})
+
+	t.Run("many levels", func(t *testing.T) {
+		ups := track.Add(3000)
+		require.Equal([]LevelUp{{Level: 3, Threshold: 900}, {Level: 4, Threshold: 2700}}, ups)
+		require.Equal(3300, track.XP())
+	})
+
+	t.Run("max level", func(t *testing.T) {
+		require.Equal([]LevelUp{{Level: 5, Threshold: 6500}}, track.Add(100000))
+		require.True(track.IsMaxLevel())
+		require.Equal(0, track.ToNext())
+		require.Equal(1.0, track.Progress())
+		require.Nil(track.Add(1))
+	})
+}
+
+func TestTrackRemove(t *testing.T) {
+	require := require.New(t)
+	track := newTrack(t, 1000)
+
+	track.Remove(200)
+	require.Equal(2, track.Level(), "Remove() did not drop the level")
+
+	track.Remove(5000)
+	require.Equal(0, track.XP())
+	require.Equal(1, track.Level())
+
+	track.Reset()
+	require.Equal(1000, track.XP())
+}
+
+func TestTrackSet(t *testing.T) {
+	require := require.New(t)
+	track := newTrack(t, 0)
+
+	require.Len(track.SetLevel(4), 3)
+	require.Equal(2700, track.XP())
+	require.Nil(track.SetLevel(2))
+	require.Equal(300, track.XP())
+	require.Len(track.SetLevel(99), 3)
+	require.Equal(6500, track.XP())
+
+	require.Nil(track.SetXP(900))
+	require.Equal(3, track.Level())
+	require.Equal([]LevelUp{{Level: 4, Threshold: 2700}}, track.SetXP(2700))
+}
+
+func TestTrackThreshold(t *testing.T) {
+	require := require.New(t)
+	track := newTrack(t, 0)
+
+	for _, tt := range []struct {
+		level, want int
+		ok          bool
+	}{{0, 0, true}, {1, 0, true}, {2, 300, true}, {5, 6500, true}, {6, 0, false}} {
+		got, ok := track.Threshold(tt.level)
+		require.Equal(tt.want, got, "Threshold(%d)", tt.level)
+		require.Equal(tt.ok, ok, "Threshold(%d)", tt.level)
+	}
+}
+
+func TestTrackProgress(t *testing.T) {
+	require := require.New(t)
+	track := newTrack(t, 600)
+	require.Equal(0.5, track.Progress())
+	require.Equal("level 2 (600/900 XP)", track.String())
+
+	track.SetXP(7000)
+	require.Equal("level 5 (7000 XP)", track.String())
+}
+
+func TestTrackMarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("table", func(t *testing.T) {
+		data, err := newTrack(t, 1000).MarshalJSON()
+		require.NoError(err, "Track.MarshalJSON() returned an error: %s", err)
+		require.Equal(
+			`{"version":1,"thresholds":[300,900,2700,6500],`+
+				`"xp":{"type":"clamped","data":{"version":3,"min":0,"max":null,"incrementer":{"version":2,"inc":1,"val":1000,"orig":1000}}}}`,
+			string(data),
+		)
+	})
+
+	t.Run("formula", func(t *testing.T) {
+		track, err := NewFormulaTrack(incrementers.NewCounter(), formulas.MustParse("100 * level"), 10)
+		require.NoError(err)
+
+		data, err := track.MarshalJSON()
+		require.NoError(err, "Track.MarshalJSON() returned an error: %s", err)
+		require.Equal(
+			`{"version":1,"formula":"100 * level","maxLevel":10,`+
+				`"xp":{"type":"clamped","data":{"version":3,"min":0,"max":null,"incrementer":{"version":2,"inc":1,"val":0,"orig":0}}}}`,
+			string(data),
+		)
+	})
+}
+
+func TestTrackUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("table", func(t *testing.T) {
+		track, err := NewTrackFromJSON([]byte(
+			`{"thresholds":[300,900],"xp":{"type":"clamped","data":{"min":0,"max":null,"incrementer":{"inc":1,"val":900,"orig":0}}}}`,
+		))
+		require.NoError(err, "Track.UnmarshalJSON() returned an error: %s", err)
+		require.Equal(3, track.Level())
+		require.True(track.IsMaxLevel())
+	})
+
+	t.Run("formula", func(t *testing.T) {
+		track, err := NewTrackFromJSON([]byte(
+			`{"version":1,"formula":"100 * level","maxLevel":3,` +
+				`"xp":{"type":"clamped","data":{"min":0,"max":null,"incrementer":{"inc":1,"val":250,"orig":0}}}}`,
+		))
+		require.NoError(err, "Track.UnmarshalJSON() returned an error: %s", err)
+		require.Equal([]int{200, 300}, track.Thresholds())
+		require.Equal(2, track.Level())
+	})
+
+	t.Run("counter types", func(t *testing.T) {
+		gc := incrementers.NewGCounterWithValue("a", 1000)
+		pn := incrementers.NewPNCounterWithValue("a", 1000)
+		bc, err := incrementers.NewBoundedCounter("a", 0, 10000, 1000)
+		require.NoError(err)
+
+		for _, xp := range []incrementers.Counter{gc, pn, bc} {
+			track, err := NewTrack(xp, 300, 900, 2700, 6500)
+			require.NoError(err, "NewTrack() returned an error: %s", err)
+
+			data, err := track.MarshalJSON()
+			require.NoError(err, "Track.MarshalJSON() returned an error: %s", err)
+
+			got, err := NewTrackFromJSON(data)
+			require.NoError(err, "Track.UnmarshalJSON() returned an error: %s", err)
+			require.IsType(xp, got.xp)
+			require.Equal(3, got.Level())
+
+			got.Add(2000)
+			require.Equal(3000, got.XP())
+		}
+	})
+
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"missing xp", `{"thresholds":[300]}`, "invalid Track: xp is required"},
+		{"both", `{"thresholds":[300],"formula":"level","maxLevel":3,"xp":{"type":"clamped","data":{"min":0,"max":null,"incrementer":{"inc":1,"val":0,"orig":0}}}}`,
+			"invalid Track: thresholds and formula can not both be set"},
+		{"bad thresholds", `{"thresholds":[300,200],"xp":{"type":"clamped","data":{"min":0,"max":null,"incrementer":{"inc":1,"val":0,"orig":0}}}}`,
+			"invalid Track: level 3 threshold 200 must be greater than 300"},
+		{"no max level", `{"formula":"level","xp":{"type":"clamped","data":{"min":0,"max":null,"incrementer":{"inc":1,"val":0,"orig":0}}}}`,
+			"invalid Track: max level must be from 1 to 1000"},
+		{"huge max level", `{"formula":"level","maxLevel":9223372036854775807,` +
+			`"xp":{"type":"clamped","data":{"min":0,"max":null,"incrementer":{"inc":1,"val":0,"orig":0}}}}`,
+			"invalid Track: max level must be from 1 to 1000"},
+		{"not a counter", `{"version":1,"thresholds":[300],"xp":{"type":"incrementer","data":{"inc":1,"val":0,"orig":0}}}`,
+			"invalid Track: xp type incrementer is not a Counter"},
+		{"version", `{"version":2,"thresholds":[300]}`, "invalid schema: levels.track version 2 is not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTrackFromJSON([]byte(tt.data))
+			require.Error(err, "Track.UnmarshalJSON() did not return an error")
+			require.Equal(tt.err, err.Error())
+		})
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		var track Track
+		err := track.UnmarshalJSON(nil)
+		require.Error(err, "Track.UnmarshalJSON() did not return an error")
+		require.Equal("Track.UnmarshalJSON(): data was nil", err.Error())
+	})
+}
+
+func TestTrackTyped(t *testing.T) {
+	require := require.New(t)
+	track := newTrack(t, 1000)
+
+	data, err := incrementers.MarshalTyped(track)
+	require.NoError(err, "MarshalTyped() returned an error: %s", err)
+
+	var got incrementers.Typed
+	require.NoError(json.Unmarshal(data, &got))
+	require.Equal(TypeTrack, got.Type)
+	require.Equal(3, got.Value.(*Track).Level())
+}
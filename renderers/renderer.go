@@ -0,0 +1,45 @@
+// Package renderers draws incrementers.Clock values as SVG pie clocks and as Unicode or ASCII text
+// for chat bots. A clock is drawn as segments, one per step unless the renderer sets its own count,
+// and a segment is filled once the clock has completely passed it.
+package renderers
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/chadeldridge/rpgtools/incrementers"
+)
+
+// MaxSegments is the most segments a renderer draws. A clock with more steps is drawn as
+// MaxSegments segments.
+const MaxSegments = 100
+
+// Renderer draws a Clock.
+type Renderer interface {
+	Render(c incrementers.Clock) string
+}
+
+// segments returns the number of segments to draw and how many of them are filled. n of less than 1
+// draws one segment per step of the clock, and n is limited to MaxSegments. A Clock which is not an
+// incrementers.Progress is filled from its value and max.
+func segments(c incrementers.Clock, n int) (total, filled int) {
+	if n < 1 {
+		n = c.Max()
+	}
+
+	n = incrementers.Clamp(n, 1, MaxSegments)
+	if p, ok := c.(incrementers.Progress); ok {
+		return n, p.Segments(n)
+	}
+
+	if c.Max() < 1 {
+		return n, 0
+	}
+
+	v := big.NewInt(int64(incrementers.Clamp(c.Value(), 0, c.Max())))
+	v.Mul(v, big.NewInt(int64(n)))
+	return n, int(v.Quo(v, big.NewInt(int64(c.Max()))).Int64())
+}
+
+// count returns the value and max of the clock, such as "3/8".
+func count(c incrementers.Clock) string { return fmt.Sprintf("%d/%d", c.Value(), c.Max()) }
@@ -0,0 +1,62 @@
+package renderers
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chadeldridge/rpgtools/incrementers"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// golden compares got with testdata/name, or writes it there when the -update flag is set.
+func golden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o644))
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run go test ./renderers -update to create %s", path)
+	require.Equal(t, string(want), got)
+}
+
+func TestSegments(t *testing.T) {
+	require := require.New(t)
+
+	total, filled := segments(incrementers.NewClockWithTicks(8, 3), 0)
+	require.Equal(8, total)
+	require.Equal(3, filled)
+
+	total, filled = segments(incrementers.NewClockWithTicks(8, 3), 4)
+	require.Equal(4, total)
+	require.Equal(1, filled)
+
+	total, filled = segments(incrementers.NewClock(0), 0)
+	require.Equal(1, total)
+	require.Equal(0, filled)
+
+	total, filled = segments(plainClock{incrementers.NewClockWithTicks(8, 7)}, 4)
+	require.Equal(4, total)
+	require.Equal(3, filled)
+
+	huge := incrementers.NewClockWithTicks(1<<40, 1<<39)
+	total, filled = segments(huge, 0)
+	require.Equal(MaxSegments, total)
+	require.Equal(MaxSegments/2, filled)
+
+	total, filled = segments(plainClock{huge}, 1<<40)
+	require.Equal(MaxSegments, total)
+	require.Equal(MaxSegments/2, filled)
+}
+
+// plainClock is a Clock which is not an incrementers.Progress.
+type plainClock struct{ incrementers.Clock }
+
+func TestCount(t *testing.T) {
+	require.Equal(t, "3/8", count(incrementers.NewClockWithTicks(8, 3)))
+}
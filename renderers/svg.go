@@ -0,0 +1,101 @@
+package renderers
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/chadeldridge/rpgtools/incrementers"
+)
+
+// SVGRenderer draws a Clock as an SVG pie chart with one wedge per segment, starting at the top and
+// filling clockwise. Start from NewSVGRenderer so every field has a usable value.
+type SVGRenderer struct {
+	Radius      float64 // Radius of the pie in pixels.
+	StrokeWidth float64 // Width of the lines between segments.
+	Filled      string  // Color of filled segments.
+	Empty       string  // Color of empty segments.
+	Stroke      string  // Color of the lines between segments and of the caption.
+	Segments    int     // Number of segments, up to MaxSegments. 0 draws one segment per step.
+	Label       string  // Caption drawn under the pie.
+	ShowCount   bool    // Adds the value and max, such as "3/8", to the caption.
+	FontSize    float64 // Font size of the caption in pixels.
+}
+
+// NewSVGRenderer creates an SVGRenderer which draws a black and white pie with a radius of 50.
+func NewSVGRenderer() SVGRenderer {
+	return SVGRenderer{
+		Radius: 50, StrokeWidth: 2, Filled: "#222222", Empty: "#ffffff", Stroke: "#222222", FontSize: 14,
+	}
+}
+
+// Render draws c as an SVG document.
+func (r SVGRenderer) Render(c incrementers.Clock) string {
+	total, filled := segments(c, r.Segments)
+	center := r.Radius + r.StrokeWidth
+	width := 2 * center
+	height := width
+
+	caption := r.Label
+	if r.ShowCount {
+		caption = strings.TrimSpace(caption + " " + count(c))
+	}
+
+	if caption != "" {
+		height += r.FontSize * 1.5
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %[1]s %[2]s">`+"\n",
+		num(width), num(height))
+
+	for i := 0; i < total; i++ {
+		color := r.Empty
+		if i < filled {
+			color = r.Filled
+		}
+
+		if total == 1 {
+			fmt.Fprintf(&b, `<circle cx="%s" cy="%[1]s" r="%s"`, num(center), num(r.Radius))
+		} else {
+			x1, y1 := r.point(center, i, total)
+			x2, y2 := r.point(center, i+1, total)
+			fmt.Fprintf(&b, `<path d="M%s %[1]s L%s %s A%s %[4]s 0 0 1 %s %s Z"`,
+				num(center), num(x1), num(y1), num(r.Radius), num(x2), num(y2))
+		}
+
+		fmt.Fprintf(&b, ` fill="%s" stroke="%s" stroke-width="%s"/>`+"\n",
+			attr(color), attr(r.Stroke), num(r.StrokeWidth))
+	}
+
+	if caption != "" {
+		fmt.Fprintf(&b,
+			`<text x="%s" y="%s" text-anchor="middle" font-family="sans-serif" font-size="%s" fill="%s">%s</text>`+"\n",
+			num(center), num(width+r.FontSize), num(r.FontSize), attr(r.Stroke), html.EscapeString(caption))
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// point returns the point on the edge of the pie at the start of segment i of total.
+func (r SVGRenderer) point(center float64, i, total int) (float64, float64) {
+	a := 2*math.Pi*float64(i)/float64(total) - math.Pi/2
+	return center + r.Radius*math.Cos(a), center + r.Radius*math.Sin(a)
+}
+
+// num formats f with at most 2 decimal places and no trailing zeros.
+func num(f float64) string {
+	s := strconv.FormatFloat(f, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+
+	return s
+}
+
+// attr escapes s for use in an attribute.
+func attr(s string) string { return html.EscapeString(s) }
@@ -0,0 +1,74 @@
+package renderers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chadeldridge/rpgtools/incrementers"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Renderer = SVGRenderer{}
+
+func TestSVGRendererGolden(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps int
+		ticks int
+		edit  func(r *SVGRenderer)
+	}{
+		{"clock4", 4, 1, nil},
+		{"clock6", 6, 6, nil},
+		{"clock8", 8, 3, nil},
+		{"clock12", 12, 0, nil},
+		{"single", 1, 1, nil},
+		{"styled", 8, 5, func(r *SVGRenderer) {
+			r.Radius = 30
+			r.StrokeWidth = 1.5
+			r.Filled = "crimson"
+			r.Empty = "#eeeeee"
+			r.Stroke = "black"
+			r.Segments = 4
+			r.Label = "Rivals <3"
+			r.ShowCount = true
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewSVGRenderer()
+			if tt.edit != nil {
+				tt.edit(&r)
+			}
+
+			got := r.Render(incrementers.NewClockWithTicks(tt.steps, tt.ticks))
+			require.NoError(t, xml.Unmarshal([]byte(got), new(any)), "invalid SVG")
+			golden(t, tt.name+".svg.golden", got)
+		})
+	}
+}
+
+func TestSVGRenderer(t *testing.T) {
+	require := require.New(t)
+	r := NewSVGRenderer()
+	r.Filled = "red"
+	got := r.Render(incrementers.NewClockWithTicks(6, 2))
+	require.Equal(6, strings.Count(got, "<path"))
+	require.Equal(2, strings.Count(got, `fill="red"`))
+	require.NotContains(got, "<text")
+
+	r.Label = `"Doom" & gloom`
+	got = r.Render(incrementers.NewClockWithTicks(6, 2))
+	require.Contains(got, `>&#34;Doom&#34; &amp; gloom</text>`)
+	require.Contains(got, fmt.Sprintf(`height="%s"`, num(104+14*1.5)))
+}
+
+func TestNum(t *testing.T) {
+	require := require.New(t)
+	require.Equal("50", num(50))
+	require.Equal("85.36", num(85.355339))
+	require.Equal("0.5", num(0.5))
+	require.Equal("0", num(-0.0001))
+}
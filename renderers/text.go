@@ -0,0 +1,51 @@
+package renderers
+
+import (
+	"strings"
+
+	"github.com/chadeldridge/rpgtools/incrementers"
+)
+
+// TextRenderer draws a Clock as a row of filled and empty segments such as "●●●○○○○○ 3/8".
+type TextRenderer struct {
+	Filled    string // Drawn for each filled segment.
+	Empty     string // Drawn for each empty segment.
+	Open      string // Drawn before the segments.
+	Close     string // Drawn after the segments.
+	Segments  int    // Number of segments, up to MaxSegments. 0 draws one segment per step.
+	Label     string // Drawn before the segments, followed by a space.
+	ShowCount bool   // Draws the value and max, such as "3/8", after the segments.
+}
+
+// NewUnicodeRenderer creates a TextRenderer which draws "●●●○○○○○ 3/8".
+func NewUnicodeRenderer() TextRenderer {
+	return TextRenderer{Filled: "●", Empty: "○", ShowCount: true}
+}
+
+// NewASCIIRenderer creates a TextRenderer which draws "[###-----] 3/8".
+func NewASCIIRenderer() TextRenderer {
+	return TextRenderer{Filled: "#", Empty: "-", Open: "[", Close: "]", ShowCount: true}
+}
+
+// Render draws c as a single line of text.
+func (r TextRenderer) Render(c incrementers.Clock) string {
+	total, filled := segments(c, r.Segments)
+
+	var b strings.Builder
+	if r.Label != "" {
+		b.WriteString(r.Label)
+		b.WriteString(" ")
+	}
+
+	b.WriteString(r.Open)
+	b.WriteString(strings.Repeat(r.Filled, filled))
+	b.WriteString(strings.Repeat(r.Empty, total-filled))
+	b.WriteString(r.Close)
+
+	if r.ShowCount {
+		b.WriteString(" ")
+		b.WriteString(count(c))
+	}
+
+	return b.String()
+}
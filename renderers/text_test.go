@@ -0,0 +1,53 @@
+package renderers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chadeldridge/rpgtools/incrementers"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Renderer = TextRenderer{}
+
+func TestTextRenderer(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("unicode", func(t *testing.T) {
+		require.Equal("●●●○○○○○ 3/8", NewUnicodeRenderer().Render(incrementers.NewClockWithTicks(8, 3)))
+	})
+
+	t.Run("ascii", func(t *testing.T) {
+		require.Equal("[###-----] 3/8", NewASCIIRenderer().Render(incrementers.NewClockWithTicks(8, 3)))
+	})
+
+	t.Run("label", func(t *testing.T) {
+		r := NewASCIIRenderer()
+		r.Label = "Doom"
+		r.Segments = 4
+		r.ShowCount = false
+		require.Equal("Doom [#---]", r.Render(incrementers.NewClockWithTicks(8, 3)))
+	})
+}
+
+func TestTextRendererGolden(t *testing.T) {
+	for _, style := range []struct {
+		name string
+		r    TextRenderer
+	}{
+		{"unicode", NewUnicodeRenderer()},
+		{"ascii", NewASCIIRenderer()},
+	} {
+		t.Run(style.name, func(t *testing.T) {
+			var b strings.Builder
+			for _, steps := range []int{4, 6, 8, 12} {
+				for ticks := 0; ticks <= steps; ticks++ {
+					fmt.Fprintln(&b, style.r.Render(incrementers.NewClockWithTicks(steps, ticks)))
+				}
+			}
+
+			golden(t, style.name+".golden", b.String())
+		})
+	}
+}
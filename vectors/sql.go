@@ -0,0 +1,104 @@
+package vectors
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Value returns the JSON representation of the vector for a database column.
+func (v Vector2) Value() (driver.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan reads the vector from a database column holding JSON or text such as "(1, 2)". NULL leaves the
+// vector unchanged.
+func (v *Vector2) Scan(src any) error {
+	data, err := columnBytes("Vector2", src)
+	if err != nil || data == nil {
+		return err
+	}
+
+	if data[0] == '{' {
+		var n Vector2
+		if err := json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+
+		*v = n
+		return nil
+	}
+
+	var x, y float64
+	if _, err := fmt.Sscanf(string(data), "(%f,%f)", &x, &y); err != nil {
+		return fmt.Errorf("invalid Vector2: %w", err)
+	}
+
+	v.X, v.Y = x, y
+	return nil
+}
+
+// Value returns the JSON representation of the vector for a database column.
+func (v Vector3) Value() (driver.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan reads the vector from a database column holding JSON or text such as "(1, 2, 3)". NULL leaves
+// the vector unchanged.
+func (v *Vector3) Scan(src any) error {
+	data, err := columnBytes("Vector3", src)
+	if err != nil || data == nil {
+		return err
+	}
+
+	if data[0] == '{' {
+		var n Vector3
+		if err := json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+
+		*v = n
+		return nil
+	}
+
+	var x, y, z float64
+	if _, err := fmt.Sscanf(string(data), "(%f,%f,%f)", &x, &y, &z); err != nil {
+		return fmt.Errorf("invalid Vector3: %w", err)
+	}
+
+	v.X, v.Y, v.Z = x, y, z
+	return nil
+}
+
+// columnBytes returns a column value as bytes with spaces removed, or nil for NULL.
+func columnBytes(typ string, src any) ([]byte, error) {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	default:
+		return nil, fmt.Errorf("invalid %s: cannot scan %T", typ, src)
+	}
+
+	data = bytes.Join(bytes.Fields(data), nil)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("invalid %s: column was empty", typ)
+	}
+
+	return data, nil
+}
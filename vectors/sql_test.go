@@ -0,0 +1,89 @@
+package vectors
+
+import (
+	"testing"
+
+	"github.com/chadeldridge/rpgtools/internal/sqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLRoundTrip(t *testing.T) {
+	require := require.New(t)
+	db, err := sqltest.Open(t.Name())
+	require.NoError(err, "sqltest.Open() returned an error: %s", err)
+	defer db.Close()
+
+	v2 := NewVector2(1.5, -2)
+	v3 := NewVector3(1, 2.25, -3)
+	_, err = db.Exec("INSERT INTO tokens VALUES (?, ?)", v2, v3)
+	require.NoError(err, "db.Exec() returned an error: %s", err)
+
+	var got2 Vector2
+	var got3 Vector3
+	require.NoError(db.QueryRow("SELECT * FROM tokens").Scan(&got2, &got3))
+	require.Equal(v2, got2)
+	require.Equal(v3, got3)
+}
+
+func TestVector2SQL(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("value", func(t *testing.T) {
+		v, err := NewVector2(1.5, -2).Value()
+		require.NoError(err, "Vector2.Value() returned an error: %s", err)
+		require.Equal(`{"x":1.5,"y":-2}`, v)
+	})
+
+	t.Run("scan text", func(t *testing.T) {
+		var v Vector2
+		require.NoError(v.Scan([]byte("(1.5, -2)")))
+		require.Equal(NewVector2(1.5, -2), v)
+	})
+
+	t.Run("scan null", func(t *testing.T) {
+		v := NewVector2(1, 2)
+		require.NoError(v.Scan(nil))
+		require.Equal(NewVector2(1, 2), v)
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		var v Vector2
+		require.Error(v.Scan("(1.5)"))
+
+		err := v.Scan(4.5)
+		require.Error(err, "Vector2.Scan() did not return an error")
+		require.Equal("invalid Vector2: cannot scan float64", err.Error())
+
+		err = v.Scan(" ")
+		require.Error(err, "Vector2.Scan() did not return an error")
+		require.Equal("invalid Vector2: column was empty", err.Error())
+	})
+}
+
+func TestVector3SQL(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("value", func(t *testing.T) {
+		v, err := NewVector3(1, 2, 3).Value()
+		require.NoError(err, "Vector3.Value() returned an error: %s", err)
+		require.Equal(`{"x":1.000000,"y":2.000000,"z":3.000000}`, v)
+	})
+
+	t.Run("scan text", func(t *testing.T) {
+		var v Vector3
+		require.NoError(v.Scan("(1, 2, 3)"))
+		require.Equal(NewVector3(1, 2, 3), v)
+	})
+
+	t.Run("scan json", func(t *testing.T) {
+		var v Vector3
+		require.NoError(v.Scan(`{"x": 1, "y": 2, "z": 3}`))
+		require.Equal(NewVector3(1, 2, 3), v)
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		var v Vector3
+		require.Error(v.Scan("(1, 2)"))
+		require.Error(v.Scan(`{"x":1}`))
+	})
+}